@@ -13,6 +13,7 @@ import (
 	"context"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-virtual-kubelet/internal/config"
 	"github.com/aws/aws-virtual-kubelet/internal/k8sutils"
@@ -36,6 +37,9 @@ var (
 	k8sVersion   = "v1.19.10" // This should follow the version of k8s.io/client-go we are importing
 )
 
+// shutdownTimeout is how long to wait for the provider's goroutines to exit on shutdown
+const shutdownTimeout = 30 * time.Second
+
 //nolint:funlen
 func main() {
 	ctx := cli.ContextWithCancelOnSignal(context.Background())
@@ -105,13 +109,17 @@ func main() {
 
 			// rehydrate cache prior to starting provider (or k8s will just ask us _right back_ for the list)
 			k8sClient, err := k8sutils.NewK8sClient(o.KubeConfigPath)
-			podList, err := k8sClient.GetPods(context.TODO(), p.NodeName)
+			podList, err := k8sClient.GetPods(ctx, p.NodeName)
 			if err != nil {
 				return err
 			}
 			podCache := ec2provider.NewPodCache()
 			// populate pod cache from k8s pod list
 			podCache.Populate(podList)
+			// reconcile with pod instances found via EC2 tags (k8s may have forgotten pods while we were down)
+			if err := p.ReconcileCacheWithInstances(ctx, podCache); err != nil {
+				log.G(ctx).Errorf("Unable to reconcile pod cache with EC2 instances (using k8s pods only): %v", err)
+			}
 			// update provider so it starts with the cache pre-loaded (before k8s asks us for it)
 			p.PopulateCache(podCache)
 
@@ -141,7 +149,18 @@ func main() {
 	}
 
 	// run the CLI command which starts VK processing / handling
-	if err := cliCommand.Run(ctx); err != nil {
-		log.G(ctx).Fatal(err)
+	runErr := cliCommand.Run(ctx)
+
+	// stop the provider's goroutines (the signal context is already cancelled, so use a fresh one for the wait)
+	if p != nil {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		if err := p.Shutdown(shutdownCtx); err != nil {
+			log.G(ctx).Errorf("Provider did not shut down cleanly: %v", err)
+		}
+		cancel()
+	}
+
+	if runErr != nil {
+		log.G(ctx).Fatal(runErr)
 	}
 }
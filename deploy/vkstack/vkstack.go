@@ -77,6 +77,15 @@ func NewVKStack(scope constructs.Construct, id string, props *VKStackProps) awsc
 			"ec2:DescribeInstances",         // needed to get EC2 information and status
 			"ec2:TerminateInstances",        // needed to remove pod and warm pool instances
 			"ec2:CreateTags",                // needed to tag pod and warm pool instances
+			"ec2:DescribeInstanceTypes",     // needed to subtract pod instance resources from node allocatable
+			"servicequotas:GetServiceQuota", // needed to limit node CPU capacity to vCPU quotas
+
+			// needed to apply changes to pod annotations to running instances (see UpdatePod)
+			"ec2:DeleteTags",
+			"ec2:DescribeSecurityGroups",
+			"ec2:ModifyInstanceAttribute",
+			"ec2:DescribeIamInstanceProfileAssociations",
+			"ec2:ReplaceIamInstanceProfileAssociation",
 		),
 		// TODO add Tag or other conditions to limit `DeleteNetworkInterface` and `TerminateInstances` to those created
 		//  by virtual-kubelet
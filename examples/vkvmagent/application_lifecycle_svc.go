@@ -14,8 +14,13 @@ import (
 	"fmt"
 	"log"
 	"math/rand"
+	"strconv"
+	"sync"
 	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	corev1 "k8s.io/api/core/v1"
@@ -25,6 +30,41 @@ import (
 
 type applicationLifecycleServer struct {
 	pb.UnimplementedApplicationLifecycleServer
+
+	// logs captures the output of the pod's containers (served via StreamLogs)
+	logs *logStore
+
+	mu sync.RWMutex
+	// containers are the names of the containers in the most recently launched pod
+	containers []string
+	// launchTime is when the most recently launched pod was launched
+	launchTime time.Time
+	// completions are the simulated exits of containers that run to completion, keyed by container name
+	completions map[string]completion
+
+	// stats samples resource usage (served via GetStats)
+	stats *statsCollector
+}
+
+// Container environment variables used to simulate containers that run to completion (e.g. Jobs)
+const (
+	// exitAfterEnv is how long (in seconds) the container runs before exiting
+	exitAfterEnv = "EXAMPLE_EXIT_AFTER_SECONDS"
+	// exitCodeEnv is the code the container exits with (default 0)
+	exitCodeEnv = "EXAMPLE_EXIT_CODE"
+)
+
+// completion is a container's simulated exit
+type completion struct {
+	after    time.Duration
+	exitCode int32
+}
+
+func newApplicationLifecycleServer() *applicationLifecycleServer {
+	return &applicationLifecycleServer{
+		logs:  newLogStore(*maxLogLines),
+		stats: newStatsCollector(),
+	}
 }
 
 func (a *applicationLifecycleServer) LaunchApplication(
@@ -33,15 +73,76 @@ func (a *applicationLifecycleServer) LaunchApplication(
 	// TODO implement LaunchApplication behavior here
 	log.Printf("Pod size is %d", request.GetPod().Size())
 
+	var containers []string
+	completions := map[string]completion{}
+	for _, container := range request.GetPod().Spec.Containers {
+		containers = append(containers, container.Name)
+		if c, ok := containerCompletion(container); ok {
+			completions[container.Name] = c
+		}
+	}
+
+	a.mu.Lock()
+	a.containers = containers
+	a.launchTime = time.Now()
+	a.completions = completions
+	a.mu.Unlock()
+
+	for _, container := range containers {
+		// a (re)launch starts a new instance of each container, so the existing output becomes the "previous" log
+		a.logs.restart(container)
+		a.logs.write(container, "Launched container %v for pod %v(%v)",
+			container, request.GetPod().Name, request.GetPod().Namespace)
+	}
+
 	return &pb.LaunchApplicationResponse{}, nil
 }
 
 func (a *applicationLifecycleServer) TerminateApplication(
 	ctx context.Context, request *pb.TerminateApplicationRequest) (*pb.TerminateApplicationResponse, error) {
 	log.Printf("TerminateApplication invoked: %v", request)
-	// TODO implement TerminateApplication behavior here
+	// TODO implement actual TerminateApplication behavior here (example below only simulates running preStop handlers
+	//  and stopping containers)
+
+	gracePeriod := time.Duration(request.GetGracePeriodSeconds()) * time.Second
+	deadline := time.Now().Add(gracePeriod)
+
+	a.mu.RLock()
+	containers := append([]string(nil), a.containers...)
+	launchTime := a.launchTime
+	a.mu.RUnlock()
+
+	var containerStatuses []*corev1.ContainerStatus
+	for _, container := range containers {
+		terminated := &corev1.ContainerStateTerminated{
+			ExitCode:   0,
+			Reason:     "Completed",
+			StartedAt:  metav1.NewTime(launchTime),
+			FinishedAt: metav1.Now(),
+		}
 
-	return &pb.TerminateApplicationResponse{}, nil
+		if handler, ok := request.GetPreStop()[container]; ok {
+			a.logs.write(container, "Running preStop handler: %v", handler)
+		}
+
+		if ctx.Err() != nil || time.Now().After(deadline) {
+			// out of time, so the container is killed
+			terminated.ExitCode = 137
+			terminated.Reason = "Error"
+			terminated.Message = "Killed after termination grace period"
+		}
+
+		a.logs.write(container, "Stopped container %v (exit code %d)", container, terminated.ExitCode)
+
+		containerStatuses = append(containerStatuses, &corev1.ContainerStatus{
+			Name:  container,
+			State: corev1.ContainerState{Terminated: terminated},
+		})
+	}
+
+	return &pb.TerminateApplicationResponse{
+		ContainerStatuses: containerStatuses,
+	}, nil
 }
 
 func (a *applicationLifecycleServer) CheckApplicationHealth(
@@ -72,8 +173,9 @@ func (a *applicationLifecycleServer) WatchApplicationHealth(
 			log.Printf("Sleeping for %d seconds...", sleepTime)
 			time.Sleep(time.Duration(sleepTime) * time.Second)
 			appResponse = &pb.ApplicationHealthResponse{
-				PodStatus: happyPodStatus(fmt.Sprintf("Slept for %d seconds...", sleepTime)),
+				PodStatus: a.podStatus(fmt.Sprintf("Slept for %d seconds...", sleepTime)),
 			}
+			a.writeAll("Slept for %d seconds...", sleepTime)
 			log.Printf("Sending app response: %+v", appResponse)
 			if err := stream.Send(appResponse); err != nil {
 
@@ -90,6 +192,162 @@ func (a *applicationLifecycleServer) WatchApplicationHealth(
 	return nil
 }
 
+func (a *applicationLifecycleServer) StreamLogs(
+	request *pb.StreamLogsRequest, stream pb.ApplicationLifecycle_StreamLogsServer) error {
+	log.Printf("StreamLogs invoked: %v", request)
+
+	container := request.GetContainerName()
+	opts := request.GetOptions()
+
+	if !a.logs.exists(container) {
+		return status.Errorf(codes.NotFound, "container %v not found", container)
+	}
+
+	// a previous container instance can't produce more output, so there is nothing to follow
+	follow := opts.GetFollow() && !opts.GetPrevious()
+
+	lines, updates, unsubscribe := a.logs.read(container, opts.GetPrevious(), follow)
+	defer unsubscribe()
+
+	filter := newLogFilter(opts)
+
+	for _, line := range filter.initial(lines) {
+		content, more := filter.format(line)
+		if err := stream.Send(&pb.StreamLogsResponse{Content: content}); err != nil {
+			log.Printf("Error sending log content: %v", err)
+			return err
+		}
+		if !more {
+			return nil
+		}
+	}
+
+	if !follow {
+		return nil
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			log.Printf("StreamLogs for container %v ended by client", container)
+			return nil
+		case line := <-updates:
+			content, more := filter.format(line)
+			if err := stream.Send(&pb.StreamLogsResponse{Content: content}); err != nil {
+				log.Printf("Error sending log content: %v", err)
+				return err
+			}
+			if !more {
+				return nil
+			}
+		}
+	}
+}
+
+func (a *applicationLifecycleServer) ResetInstance(
+	ctx context.Context, request *pb.ResetInstanceRequest) (*pb.ResetInstanceResponse, error) {
+	log.Printf("ResetInstance invoked: %v", request)
+	// TODO implement actual ResetInstance behavior here (e.g. kill leftover processes, remove the previous
+	//  application's files, users, and keychains); the example below only forgets the previous pod
+
+	a.mu.Lock()
+	a.containers = nil
+	a.launchTime = time.Time{}
+	a.completions = nil
+	a.mu.Unlock()
+
+	a.logs.reset()
+
+	return &pb.ResetInstanceResponse{}, nil
+}
+
+// writeAll writes a line to the log of every container in the launched pod
+func (a *applicationLifecycleServer) writeAll(format string, args ...interface{}) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	for _, container := range a.containers {
+		a.logs.write(container, format, args...)
+	}
+}
+
+// containerCompletion returns the simulated exit configured in a container's environment (if any)
+func containerCompletion(container corev1.Container) (completion, bool) {
+	var c completion
+	found := false
+
+	for _, env := range container.Env {
+		switch env.Name {
+		case exitAfterEnv:
+			seconds, err := strconv.Atoi(env.Value)
+			if err != nil {
+				log.Printf("Ignoring invalid %v for container %v: %v", exitAfterEnv, container.Name, err)
+				continue
+			}
+			c.after = time.Duration(seconds) * time.Second
+			found = true
+		case exitCodeEnv:
+			code, err := strconv.ParseInt(env.Value, 10, 32)
+			if err != nil {
+				log.Printf("Ignoring invalid %v for container %v: %v", exitCodeEnv, container.Name, err)
+				continue
+			}
+			c.exitCode = int32(code)
+		}
+	}
+
+	return c, found
+}
+
+// podStatus returns a happy pod status for the launched pod's containers, except that containers that run to
+//
+//	completion are reported as terminated (with their exit code) once they have exited
+func (a *applicationLifecycleServer) podStatus(message string) *corev1.PodStatus {
+	podStatus := happyPodStatus(message)
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	// nothing launched yet
+	if len(a.containers) == 0 {
+		return podStatus
+	}
+
+	var containerStatuses []corev1.ContainerStatus
+	for _, container := range a.containers {
+		containerStatus := corev1.ContainerStatus{
+			Name:        container,
+			State:       corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(a.launchTime)}},
+			Ready:       true,
+			Image:       "image",
+			ImageID:     "image-id",
+			ContainerID: "container-id",
+		}
+
+		if c, ok := a.completions[container]; ok && time.Since(a.launchTime) >= c.after {
+			reason := "Completed"
+			if c.exitCode != 0 {
+				reason = "Error"
+			}
+			containerStatus.Ready = false
+			containerStatus.State = corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{
+					ExitCode:   c.exitCode,
+					Reason:     reason,
+					StartedAt:  metav1.NewTime(a.launchTime),
+					FinishedAt: metav1.NewTime(a.launchTime.Add(c.after)),
+				},
+			}
+		}
+
+		containerStatuses = append(containerStatuses, containerStatus)
+	}
+
+	podStatus.ContainerStatuses = containerStatuses
+
+	return podStatus
+}
+
 func happyPodStatus(message string) *corev1.PodStatus {
 	happyConditions := []corev1.PodCondition{
 		{
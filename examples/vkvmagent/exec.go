@@ -0,0 +1,222 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package main
+
+import (
+	"errors"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"sync"
+
+	"github.com/creack/pty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/aws/aws-virtual-kubelet/proto/vkvmagent/v0"
+)
+
+// ttyEOF is the terminal EOF character (Ctrl-D), written to a TTY when the client's stdin is closed
+const ttyEOF = 0x04
+
+// execSession is a single command run via Exec.  This reference implementation runs the command as a local process on
+//
+//	the instance; a real agent would run it inside the container's environment instead.
+type execSession struct {
+	stream pb.ApplicationLifecycle_ExecServer
+	// sendMu serializes sends on the stream (output and exit status are sent from different goroutines)
+	sendMu sync.Mutex
+}
+
+func (s *execSession) send(resp *pb.ExecResponse) error {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+
+	return s.stream.Send(resp)
+}
+
+// outputWriter sends anything written to it as stdout or stderr frames
+type outputWriter struct {
+	session *execSession
+	stderr  bool
+}
+
+func (w *outputWriter) Write(p []byte) (int, error) {
+	// gRPC may hold on to the message after Send returns, so the content must be copied
+	content := append([]byte(nil), p...)
+
+	resp := &pb.ExecResponse{Response: &pb.ExecResponse_Stdout{Stdout: content}}
+	if w.stderr {
+		resp = &pb.ExecResponse{Response: &pb.ExecResponse_Stderr{Stderr: content}}
+	}
+
+	if err := w.session.send(resp); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (a *applicationLifecycleServer) Exec(stream pb.ApplicationLifecycle_ExecServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	start := req.GetStart()
+	if start == nil {
+		return status.Error(codes.InvalidArgument, "first exec request must be a start request")
+	}
+	log.Printf("Exec invoked: %v", start)
+
+	if !a.logs.exists(start.GetContainerName()) {
+		return status.Errorf(codes.NotFound, "container %v not found", start.GetContainerName())
+	}
+
+	if len(start.GetCommand()) == 0 {
+		return status.Error(codes.InvalidArgument, "no command specified")
+	}
+
+	session := &execSession{stream: stream}
+
+	// the command is killed if the client goes away (which cancels the stream context)
+	cmd := exec.CommandContext(stream.Context(), start.GetCommand()[0], start.GetCommand()[1:]...)
+
+	var wait func() error
+	if start.GetTty() {
+		wait, err = session.runTTY(cmd)
+	} else {
+		wait, err = session.run(cmd, start)
+	}
+	if err != nil {
+		return err
+	}
+
+	exitCode, message := exitStatus(wait())
+	log.Printf("Exec of %v exited with code %d", start.GetCommand(), exitCode)
+
+	return session.send(&pb.ExecResponse{
+		Response: &pb.ExecResponse_Exit{Exit: &pb.ExecExit{ExitCode: exitCode, Message: message}},
+	})
+}
+
+// run starts a command with separate stdin/stdout/stderr streams and returns a function that waits for it to exit
+func (s *execSession) run(cmd *exec.Cmd, start *pb.ExecStart) (func() error, error) {
+	if start.GetStdout() {
+		cmd.Stdout = &outputWriter{session: s}
+	}
+	if start.GetStderr() {
+		cmd.Stderr = &outputWriter{session: s, stderr: true}
+	}
+
+	var stdin io.WriteCloser
+	if start.GetStdin() {
+		var err error
+		if stdin, err = cmd.StdinPipe(); err != nil {
+			return nil, status.Errorf(codes.Internal, "unable to create stdin pipe: %v", err)
+		}
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "unable to start command: %v", err)
+	}
+
+	if stdin != nil {
+		go s.receive(stdin, nil)
+	}
+
+	// cmd.Wait also waits for all output to be copied (and therefore sent)
+	return cmd.Wait, nil
+}
+
+// runTTY starts a command attached to a new pseudo-terminal and returns a function that waits for it to exit.  Stderr
+//
+//	is merged into stdout, as it is with a real terminal.
+func (s *execSession) runTTY(cmd *exec.Cmd) (func() error, error) {
+	tty, err := pty.Start(cmd)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "unable to start command: %v", err)
+	}
+
+	output := make(chan struct{})
+	go func() {
+		defer close(output)
+		// the read fails (with EIO on Linux) once the command exits and closes the terminal
+		_, _ = io.Copy(&outputWriter{session: s}, tty)
+	}()
+
+	go s.receive(tty, tty)
+
+	return func() error {
+		err := cmd.Wait()
+		// all output must be sent before the exit status
+		<-output
+		_ = tty.Close()
+		return err
+	}, nil
+}
+
+// receive handles stdin and resize requests from the client until the stream ends.  When tty is set, stdin close is
+//
+//	signalled by writing EOF to the terminal rather than closing it.
+func (s *execSession) receive(stdin io.WriteCloser, tty *os.File) {
+	closeStdin := func() {
+		if tty != nil {
+			_, _ = tty.Write([]byte{ttyEOF})
+			return
+		}
+		_ = stdin.Close()
+	}
+
+	for {
+		req, err := s.stream.Recv()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				log.Printf("Exec stream receive ended: %v", err)
+			}
+			closeStdin()
+			return
+		}
+
+		switch r := req.GetRequest().(type) {
+		case *pb.ExecRequest_Stdin:
+			if _, err := stdin.Write(r.Stdin); err != nil {
+				log.Printf("Error writing exec stdin: %v", err)
+			}
+		case *pb.ExecRequest_StdinClosed:
+			closeStdin()
+		case *pb.ExecRequest_Resize:
+			if tty == nil {
+				continue
+			}
+			if err := pty.Setsize(tty, &pty.Winsize{
+				Cols: uint16(r.Resize.GetWidth()),
+				Rows: uint16(r.Resize.GetHeight()),
+			}); err != nil {
+				log.Printf("Error resizing exec terminal: %v", err)
+			}
+		}
+	}
+}
+
+// exitStatus converts the result of waiting on a command to an exit code and message
+func exitStatus(err error) (int32, string) {
+	if err == nil {
+		return 0, ""
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() >= 0 {
+		return int32(exitErr.ExitCode()), ""
+	}
+
+	// the command was killed by a signal (or couldn't be waited on)
+	return 1, err.Error()
+}
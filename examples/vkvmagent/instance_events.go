@@ -0,0 +1,186 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/aws/aws-virtual-kubelet/proto/vkvmagent/v0"
+)
+
+// Instance metadata paths of the notices reported as instance events
+const (
+	imdsTokenPath     = "/latest/api/token"
+	imdsSpotPath      = "/latest/meta-data/spot/instance-action"
+	imdsRebalancePath = "/latest/meta-data/events/recommendations/rebalance"
+)
+
+// imdsTokenTTL is how long IMDSv2 session tokens are requested for
+const imdsTokenTTL = 6 * time.Hour
+
+// spotInstanceAction is the spot interruption notice (see
+//
+//	https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/spot-instance-termination-notices.html)
+type spotInstanceAction struct {
+	Action string    `json:"action"`
+	Time   time.Time `json:"time"`
+}
+
+// rebalanceRecommendation is the rebalance recommendation notice (see
+//
+//	https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/rebalance-recommendations.html)
+type rebalanceRecommendation struct {
+	NoticeTime time.Time `json:"noticeTime"`
+}
+
+func (a *applicationLifecycleServer) WatchInstanceEvents(
+	request *pb.WatchInstanceEventsRequest, stream pb.ApplicationLifecycle_WatchInstanceEventsServer) error {
+	log.Printf("WatchInstanceEvents invoked: %v", request)
+
+	imds := &imdsClient{endpoint: *imdsEndpoint, client: &http.Client{Timeout: 2 * time.Second}}
+
+	ticker := time.NewTicker(*imdsPollInterval)
+	defer ticker.Stop()
+
+	// each notice is sent once per stream
+	sent := map[string]bool{}
+
+	for {
+		for _, event := range imds.instanceEvents(stream.Context()) {
+			key := fmt.Sprintf("%v/%v", event.Type, event.Time.AsTime())
+			if sent[key] {
+				continue
+			}
+
+			log.Printf("Sending instance event: %v", event)
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+			sent[key] = true
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// imdsClient reads notices from the instance metadata service (using IMDSv2)
+type imdsClient struct {
+	endpoint string
+	client   *http.Client
+	token    string
+	expires  time.Time
+}
+
+// instanceEvents returns the current spot interruption and rebalance recommendation notices (if any)
+func (c *imdsClient) instanceEvents(ctx context.Context) []*pb.InstanceEvent {
+	var events []*pb.InstanceEvent
+
+	var action spotInstanceAction
+	if found, err := c.get(ctx, imdsSpotPath, &action); err != nil {
+		log.Printf("Unable to get spot instance action: %v", err)
+	} else if found {
+		events = append(events, &pb.InstanceEvent{
+			Type:   pb.InstanceEvent_SPOT_INTERRUPTION,
+			Time:   timestamppb.New(action.Time),
+			Action: action.Action,
+		})
+	}
+
+	var rebalance rebalanceRecommendation
+	if found, err := c.get(ctx, imdsRebalancePath, &rebalance); err != nil {
+		log.Printf("Unable to get rebalance recommendation: %v", err)
+	} else if found {
+		events = append(events, &pb.InstanceEvent{
+			Type: pb.InstanceEvent_REBALANCE_RECOMMENDATION,
+			Time: timestamppb.New(rebalance.NoticeTime),
+		})
+	}
+
+	return events
+}
+
+// get reads a JSON metadata item into v, returning false if the item doesn't exist (i.e. there's no notice)
+func (c *imdsClient) get(ctx context.Context, path string, v interface{}) (bool, error) {
+	token, err := c.sessionToken(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint+path, nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("X-aws-ec2-metadata-token", token)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, json.NewDecoder(resp.Body).Decode(v)
+	case http.StatusNotFound:
+		return false, nil
+	case http.StatusUnauthorized:
+		// the token expired early (e.g. the instance was stopped and started), so get a new one next time
+		c.token = ""
+		return false, fmt.Errorf("unauthorized reading %v", path)
+	default:
+		return false, fmt.Errorf("unexpected status reading %v: %v", path, resp.Status)
+	}
+}
+
+// sessionToken returns an IMDSv2 session token, requesting a new one if needed
+func (c *imdsClient) sessionToken(ctx context.Context) (string, error) {
+	if c.token != "" && time.Now().Before(c.expires) {
+		return c.token, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, c.endpoint+imdsTokenPath, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-aws-ec2-metadata-token-ttl-seconds", fmt.Sprint(int(imdsTokenTTL.Seconds())))
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status getting IMDS token: %v", resp.Status)
+	}
+
+	token, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	c.token = string(token)
+	// renew the token well before it expires
+	c.expires = time.Now().Add(imdsTokenTTL / 2)
+
+	return c.token, nil
+}
@@ -0,0 +1,194 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	pb "github.com/aws/aws-virtual-kubelet/proto/vkvmagent/v0"
+)
+
+// logLine is a single line of container output and the time it was written
+type logLine struct {
+	timestamp time.Time
+	content   string
+}
+
+// containerLog holds the output of the current and previous instance of a container
+type containerLog struct {
+	current     []logLine
+	previous    []logLine
+	subscribers map[chan logLine]struct{}
+}
+
+// logStore is an in-memory store of container output.  A real agent would likely capture application stdout/stderr
+//
+//	to files (or journald, etc.) instead, but the behavior exposed via StreamLogs would be the same.
+type logStore struct {
+	mu         sync.Mutex
+	containers map[string]*containerLog
+	// maxLines is the number of lines retained per container instance (older lines are discarded)
+	maxLines int
+}
+
+func newLogStore(maxLines int) *logStore {
+	return &logStore{
+		containers: map[string]*containerLog{},
+		maxLines:   maxLines,
+	}
+}
+
+// getOrCreate returns the log for a container, creating it if needed (caller must hold the lock)
+func (ls *logStore) getOrCreate(container string) *containerLog {
+	cl, ok := ls.containers[container]
+	if !ok {
+		cl = &containerLog{subscribers: map[chan logLine]struct{}{}}
+		ls.containers[container] = cl
+	}
+	return cl
+}
+
+// exists returns true if the container has ever been started
+func (ls *logStore) exists(container string) bool {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	_, ok := ls.containers[container]
+	return ok
+}
+
+// write appends a line to a container's current log and sends it to any followers
+func (ls *logStore) write(container string, format string, args ...interface{}) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	cl := ls.getOrCreate(container)
+	line := logLine{timestamp: time.Now(), content: fmt.Sprintf(format, args...) + "\n"}
+
+	cl.current = append(cl.current, line)
+	if len(cl.current) > ls.maxLines {
+		cl.current = cl.current[len(cl.current)-ls.maxLines:]
+	}
+
+	for sub := range cl.subscribers {
+		// never block writers on a slow follower (it will miss lines instead)
+		select {
+		case sub <- line:
+		default:
+		}
+	}
+}
+
+// restart moves a container's current log to its previous log (like a container restart would)
+func (ls *logStore) restart(container string) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	cl := ls.getOrCreate(container)
+	if len(cl.current) > 0 {
+		cl.previous = cl.current
+		cl.current = nil
+	}
+}
+
+// reset discards the logs of all containers (e.g. before the instance is reused for another pod)
+func (ls *logStore) reset() {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	ls.containers = map[string]*containerLog{}
+}
+
+// read returns a copy of a container's current (or previous) log.  If follow is true a channel receiving new lines is
+//
+//	also returned, along with a function that must be called to stop following.
+func (ls *logStore) read(container string, previous bool, follow bool) ([]logLine, chan logLine, func()) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	cl := ls.getOrCreate(container)
+
+	var lines []logLine
+	if previous {
+		lines = append(lines, cl.previous...)
+	} else {
+		lines = append(lines, cl.current...)
+	}
+
+	if !follow {
+		return lines, nil, func() {}
+	}
+
+	sub := make(chan logLine, 100)
+	cl.subscribers[sub] = struct{}{}
+
+	return lines, sub, func() {
+		ls.mu.Lock()
+		defer ls.mu.Unlock()
+		delete(cl.subscribers, sub)
+	}
+}
+
+// logFilter applies ContainerLogOptions to lines of output
+type logFilter struct {
+	opts      *pb.ContainerLogOptions
+	since     time.Time
+	sentBytes int64
+}
+
+func newLogFilter(opts *pb.ContainerLogOptions) *logFilter {
+	lf := &logFilter{opts: opts}
+
+	if opts.GetSinceSeconds() > 0 {
+		lf.since = time.Now().Add(-time.Duration(opts.GetSinceSeconds()) * time.Second)
+	} else if opts.GetSinceTime() != nil {
+		lf.since = opts.GetSinceTime().AsTime()
+	}
+
+	return lf
+}
+
+// initial selects the lines of existing output to send (applying since and tail options)
+func (lf *logFilter) initial(lines []logLine) []logLine {
+	var selected []logLine
+	for _, line := range lines {
+		if !line.timestamp.Before(lf.since) {
+			selected = append(selected, line)
+		}
+	}
+
+	if tail := int(lf.opts.GetTail()); tail > 0 && len(selected) > tail {
+		selected = selected[len(selected)-tail:]
+	}
+
+	return selected
+}
+
+// format renders a line for sending.  The second return value is false once the byte limit (if any) is reached, in
+//
+//	which case the returned content is truncated to fit within the limit.
+func (lf *logFilter) format(line logLine) ([]byte, bool) {
+	content := line.content
+	if lf.opts.GetTimestamps() {
+		content = line.timestamp.UTC().Format(time.RFC3339Nano) + " " + content
+	}
+
+	limit := lf.opts.GetLimitBytes()
+	if limit > 0 && lf.sentBytes+int64(len(content)) >= limit {
+		remaining := limit - lf.sentBytes
+		lf.sentBytes = limit
+		return []byte(content[:remaining]), false
+	}
+
+	lf.sentBytes += int64(len(content))
+	return []byte(content), true
+}
@@ -14,6 +14,7 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"time"
 
 	grpc_health_v1 "github.com/aws/aws-virtual-kubelet/proto/grpc/health/v1"
 
@@ -23,7 +24,12 @@ import (
 )
 
 var (
-	port = flag.Int("port", 8200, "The server port") //nolint:gochecknoglobals
+	port         = flag.Int("port", 8200, "The server port")                                  //nolint:gochecknoglobals
+	maxLogLines  = flag.Int("max-log-lines", 10000, "Lines of output retained per container") //nolint:gochecknoglobals
+	imdsEndpoint = flag.String("imds-endpoint", "http://169.254.169.254",                     //nolint:gochecknoglobals
+		"Instance metadata service endpoint (polled for spot interruption and rebalance notices)")
+	imdsPollInterval = flag.Duration("imds-poll-interval", 5*time.Second, //nolint:gochecknoglobals
+		"How often the instance metadata service is polled for instance events")
 )
 
 func main() {
@@ -42,7 +48,7 @@ func main() {
 	grpcServer := grpc.NewServer(opts...)
 
 	log.Printf("registering ApplicationLifecycleServer")
-	vkvmagent.RegisterApplicationLifecycleServer(grpcServer, &applicationLifecycleServer{})
+	vkvmagent.RegisterApplicationLifecycleServer(grpcServer, newApplicationLifecycleServer())
 
 	log.Printf("creating Health server")
 
@@ -0,0 +1,143 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package main
+
+import (
+	"context"
+	"log"
+	"runtime"
+	"sync"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/aws/aws-virtual-kubelet/proto/vkvmagent/v0"
+)
+
+// statsCollector samples resource usage.  This example reports the agent process' own CPU and memory usage (and the
+//
+//	root filesystem) as a stand-in for the instance, a real agent would report instance-wide usage (e.g. from /proc on
+//	Linux or host_statistics on macOS) along with per-application usage and network interface counters.
+type statsCollector struct {
+	startTime time.Time
+
+	mu sync.Mutex
+	// lastCPUTime and lastSampleTime are used to calculate CPU usage (in cores) between samples
+	lastCPUTime    time.Duration
+	lastSampleTime time.Time
+}
+
+func newStatsCollector() *statsCollector {
+	now := time.Now()
+	return &statsCollector{
+		startTime:      now,
+		lastSampleTime: now,
+	}
+}
+
+// cpu returns CPU usage since the last sample
+func (sc *statsCollector) cpu() (*pb.CpuStats, error) {
+	var usage syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &usage); err != nil {
+		return nil, err
+	}
+	cpuTime := time.Duration(usage.Utime.Nano() + usage.Stime.Nano())
+
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	now := time.Now()
+
+	var nanoCores uint64
+	if elapsed := now.Sub(sc.lastSampleTime); elapsed > 0 {
+		nanoCores = uint64(float64(cpuTime-sc.lastCPUTime) / float64(elapsed) * float64(time.Second))
+	}
+
+	sc.lastCPUTime = cpuTime
+	sc.lastSampleTime = now
+
+	return &pb.CpuStats{
+		Time:                 timestamppb.New(now),
+		UsageNanoCores:       nanoCores,
+		UsageCoreNanoSeconds: uint64(cpuTime.Nanoseconds()),
+	}, nil
+}
+
+func (sc *statsCollector) memory() *pb.MemoryStats {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+
+	return &pb.MemoryStats{
+		Time:            timestamppb.Now(),
+		UsageBytes:      m.Sys,
+		WorkingSetBytes: m.HeapInuse + m.StackInuse,
+	}
+}
+
+// fs returns usage of the filesystem containing path
+func (sc *statsCollector) fs(path string) (*pb.FsStats, error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return nil, err
+	}
+
+	// Bsize is a different type on Linux and macOS
+	blockSize := uint64(st.Bsize)
+
+	return &pb.FsStats{
+		Time:           timestamppb.Now(),
+		AvailableBytes: st.Bavail * blockSize,
+		CapacityBytes:  st.Blocks * blockSize,
+		UsedBytes:      (st.Blocks - st.Bfree) * blockSize,
+		InodesFree:     st.Ffree,
+		Inodes:         st.Files,
+		InodesUsed:     st.Files - st.Ffree,
+	}, nil
+}
+
+func (a *applicationLifecycleServer) GetStats(
+	ctx context.Context, request *pb.GetStatsRequest) (*pb.GetStatsResponse, error) {
+	log.Printf("GetStats invoked: %v", request)
+
+	cpu, err := a.stats.cpu()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to get cpu usage: %v", err)
+	}
+
+	fs, err := a.stats.fs("/")
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to get filesystem usage: %v", err)
+	}
+
+	resp := &pb.GetStatsResponse{
+		Instance: &pb.InstanceStats{
+			StartTime: timestamppb.New(a.stats.startTime),
+			Cpu:       cpu,
+			Memory:    a.stats.memory(),
+			Fs:        fs,
+		},
+	}
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	// this example doesn't run real applications, so only container start times are reported
+	for _, container := range a.containers {
+		resp.Containers = append(resp.Containers, &pb.ContainerStats{
+			Name:      container,
+			StartTime: timestamppb.New(a.launchTime),
+		})
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,152 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package awsutils
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/smithy-go/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+
+	vkconfig "github.com/aws/aws-virtual-kubelet/internal/config"
+	"github.com/aws/aws-virtual-kubelet/internal/metrics"
+	"github.com/aws/aws-virtual-kubelet/internal/poderrors"
+	"github.com/aws/aws-virtual-kubelet/internal/tracing"
+)
+
+// Clients are the AWS service clients shared by the provider.  They're created once (at startup) and reused for every
+//
+//	request, so retry and rate limiting state (and connections) are shared too.
+type Clients struct {
+	EC2           EC2API
+	S3            S3API
+	ServiceQuotas ServiceQuotasAPI
+}
+
+// NewClients creates the shared AWS service clients from the SDK's default config (credentials, etc.) and the
+//
+//	provider's AWS client config
+func NewClients(ctx context.Context) (*Clients, error) {
+	cfg, err := LoadSDKConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Clients{
+		EC2:           newEc2Client(cfg),
+		S3:            newS3Client(cfg),
+		ServiceQuotas: newServiceQuotasClient(cfg),
+	}, nil
+}
+
+// LoadSDKConfig loads the SDK config the shared clients are created from, with the configured timeouts and adaptive
+//
+//	retries (which back off and slow the client's request rate when AWS throttles requests)
+func LoadSDKConfig(ctx context.Context) (aws.Config, error) {
+	// See the following links for an explanation of the timeout options
+	// https://aws.github.io/aws-sdk-go-v2/docs/configuring-sdk/custom-http/
+	// https://aws.github.io/aws-sdk-go-v2/docs/configuring-sdk/retries-timeouts/
+	vkcfg := vkconfig.Config()
+	httpClient := http.NewBuildableClient().WithTimeout(time.Second * time.Duration(vkcfg.AWSClientTimeoutSeconds)).WithDialerOptions(func(d *net.Dialer) {
+		d.KeepAlive = -1
+		d.Timeout = time.Second * time.Duration(vkcfg.AWSClientDialerTimeoutSeconds)
+	})
+
+	cfg, err := config.LoadDefaultConfig(ctx, config.WithHTTPClient(httpClient), config.WithRetryer(newRetryer))
+	if err != nil {
+		return aws.Config{}, fmt.Errorf("unable to load AWS SDK config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// newRetryer creates an adaptive mode retryer with the configured attempts and backoff (zero values leave the SDK
+//
+//	defaults in place)
+func newRetryer() aws.Retryer {
+	clientCfg := vkconfig.Config().AWSClientConfig
+
+	return retry.NewAdaptiveMode(func(o *retry.AdaptiveModeOptions) {
+		o.StandardOptions = append(o.StandardOptions, func(so *retry.StandardOptions) {
+			if clientCfg.MaxAttempts > 0 {
+				so.MaxAttempts = clientCfg.MaxAttempts
+			}
+			if clientCfg.MaxBackoffSeconds > 0 {
+				so.MaxBackoff = time.Duration(clientCfg.MaxBackoffSeconds) * time.Second
+			}
+		})
+	})
+}
+
+// apiOptions are the middleware added to the shared clients' API calls
+var apiOptions = []func(*middleware.Stack) error{
+	addTracingMiddleware,
+	addMetricsMiddleware,
+	addRateLimitMiddleware,
+}
+
+// addTracingMiddleware adds a middleware that traces each API operation (including all attempts) as a span
+func addTracingMiddleware(stack *middleware.Stack) error {
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("APITracing",
+		func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (
+			out middleware.InitializeOutput, metadata middleware.Metadata, err error) {
+			service, operation := awsmiddleware.GetServiceID(ctx), awsmiddleware.GetOperationName(ctx)
+			ctx, span := tracing.Start(ctx, service+"."+operation,
+				semconv.RPCSystemKey.String("aws-api"),
+				semconv.RPCServiceKey.String(service),
+				semconv.RPCMethodKey.String(operation))
+			defer func() { tracing.End(span, err) }()
+
+			out, metadata, err = next.HandleInitialize(ctx, in)
+
+			if requestID, ok := awsmiddleware.GetRequestIDMetadata(metadata); ok {
+				span.SetAttributes(attribute.String("aws.request_id", requestID))
+			}
+			if results, ok := retry.GetAttemptResults(metadata); ok {
+				span.SetAttributes(attribute.Int("aws.attempts", len(results.Results)))
+			}
+
+			return out, metadata, err
+		}), middleware.After)
+}
+
+// addMetricsMiddleware adds a middleware that records each API operation's latency (including all attempts) and the
+//
+//	number of its attempts that were throttled
+func addMetricsMiddleware(stack *middleware.Stack) error {
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("APIMetrics",
+		func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (
+			middleware.InitializeOutput, middleware.Metadata, error) {
+			service, operation := awsmiddleware.GetServiceID(ctx), awsmiddleware.GetOperationName(ctx)
+			start := time.Now()
+
+			out, metadata, err := next.HandleInitialize(ctx, in)
+
+			metrics.AWSAPIRequestSeconds.WithLabelValues(service, operation).Observe(time.Since(start).Seconds())
+			if results, ok := retry.GetAttemptResults(metadata); ok {
+				for _, result := range results.Results {
+					if poderrors.Classify(result.Err) == poderrors.CategoryThrottling {
+						metrics.AWSAPIThrottles.WithLabelValues(service, operation).Inc()
+					}
+				}
+			}
+
+			return out, metadata, err
+		}), middleware.After)
+}
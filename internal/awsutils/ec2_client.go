@@ -11,12 +11,9 @@ package awsutils
 
 import (
 	"context"
-	"errors"
-	"net"
 	"time"
 
-	"github.com/aws/aws-sdk-go-v2/aws/transport/http"
-	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	vkconfig "github.com/aws/aws-virtual-kubelet/internal/config"
 	"k8s.io/klog"
@@ -28,27 +25,12 @@ type Client struct {
 	WaiterSvc *ec2.InstanceRunningWaiter
 }
 
-// NewEc2Client creates a new AWS client in the given region.
-func NewEc2Client() (*Client, error) {
-	// Initialize client session configuration.
-	// See the following links for an explanation of the timeout options
-	// https://aws.github.io/aws-sdk-go-v2/docs/configuring-sdk/custom-http/
-	// https://aws.github.io/aws-sdk-go-v2/docs/configuring-sdk/retries-timeouts/
-	vkcfg := vkconfig.Config()
-	httpClient := http.NewBuildableClient().WithTimeout(time.Second * time.Duration(vkcfg.AWSClientTimeoutSeconds)).WithDialerOptions(func(d *net.Dialer) {
-		d.KeepAlive = -1
-		d.Timeout = time.Second * time.Duration(vkcfg.AWSClientDialerTimeoutSeconds)
+// newEc2Client creates an EC2 client in the configured region from the shared SDK config
+func newEc2Client(cfg aws.Config) *Client {
+	ec2Client := ec2.NewFromConfig(cfg, func(o *ec2.Options) {
+		o.Region = vkconfig.Config().Region
+		o.APIOptions = append(o.APIOptions, apiOptions...)
 	})
-	cfg, err := config.LoadDefaultConfig(context.TODO(), config.WithHTTPClient(httpClient))
-	if err != nil {
-		klog.Fatalf("unable to load SDK config, %v", err)
-		return nil, errors.New("unable to find load SDK config : ")
-	}
-
-	cfg.Region = vkcfg.Region
-
-	// Create the AWS service client.
-	ec2Client := ec2.NewFromConfig(cfg)
 	var options = func(options *ec2.InstanceRunningWaiterOptions) {
 		options.MaxDelay = 15 * time.Second
 		options.MinDelay = 5 * time.Second
@@ -58,7 +40,7 @@ func NewEc2Client() (*Client, error) {
 	return &Client{
 		Svc:       ec2Client,
 		WaiterSvc: waiter,
-	}, nil
+	}
 }
 
 // DescribeNetworkInterfaces retrieves description of Network Interface based on the parameters
@@ -93,6 +75,11 @@ func (client *Client) DescribeInstances(ctx context.Context, params *ec2.Describ
 	return client.Svc.DescribeInstances(ctx, params)
 }
 
+// DescribeInstanceTypes retrieves the details (vCPUs, memory, etc.) of EC2 instance types
+func (client *Client) DescribeInstanceTypes(ctx context.Context, params *ec2.DescribeInstanceTypesInput) (*ec2.DescribeInstanceTypesOutput, error) {
+	return client.Svc.DescribeInstanceTypes(ctx, params)
+}
+
 // DescribeInstanceStatus Describes the status of the specified instances or all of your instances
 func (client *Client) DescribeInstanceStatus(ctx context.Context, params *ec2.DescribeInstanceStatusInput) (*ec2.DescribeInstanceStatusOutput, error) {
 	return client.Svc.DescribeInstanceStatus(ctx, params)
@@ -103,6 +90,11 @@ func (client *Client) CreateTags(ctx context.Context, input *ec2.CreateTagsInput
 	return client.Svc.CreateTags(ctx, input)
 }
 
+// DeleteTags deletes tags from AWS resources based on input specifications.
+func (client *Client) DeleteTags(ctx context.Context, input *ec2.DeleteTagsInput) (*ec2.DeleteTagsOutput, error) {
+	return client.Svc.DeleteTags(ctx, input)
+}
+
 // ModifyInstanceAttribute modifies existing AWS EC2 Attribute based on input
 func (client *Client) ModifyInstanceAttribute(ctx context.Context, input *ec2.ModifyInstanceAttributeInput) (*ec2.ModifyInstanceAttributeOutput, error) {
 	return client.Svc.ModifyInstanceAttribute(ctx, input)
@@ -123,11 +115,36 @@ func (client *Client) ReplaceIamInstanceProfileAssociation(ctx context.Context,
 	return client.Svc.ReplaceIamInstanceProfileAssociation(ctx, input)
 }
 
+// DescribeHosts retrieves information of dedicated hosts based on the parameters
+func (client *Client) DescribeHosts(ctx context.Context, input *ec2.DescribeHostsInput) (*ec2.DescribeHostsOutput, error) {
+	return client.Svc.DescribeHosts(ctx, input)
+}
+
+// AllocateHosts allocates dedicated hosts based on the parameters
+func (client *Client) AllocateHosts(ctx context.Context, input *ec2.AllocateHostsInput) (*ec2.AllocateHostsOutput, error) {
+	return client.Svc.AllocateHosts(ctx, input)
+}
+
+// ReleaseHosts releases dedicated hosts based on the parameters
+func (client *Client) ReleaseHosts(ctx context.Context, input *ec2.ReleaseHostsInput) (*ec2.ReleaseHostsOutput, error) {
+	return client.Svc.ReleaseHosts(ctx, input)
+}
+
+// DescribeSubnets retrieves information of subnets based on the parameters
+func (client *Client) DescribeSubnets(ctx context.Context, input *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
+	return client.Svc.DescribeSubnets(ctx, input)
+}
+
+// DescribeLaunchTemplateVersions retrieves launch template versions based on the parameters
+func (client *Client) DescribeLaunchTemplateVersions(ctx context.Context, input *ec2.DescribeLaunchTemplateVersionsInput) (*ec2.DescribeLaunchTemplateVersionsOutput, error) {
+	return client.Svc.DescribeLaunchTemplateVersions(ctx, input)
+}
+
 // NewInstanceRunningWaiter waits until instance status becomes "running"
-func (client *Client) NewInstanceRunningWaiter(input ec2.DescribeInstancesInput) error {
+func (client *Client) NewInstanceRunningWaiter(ctx context.Context, input ec2.DescribeInstancesInput) error {
 	waiter := client.WaiterSvc
 	maxWaitTime := 60 * time.Second
-	err := waiter.Wait(context.TODO(), &input, maxWaitTime)
+	err := waiter.Wait(ctx, &input, maxWaitTime)
 	if err != nil {
 		klog.Errorf("NewInstanceRunningWaiter: %v", err)
 		return err
@@ -20,7 +20,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"go.opentelemetry.io/otel/attribute"
 	"k8s.io/klog"
+
+	vkconfig "github.com/aws/aws-virtual-kubelet/internal/config"
+	"github.com/aws/aws-virtual-kubelet/internal/poderrors"
+	"github.com/aws/aws-virtual-kubelet/internal/tracing"
 )
 
 // UserData represents the elements of UserData for consumption
@@ -51,6 +56,50 @@ func (client *Client) SecurityGroupNametoID(ctx context.Context, sgNames []strin
 	return sgIDs, err
 }
 
+// RunInstancesOption customizes the RunInstancesInput built by EC2RunInstancesUtil (e.g. to set placement)
+type RunInstancesOption func(input *ec2.RunInstancesInput)
+
+// WithHostPlacement launches an instance on a dedicated host (with host affinity, so the instance stays on the host if
+//
+//	it's stopped and started)
+func WithHostPlacement(hostID string) RunInstancesOption {
+	return func(input *ec2.RunInstancesInput) {
+		input.Placement = &types.Placement{
+			Tenancy:  types.TenancyHost,
+			HostId:   aws.String(hostID),
+			Affinity: aws.String("host"),
+		}
+	}
+}
+
+// WithSpotMarket launches a (one-time) spot instance, which is terminated when EC2 interrupts it
+func WithSpotMarket() RunInstancesOption {
+	return func(input *ec2.RunInstancesInput) {
+		input.InstanceMarketOptions = &types.InstanceMarketOptionsRequest{
+			MarketType: types.MarketTypeSpot,
+			SpotOptions: &types.SpotMarketOptions{
+				SpotInstanceType:             types.SpotInstanceTypeOneTime,
+				InstanceInterruptionBehavior: types.InstanceInterruptionBehaviorTerminate,
+			},
+		}
+	}
+}
+
+// CapacityTypeOptions returns the RunInstances options that launch an instance of the given capacity type (see the
+//
+//	config.CapacityType constants, an empty capacity type is on-demand)
+func CapacityTypeOptions(capacityType string) ([]RunInstancesOption, error) {
+	switch capacityType {
+	case "", vkconfig.CapacityTypeOnDemand:
+		return nil, nil
+	case vkconfig.CapacityTypeSpot:
+		return []RunInstancesOption{WithSpotMarket()}, nil
+	default:
+		return nil, poderrors.Newf(poderrors.CategoryInvalidSpec, "capacity type %q must be %v or %v", capacityType,
+			vkconfig.CapacityTypeOnDemand, vkconfig.CapacityTypeSpot)
+	}
+}
+
 // EC2RunInstancesUtil assists in standardizing RunInstancesInput for all VK work
 func EC2RunInstancesUtil(
 	ctx context.Context,
@@ -62,26 +111,46 @@ func EC2RunInstancesUtil(
 	SubnetID string,
 	Tags []types.TagSpecification,
 	UserData string,
-	client EC2API) (output *ec2.RunInstancesOutput, err error) {
+	client EC2API,
+	optFns ...RunInstancesOption) (output *ec2.RunInstancesOutput, err error) {
 
 	var MinCount, MaxCount int32 = 1, 1
 	input := ec2.RunInstancesInput{
-		IamInstanceProfile: &types.IamInstanceProfileSpecification{
-			Name: aws.String(IAMInstanceProfile),
-		},
-		ImageId:      aws.String(ImageID),
-		InstanceType: types.InstanceType(*aws.String(InstanceType)),
+		InstanceType: types.InstanceType(InstanceType),
 		//Monitoring
 		MaxCount:          &MaxCount,
 		MinCount:          &MinCount,
-		SecurityGroupIds:  SecurityGroupIDs,
-		SubnetId:          aws.String(SubnetID),
 		TagSpecifications: Tags,
 		UserData:          aws.String(UserData),
 	}
+	// empty parameters are left unset, so they can come from a launch template
+	if IAMInstanceProfile != "" {
+		input.IamInstanceProfile = &types.IamInstanceProfileSpecification{
+			Name: aws.String(IAMInstanceProfile),
+		}
+	}
+	if ImageID != "" {
+		input.ImageId = aws.String(ImageID)
+	}
+	for _, sgID := range SecurityGroupIDs {
+		if sgID != "" {
+			input.SecurityGroupIds = append(input.SecurityGroupIds, sgID)
+		}
+	}
+	if SubnetID != "" {
+		input.SubnetId = aws.String(SubnetID)
+	}
 	if KeyName != "" {
 		input.KeyName = aws.String(KeyName)
 	}
+	for _, optFn := range optFns {
+		optFn(&input)
+	}
+
+	if err := validateRunInstancesInput(ctx, client, &input); err != nil {
+		return nil, err
+	}
+
 	resp, err := client.RunInstances(ctx, &input)
 	return resp, err
 }
@@ -100,12 +169,7 @@ func EC2RunInstancesUtil(
 //
 //	userdata: base 64 encoded string with presigned URL to download and initialize the bootstrap agent
 //	err: any error that might occur as part of attempting to generate UserData
-func GenerateVKVMUserData(ctx context.Context, bootstrapS3Bucket string, bootstrapS3Key string, VMInit string, BootstrapAgent string) (userdata string, err error) {
-	s3api, err := NewS3Client()
-	if err != nil {
-		return "", err
-	}
-
+func GenerateVKVMUserData(ctx context.Context, s3api S3API, bootstrapS3Bucket string, bootstrapS3Key string, VMInit string, BootstrapAgent string) (userdata string, err error) {
 	url, err := SignURL(ctx, s3api, &bootstrapS3Bucket, &bootstrapS3Key)
 	if err != nil {
 		return "", err
@@ -131,7 +195,11 @@ func EncodeUserData(userData string) string {
 }
 
 // SignURL will generate a presigned URL for a configured location's key and bucket and return it to caller.
-func SignURL(ctx context.Context, s3Client S3API, presignBucket *string, presignKey *string) (string, error) {
+func SignURL(ctx context.Context, s3Client S3API, presignBucket *string, presignKey *string) (url string, err error) {
+	ctx, span := tracing.Start(ctx, "S3.PresignGetObject", attribute.String("aws.s3.bucket", aws.ToString(presignBucket)),
+		attribute.String("aws.s3.key", aws.ToString(presignKey)))
+	defer func() { tracing.End(span, err) }()
+
 	input := s3.GetObjectInput{
 		Bucket: presignBucket,
 		Key:    presignKey,
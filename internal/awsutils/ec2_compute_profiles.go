@@ -0,0 +1,170 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package awsutils
+
+import (
+	"encoding/json"
+	"math/rand"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws/aws-virtual-kubelet/internal/config"
+	"github.com/aws/aws-virtual-kubelet/internal/poderrors"
+	util "github.com/aws/aws-virtual-kubelet/internal/utils"
+)
+
+// Compute profile annotations
+const (
+	// ProfileAnnotation selects a pod's compute profile (see config.ProviderConfig.ComputeProfiles)
+	ProfileAnnotation = "compute.amazonaws.com/profile"
+	// ResolvedSpecAnnotation records the spec a pod's instance was launched with (set by the provider)
+	ResolvedSpecAnnotation = "compute.amazonaws.com/resolved-spec"
+)
+
+// ComputeSpec is the effective configuration of a pod's instance, resolved from its compute profile and compute
+//
+//	annotations
+type ComputeSpec struct {
+	Profile            string            `json:"profile,omitempty"`
+	ImageID            string            `json:"imageID,omitempty"`
+	InstanceType       string            `json:"instanceType,omitempty"`
+	SubnetID           string            `json:"subnetID,omitempty"`
+	SecurityGroups     []string          `json:"securityGroups,omitempty"`
+	IamInstanceProfile string            `json:"instanceProfile,omitempty"`
+	KeyPair            string            `json:"keyPair,omitempty"`
+	Tags               map[string]string `json:"tags,omitempty"`
+	LaunchTemplate     string            `json:"launchTemplate,omitempty"`
+	CapacityType       string            `json:"capacityType,omitempty"`
+}
+
+// ResolveComputeSpec resolves a pod's compute spec from its compute profile (selected by the profile annotation, or
+//
+//	the default profile) and its compute annotations, which override the profile's settings one by one.  Settings
+//	neither provides are left empty (so they're left unset at launch).
+func ResolveComputeSpec(pod *corev1.Pod) (*ComputeSpec, error) {
+	profileName, profile, err := podComputeProfile(pod)
+	if err != nil {
+		return nil, err
+	}
+
+	annotations := pod.Annotations
+	spec := &ComputeSpec{
+		Profile:      profileName,
+		ImageID:      valueOrDefault(annotations["compute.amazonaws.com/image-id"], profile.ImageID),
+		InstanceType: valueOrDefault(annotations["compute.amazonaws.com/instance-type"], profile.InstanceType),
+		SubnetID:     annotations["compute.amazonaws.com/subnet-id"],
+		IamInstanceProfile: valueOrDefault(annotations["compute.amazonaws.com/instance-profile"],
+			profile.IamInstanceProfile),
+		KeyPair:        valueOrDefault(annotations["compute.amazonaws.com/key-pair"], profile.KeyPair),
+		LaunchTemplate: annotations["compute.amazonaws.com/launch-template"],
+		CapacityType:   annotations["compute.amazonaws.com/capacity-type"],
+	}
+
+	// select a random subnet from the profile's list
+	if spec.SubnetID == "" && len(profile.Subnets) > 0 {
+		spec.SubnetID = profile.Subnets[rand.Intn(len(profile.Subnets))] //nolint:gosec
+	}
+
+	if securityGroups := annotations["compute.amazonaws.com/security-groups"]; securityGroups != "" {
+		spec.SecurityGroups = util.TrimmedStringSplit(securityGroups, ",")
+	} else {
+		spec.SecurityGroups = append([]string{}, profile.SecurityGroups...)
+	}
+
+	// the annotation's tags are merged with (and take precedence over) the profile's
+	annotationTags, err := ParseTagsAnnotation(annotations["compute.amazonaws.com/tags"])
+	if err != nil {
+		return nil, poderrors.Newf(poderrors.CategoryInvalidSpec,
+			"tags annotation must be a JSON object of tag keys to values: %w", err)
+	}
+	if len(profile.Tags)+len(annotationTags) > 0 {
+		spec.Tags = map[string]string{}
+		for key, value := range profile.Tags {
+			spec.Tags[key] = value
+		}
+		for key, value := range annotationTags {
+			spec.Tags[key] = value
+		}
+	}
+
+	return spec, nil
+}
+
+// PodInstanceType returns the instance type a pod's instance is (or will be) launched as, from its instance type
+//
+//	annotation, resolved spec, or compute profile (or "" if none of them set it)
+func PodInstanceType(pod *corev1.Pod) string {
+	if instanceType := pod.Annotations["compute.amazonaws.com/instance-type"]; instanceType != "" {
+		return instanceType
+	}
+
+	if spec := PodResolvedSpec(pod); spec != nil && spec.InstanceType != "" {
+		return spec.InstanceType
+	}
+
+	_, profile, err := podComputeProfile(pod)
+	if err != nil {
+		return ""
+	}
+	return profile.InstanceType
+}
+
+// PodResolvedSpec returns the spec recorded in a pod's resolved spec annotation (or nil if none was recorded)
+func PodResolvedSpec(pod *corev1.Pod) *ComputeSpec {
+	resolved := pod.Annotations[ResolvedSpecAnnotation]
+	if resolved == "" {
+		return nil
+	}
+
+	spec := &ComputeSpec{}
+	if err := json.Unmarshal([]byte(resolved), spec); err != nil {
+		return nil
+	}
+	return spec
+}
+
+// String returns the spec's JSON encoding (as recorded in the resolved spec annotation)
+func (s *ComputeSpec) String() string {
+	encoded, err := json.Marshal(s)
+	if err != nil {
+		return ""
+	}
+	return string(encoded)
+}
+
+// podComputeProfile returns the name and settings of a pod's compute profile (an empty profile if the pod has none)
+func podComputeProfile(pod *corev1.Pod) (string, config.ComputeProfile, error) {
+	var profiles map[string]config.ComputeProfile
+	var defaultProfile string
+	if cfg := config.Config(); cfg != nil {
+		profiles = cfg.ComputeProfiles
+		defaultProfile = cfg.DefaultComputeProfile
+	}
+
+	name := valueOrDefault(pod.Annotations[ProfileAnnotation], defaultProfile)
+	if name == "" {
+		return "", config.ComputeProfile{}, nil
+	}
+
+	profile, ok := profiles[name]
+	if !ok {
+		return "", config.ComputeProfile{}, poderrors.Newf(poderrors.CategoryInvalidSpec,
+			"compute profile %q isn't configured", name)
+	}
+	return name, profile, nil
+}
+
+// valueOrDefault returns value, or defaultValue if value is empty
+func valueOrDefault(value string, defaultValue string) string {
+	if value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,245 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package awsutils
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vkconfig "github.com/aws/aws-virtual-kubelet/internal/config"
+	"github.com/aws/aws-virtual-kubelet/internal/poderrors"
+)
+
+func TestResolveComputeSpec(t *testing.T) {
+	profiles := map[string]vkconfig.ComputeProfile{
+		"general": {
+			ImageID:            "ami-general",
+			InstanceType:       "t3.large",
+			Subnets:            []string{"subnet-general"},
+			SecurityGroups:     []string{"sg-general"},
+			IamInstanceProfile: "general-profile",
+			KeyPair:            "general-key",
+			Tags:               map[string]string{"team": "platform", "tier": "general"},
+		},
+		"mac": {
+			ImageID:      "ami-mac",
+			InstanceType: "mac1.metal",
+			Subnets:      []string{"subnet-mac-a", "subnet-mac-b"},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		defaultProfile string
+		annotations    map[string]string
+		want           *ComputeSpec
+		// wantSubnets are the subnets one of which is chosen (if the subnet isn't deterministic)
+		wantSubnets  []string
+		wantErr      bool
+		wantCategory poderrors.Category
+	}{
+		{
+			name: "annotations without profile",
+			annotations: map[string]string{
+				"compute.amazonaws.com/image-id":        "ami-pod",
+				"compute.amazonaws.com/instance-type":   "t3.micro",
+				"compute.amazonaws.com/subnet-id":       "subnet-pod",
+				"compute.amazonaws.com/security-groups": "sg-a, sg-b",
+				"compute.amazonaws.com/tags":            `{"app": "web"}`,
+			},
+			want: &ComputeSpec{
+				ImageID:        "ami-pod",
+				InstanceType:   "t3.micro",
+				SubnetID:       "subnet-pod",
+				SecurityGroups: []string{"sg-a", "sg-b"},
+				Tags:           map[string]string{"app": "web"},
+			},
+		},
+		{
+			name:        "profile settings",
+			annotations: map[string]string{ProfileAnnotation: "general"},
+			want: &ComputeSpec{
+				Profile:            "general",
+				ImageID:            "ami-general",
+				InstanceType:       "t3.large",
+				SubnetID:           "subnet-general",
+				SecurityGroups:     []string{"sg-general"},
+				IamInstanceProfile: "general-profile",
+				KeyPair:            "general-key",
+				Tags:               map[string]string{"team": "platform", "tier": "general"},
+			},
+		},
+		{
+			name: "annotations override profile",
+			annotations: map[string]string{
+				ProfileAnnotation:                        "general",
+				"compute.amazonaws.com/instance-type":    "t3.xlarge",
+				"compute.amazonaws.com/subnet-id":        "subnet-pod",
+				"compute.amazonaws.com/security-groups":  "sg-pod",
+				"compute.amazonaws.com/instance-profile": "pod-profile",
+			},
+			want: &ComputeSpec{
+				Profile:            "general",
+				ImageID:            "ami-general",
+				InstanceType:       "t3.xlarge",
+				SubnetID:           "subnet-pod",
+				SecurityGroups:     []string{"sg-pod"},
+				IamInstanceProfile: "pod-profile",
+				KeyPair:            "general-key",
+				Tags:               map[string]string{"team": "platform", "tier": "general"},
+			},
+		},
+		{
+			name: "annotation tags take precedence over profile tags",
+			annotations: map[string]string{
+				ProfileAnnotation:            "general",
+				"compute.amazonaws.com/tags": `{"tier": "web", "app": "frontend"}`,
+			},
+			want: &ComputeSpec{
+				Profile:            "general",
+				ImageID:            "ami-general",
+				InstanceType:       "t3.large",
+				SubnetID:           "subnet-general",
+				SecurityGroups:     []string{"sg-general"},
+				IamInstanceProfile: "general-profile",
+				KeyPair:            "general-key",
+				Tags:               map[string]string{"team": "platform", "tier": "web", "app": "frontend"},
+			},
+		},
+		{
+			name:           "default profile",
+			defaultProfile: "mac",
+			want: &ComputeSpec{
+				Profile:        "mac",
+				ImageID:        "ami-mac",
+				InstanceType:   "mac1.metal",
+				SecurityGroups: []string{},
+			},
+			wantSubnets: []string{"subnet-mac-a", "subnet-mac-b"},
+		},
+		{
+			name:           "profile annotation overrides default profile",
+			defaultProfile: "mac",
+			annotations:    map[string]string{ProfileAnnotation: "general"},
+			want: &ComputeSpec{
+				Profile:            "general",
+				ImageID:            "ami-general",
+				InstanceType:       "t3.large",
+				SubnetID:           "subnet-general",
+				SecurityGroups:     []string{"sg-general"},
+				IamInstanceProfile: "general-profile",
+				KeyPair:            "general-key",
+				Tags:               map[string]string{"team": "platform", "tier": "general"},
+			},
+		},
+		{
+			name:        "subnet annotation overrides profile subnets",
+			annotations: map[string]string{ProfileAnnotation: "mac", "compute.amazonaws.com/subnet-id": "subnet-pod"},
+			want: &ComputeSpec{
+				Profile:        "mac",
+				ImageID:        "ami-mac",
+				InstanceType:   "mac1.metal",
+				SubnetID:       "subnet-pod",
+				SecurityGroups: []string{},
+			},
+		},
+		{
+			name:         "unknown profile",
+			annotations:  map[string]string{ProfileAnnotation: "missing"},
+			wantErr:      true,
+			wantCategory: poderrors.CategoryInvalidSpec,
+		},
+		{
+			name: "invalid tags annotation",
+			annotations: map[string]string{
+				ProfileAnnotation:            "general",
+				"compute.amazonaws.com/tags": `team=platform`,
+			},
+			wantErr:      true,
+			wantCategory: poderrors.CategoryInvalidSpec,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTestConfig(t, vkconfig.ProviderConfig{
+				ComputeProfiles:       profiles,
+				DefaultComputeProfile: tt.defaultProfile,
+			})
+
+			pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Annotations: tt.annotations}}
+			spec, err := ResolveComputeSpec(pod)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveComputeSpec() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if poderrors.Classify(err) != tt.wantCategory {
+					t.Errorf("ResolveComputeSpec() error category = %v, want %v", poderrors.Classify(err),
+						tt.wantCategory)
+				}
+				return
+			}
+
+			if len(tt.wantSubnets) > 0 {
+				found := false
+				for _, subnet := range tt.wantSubnets {
+					found = found || spec.SubnetID == subnet
+				}
+				if !found {
+					t.Errorf("ResolveComputeSpec() subnet = %v, want one of %v", spec.SubnetID, tt.wantSubnets)
+				}
+				spec.SubnetID = ""
+			}
+
+			if !reflect.DeepEqual(spec, tt.want) {
+				t.Errorf("ResolveComputeSpec() = %+v, want %+v", spec, tt.want)
+			}
+		})
+	}
+}
+
+func TestPodResolvedSpec(t *testing.T) {
+	tests := []struct {
+		name     string
+		resolved string
+		want     *ComputeSpec
+	}{
+		{
+			name: "no resolved spec",
+		},
+		{
+			name:     "resolved spec",
+			resolved: `{"profile":"general","instanceType":"t3.large","tags":{"team":"platform"}}`,
+			want: &ComputeSpec{
+				Profile:      "general",
+				InstanceType: "t3.large",
+				Tags:         map[string]string{"team": "platform"},
+			},
+		},
+		{
+			name:     "invalid resolved spec",
+			resolved: "t3.large",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Annotations: map[string]string{}}}
+			if tt.resolved != "" {
+				pod.Annotations[ResolvedSpecAnnotation] = tt.resolved
+			}
+
+			if spec := PodResolvedSpec(pod); !reflect.DeepEqual(spec, tt.want) {
+				t.Errorf("PodResolvedSpec() = %+v, want %+v", spec, tt.want)
+			}
+		})
+	}
+}
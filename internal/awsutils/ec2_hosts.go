@@ -0,0 +1,123 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package awsutils
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"k8s.io/klog/v2"
+)
+
+// HostPoolTagKey marks dedicated hosts allocated by the provider's host pool (see DescribePoolHosts)
+const HostPoolTagKey = "aws-virtual-kubelet/HostPool"
+
+// DescribePoolHosts returns the (unreleased) dedicated hosts allocated by the host pool of the given node and cluster
+func DescribePoolHosts(ctx context.Context, ec2Client EC2API, clusterName string, nodeName string) ([]types.Host, error) {
+	input := &ec2.DescribeHostsInput{
+		Filter: []types.Filter{
+			{Name: aws.String("tag:" + ClusterNameTagKey), Values: []string{clusterName}},
+			{Name: aws.String("tag:" + NodeNameTagKey), Values: []string{nodeName}},
+			{Name: aws.String("tag-key"), Values: []string{HostPoolTagKey}},
+			{Name: aws.String("state"), Values: []string{
+				string(types.AllocationStateAvailable),
+				string(types.AllocationStatePending),
+				string(types.AllocationStateUnderAssessment),
+				string(types.AllocationStatePermanentFailure),
+			}},
+		},
+	}
+
+	var hosts []types.Host
+	for {
+		resp, err := ec2Client.DescribeHosts(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		hosts = append(hosts, resp.Hosts...)
+
+		if resp.NextToken == nil {
+			break
+		}
+		input.NextToken = resp.NextToken
+	}
+
+	return hosts, nil
+}
+
+// AllocatePoolHost allocates a dedicated host for an instance type in an availability zone, tagged as belonging to the
+//
+//	host pool of the given node and cluster.  Auto placement is off, so only instances targeting the host launch on it.
+func AllocatePoolHost(ctx context.Context, ec2Client EC2API, availabilityZone string, instanceType string,
+	clusterName string, nodeName string) (string, error) {
+	resp, err := ec2Client.AllocateHosts(ctx, &ec2.AllocateHostsInput{
+		AvailabilityZone: aws.String(availabilityZone),
+		InstanceType:     aws.String(instanceType),
+		Quantity:         aws.Int32(1),
+		AutoPlacement:    types.AutoPlacementOff,
+		TagSpecifications: []types.TagSpecification{{
+			ResourceType: types.ResourceTypeDedicatedHost,
+			Tags: []types.Tag{
+				{Key: aws.String(HostPoolTagKey), Value: aws.String("true")},
+				{Key: aws.String(ClusterNameTagKey), Value: aws.String(clusterName)},
+				{Key: aws.String(NodeNameTagKey), Value: aws.String(nodeName)},
+			},
+		}},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if len(resp.HostIds) != 1 {
+		return "", fmt.Errorf("AllocateHosts expected 1 host, but got %d", len(resp.HostIds))
+	}
+
+	klog.InfoS("Allocated dedicated host", "host", resp.HostIds[0], "instanceType", instanceType,
+		"availabilityZone", availabilityZone)
+
+	return resp.HostIds[0], nil
+}
+
+// ReleaseHost releases a dedicated host
+func ReleaseHost(ctx context.Context, ec2Client EC2API, hostID string) error {
+	resp, err := ec2Client.ReleaseHosts(ctx, &ec2.ReleaseHostsInput{HostIds: []string{hostID}})
+	if err != nil {
+		return err
+	}
+
+	for _, item := range resp.Unsuccessful {
+		if item.Error != nil {
+			return fmt.Errorf("unable to release host %v: %v (%v)", hostID, aws.ToString(item.Error.Message),
+				aws.ToString(item.Error.Code))
+		}
+	}
+
+	klog.InfoS("Released dedicated host", "host", hostID)
+
+	return nil
+}
+
+// SubnetAvailabilityZone returns the availability zone of a subnet
+func SubnetAvailabilityZone(ctx context.Context, ec2Client EC2API, subnetID string) (string, error) {
+	resp, err := ec2Client.DescribeSubnets(ctx, &ec2.DescribeSubnetsInput{SubnetIds: []string{subnetID}})
+	if err != nil {
+		return "", err
+	}
+
+	if len(resp.Subnets) != 1 {
+		return "", fmt.Errorf("DescribeSubnets expected 1 subnet, but got %d", len(resp.Subnets))
+	}
+
+	return aws.ToString(resp.Subnets[0].AvailabilityZone), nil
+}
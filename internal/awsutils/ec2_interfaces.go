@@ -29,8 +29,14 @@ type EC2API interface {
 	RunInstances(ctx context.Context, input *ec2.RunInstancesInput) (*ec2.RunInstancesOutput, error)
 	// DescribeInstance retrieves information of EC2 instance based on the parameters
 	DescribeInstances(crx context.Context, input *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error)
+	// DescribeInstanceStatus retrieves the status of EC2 instances based on the parameters
+	DescribeInstanceStatus(ctx context.Context, input *ec2.DescribeInstanceStatusInput) (*ec2.DescribeInstanceStatusOutput, error)
+	// DescribeInstanceTypes retrieves the details (vCPUs, memory, etc.) of EC2 instance types
+	DescribeInstanceTypes(ctx context.Context, input *ec2.DescribeInstanceTypesInput) (*ec2.DescribeInstanceTypesOutput, error)
 	// CreateTags updates or creates tags of applied resource based on the parameters
 	CreateTags(ctx context.Context, input *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error)
+	// DeleteTags deletes tags from applied resource based on the parameters
+	DeleteTags(ctx context.Context, input *ec2.DeleteTagsInput) (*ec2.DeleteTagsOutput, error)
 	// ModifyInstanceAttribute modifies existing AWS EC2 Attribute based on input
 	ModifyInstanceAttribute(ctx context.Context, input *ec2.ModifyInstanceAttributeInput) (*ec2.ModifyInstanceAttributeOutput, error)
 	// SecurityGroupNametoID is a helper that converts SG group names (e.g. "Default") to IDs (e.g. sg-xxxxxx)
@@ -39,6 +45,16 @@ type EC2API interface {
 	DescribeIamInstanceProfileAssociations(ctx context.Context, input *ec2.DescribeIamInstanceProfileAssociationsInput) (*ec2.DescribeIamInstanceProfileAssociationsOutput, error)
 	// ReplaceIamInstanceProfileAssociation describes IAM profile associations for given EC2 instances
 	ReplaceIamInstanceProfileAssociation(ctx context.Context, input *ec2.ReplaceIamInstanceProfileAssociationInput) (*ec2.ReplaceIamInstanceProfileAssociationOutput, error)
+	// DescribeHosts retrieves information of dedicated hosts based on the parameters
+	DescribeHosts(ctx context.Context, input *ec2.DescribeHostsInput) (*ec2.DescribeHostsOutput, error)
+	// AllocateHosts allocates dedicated hosts based on the parameters
+	AllocateHosts(ctx context.Context, input *ec2.AllocateHostsInput) (*ec2.AllocateHostsOutput, error)
+	// ReleaseHosts releases dedicated hosts based on the parameters
+	ReleaseHosts(ctx context.Context, input *ec2.ReleaseHostsInput) (*ec2.ReleaseHostsOutput, error)
+	// DescribeSubnets retrieves information of subnets based on the parameters
+	DescribeSubnets(ctx context.Context, input *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error)
+	// DescribeLaunchTemplateVersions retrieves launch template versions based on the parameters
+	DescribeLaunchTemplateVersions(ctx context.Context, input *ec2.DescribeLaunchTemplateVersionsInput) (*ec2.DescribeLaunchTemplateVersionsOutput, error)
 	//NewInstanceRunningWaiter waits until instance status becomes "running"
-	NewInstanceRunningWaiter(input ec2.DescribeInstancesInput) error
+	NewInstanceRunningWaiter(ctx context.Context, input ec2.DescribeInstancesInput) error
 }
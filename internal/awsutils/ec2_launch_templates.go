@@ -0,0 +1,156 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package awsutils
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"github.com/aws/aws-virtual-kubelet/internal/poderrors"
+	util "github.com/aws/aws-virtual-kubelet/internal/utils"
+)
+
+// WithLaunchTemplate launches an instance from a launch template.  Parameters set in the RunInstancesInput (e.g. from
+//
+//	pod annotations) override the template's.
+func WithLaunchTemplate(ref util.LaunchTemplateRef) RunInstancesOption {
+	return func(input *ec2.RunInstancesInput) {
+		spec := &types.LaunchTemplateSpecification{Version: aws.String(ref.Version)}
+		if ref.ID != "" {
+			spec.LaunchTemplateId = aws.String(ref.ID)
+		} else {
+			spec.LaunchTemplateName = aws.String(ref.Name)
+		}
+		input.LaunchTemplate = spec
+	}
+}
+
+// LaunchTemplateOptions returns the RunInstances options that launch an instance from a launch template reference
+//
+//	(see utils.ParseLaunchTemplate), or no options if the reference is empty
+func LaunchTemplateOptions(launchTemplate string) ([]RunInstancesOption, error) {
+	if launchTemplate == "" {
+		return nil, nil
+	}
+
+	ref, err := util.ParseLaunchTemplate(launchTemplate)
+	if err != nil {
+		return nil, poderrors.WrapAs(poderrors.CategoryInvalidSpec, err)
+	}
+
+	return []RunInstancesOption{WithLaunchTemplate(ref)}, nil
+}
+
+// ResolveTemplatePlacement returns the instance type and subnet an instance will be launched with, taking those the
+//
+//	launch template (see utils.ParseLaunchTemplate) provides if they aren't set.  Placement decisions made before
+//	launch (e.g. whether the instance type needs a dedicated host) must use these rather than the unresolved settings.
+func ResolveTemplatePlacement(ctx context.Context, client EC2API, launchTemplate string, instanceType string,
+	subnetID string) (string, string, error) {
+	if launchTemplate == "" || (instanceType != "" && subnetID != "") {
+		return instanceType, subnetID, nil
+	}
+
+	opts, err := LaunchTemplateOptions(launchTemplate)
+	if err != nil {
+		return "", "", err
+	}
+	input := &ec2.RunInstancesInput{}
+	for _, opt := range opts {
+		opt(input)
+	}
+
+	template, err := launchTemplateData(ctx, client, input.LaunchTemplate)
+	if err != nil {
+		return "", "", err
+	}
+
+	if instanceType == "" {
+		instanceType = string(template.InstanceType)
+	}
+	if subnetID == "" {
+		for _, networkInterface := range template.NetworkInterfaces {
+			if aws.ToInt32(networkInterface.DeviceIndex) == 0 && networkInterface.SubnetId != nil {
+				subnetID = aws.ToString(networkInterface.SubnetId)
+			}
+		}
+	}
+
+	return instanceType, subnetID, nil
+}
+
+// validateRunInstancesInput checks that an input (together with its launch template, if any) has the parameters
+//
+//	required to launch an instance, and that the parameters overriding its launch template don't conflict with it
+func validateRunInstancesInput(ctx context.Context, client EC2API, input *ec2.RunInstancesInput) error {
+	template := &types.ResponseLaunchTemplateData{}
+	if input.LaunchTemplate != nil {
+		var err error
+		if template, err = launchTemplateData(ctx, client, input.LaunchTemplate); err != nil {
+			return err
+		}
+	}
+
+	var problems []string
+
+	if aws.ToString(input.ImageId) == "" && aws.ToString(template.ImageId) == "" {
+		problems = append(problems, "an image ID is required (from the image-id annotation, a compute profile, or "+
+			"a launch template)")
+	}
+	if input.InstanceType == "" && template.InstanceType == "" {
+		problems = append(problems, "an instance type is required (from the instance-type annotation, a compute "+
+			"profile, or a launch template)")
+	}
+
+	// EC2 rejects instance-level network settings alongside network interfaces
+	if len(template.NetworkInterfaces) > 0 && (input.SubnetId != nil || len(input.SecurityGroupIds) > 0) {
+		problems = append(problems, "the subnet and security groups can't override a launch template that specifies "+
+			"network interfaces (set them in the template's network interfaces instead)")
+	}
+
+	// the dedicated host placement would replace the template's tenancy and host settings
+	if input.Placement != nil && template.Placement != nil && (template.Placement.Tenancy != "" ||
+		template.Placement.HostId != nil || template.Placement.HostResourceGroupArn != nil ||
+		template.Placement.Affinity != nil) {
+		problems = append(problems,
+			"the launch template's tenancy or host placement conflicts with the host pool's dedicated host placement")
+	}
+
+	if len(problems) > 0 {
+		return poderrors.Newf(poderrors.CategoryInvalidSpec, "invalid launch parameters: %v",
+			strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// launchTemplateData returns the launch parameters of a launch template version
+func launchTemplateData(ctx context.Context, client EC2API, spec *types.LaunchTemplateSpecification) (
+	*types.ResponseLaunchTemplateData, error) {
+	resp, err := client.DescribeLaunchTemplateVersions(ctx, &ec2.DescribeLaunchTemplateVersionsInput{
+		LaunchTemplateId:   spec.LaunchTemplateId,
+		LaunchTemplateName: spec.LaunchTemplateName,
+		Versions:           []string{aws.ToString(spec.Version)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.LaunchTemplateVersions) != 1 || resp.LaunchTemplateVersions[0].LaunchTemplateData == nil {
+		return nil, poderrors.Newf(poderrors.CategoryNotFound, "launch template %v%v version %v not found",
+			aws.ToString(spec.LaunchTemplateId), aws.ToString(spec.LaunchTemplateName), aws.ToString(spec.Version))
+	}
+
+	return resp.LaunchTemplateVersions[0].LaunchTemplateData, nil
+}
@@ -0,0 +1,220 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package awsutils
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"github.com/aws/aws-virtual-kubelet/internal/poderrors"
+)
+
+// fakeEC2 is an EC2API that returns a fixed launch template version (other EC2API methods aren't implemented)
+type fakeEC2 struct {
+	EC2API
+	// templates are the launch template versions' data, keyed by template name
+	templates map[string]*types.ResponseLaunchTemplateData
+	err       error
+}
+
+func (f *fakeEC2) DescribeLaunchTemplateVersions(ctx context.Context,
+	input *ec2.DescribeLaunchTemplateVersionsInput) (*ec2.DescribeLaunchTemplateVersionsOutput, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	output := &ec2.DescribeLaunchTemplateVersionsOutput{}
+	if data, ok := f.templates[aws.ToString(input.LaunchTemplateName)]; ok {
+		output.LaunchTemplateVersions = []types.LaunchTemplateVersion{{LaunchTemplateData: data}}
+	}
+	return output, nil
+}
+
+// testTemplates are the launch templates known to the fake EC2 client
+var testTemplates = map[string]*types.ResponseLaunchTemplateData{
+	"complete": {
+		ImageId:      aws.String("ami-template"),
+		InstanceType: types.InstanceTypeMac1Metal,
+	},
+	"image-only": {
+		ImageId: aws.String("ami-template"),
+	},
+	"network-interfaces": {
+		ImageId:      aws.String("ami-template"),
+		InstanceType: types.InstanceTypeMac1Metal,
+		NetworkInterfaces: []types.LaunchTemplateInstanceNetworkInterfaceSpecification{
+			{DeviceIndex: aws.Int32(1), SubnetId: aws.String("subnet-secondary")},
+			{DeviceIndex: aws.Int32(0), SubnetId: aws.String("subnet-template")},
+		},
+	},
+	"dedicated-host": {
+		ImageId:      aws.String("ami-template"),
+		InstanceType: types.InstanceTypeMac1Metal,
+		Placement:    &types.LaunchTemplatePlacement{Tenancy: types.TenancyHost, HostId: aws.String("h-template")},
+	},
+}
+
+// templateSpec references a test launch template by name
+func templateSpec(name string) *types.LaunchTemplateSpecification {
+	return &types.LaunchTemplateSpecification{LaunchTemplateName: aws.String(name), Version: aws.String("$Default")}
+}
+
+func TestValidateRunInstancesInput(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        *ec2.RunInstancesInput
+		err          error
+		wantErr      bool
+		wantCategory poderrors.Category
+	}{
+		{
+			name:  "complete without template",
+			input: &ec2.RunInstancesInput{ImageId: aws.String("ami-input"), InstanceType: types.InstanceTypeT3Micro},
+		},
+		{
+			name:         "missing image and instance type",
+			input:        &ec2.RunInstancesInput{},
+			wantErr:      true,
+			wantCategory: poderrors.CategoryInvalidSpec,
+		},
+		{
+			name:  "template provides image and instance type",
+			input: &ec2.RunInstancesInput{LaunchTemplate: templateSpec("complete")},
+		},
+		{
+			name:         "template missing instance type",
+			input:        &ec2.RunInstancesInput{LaunchTemplate: templateSpec("image-only")},
+			wantErr:      true,
+			wantCategory: poderrors.CategoryInvalidSpec,
+		},
+		{
+			name: "input completes template",
+			input: &ec2.RunInstancesInput{LaunchTemplate: templateSpec("image-only"),
+				InstanceType: types.InstanceTypeT3Micro},
+		},
+		{
+			name: "subnet overrides template network interfaces",
+			input: &ec2.RunInstancesInput{LaunchTemplate: templateSpec("network-interfaces"),
+				SubnetId: aws.String("subnet-input")},
+			wantErr:      true,
+			wantCategory: poderrors.CategoryInvalidSpec,
+		},
+		{
+			name: "host placement conflicts with template placement",
+			input: &ec2.RunInstancesInput{LaunchTemplate: templateSpec("dedicated-host"),
+				Placement: &types.Placement{Tenancy: types.TenancyHost, HostId: aws.String("h-pool")}},
+			wantErr:      true,
+			wantCategory: poderrors.CategoryInvalidSpec,
+		},
+		{
+			name: "host placement with template",
+			input: &ec2.RunInstancesInput{LaunchTemplate: templateSpec("complete"),
+				Placement: &types.Placement{Tenancy: types.TenancyHost, HostId: aws.String("h-pool")}},
+		},
+		{
+			name:         "template not found",
+			input:        &ec2.RunInstancesInput{LaunchTemplate: templateSpec("missing")},
+			wantErr:      true,
+			wantCategory: poderrors.CategoryNotFound,
+		},
+		{
+			name:         "template lookup fails",
+			input:        &ec2.RunInstancesInput{LaunchTemplate: templateSpec("complete")},
+			err:          errors.New("DescribeLaunchTemplateVersions failed"),
+			wantErr:      true,
+			wantCategory: poderrors.CategoryUnknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeEC2{templates: testTemplates, err: tt.err}
+
+			err := validateRunInstancesInput(context.Background(), client, tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateRunInstancesInput() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && poderrors.Classify(err) != tt.wantCategory {
+				t.Errorf("validateRunInstancesInput() error category = %v, want %v", poderrors.Classify(err),
+					tt.wantCategory)
+			}
+		})
+	}
+}
+
+func TestResolveTemplatePlacement(t *testing.T) {
+	tests := []struct {
+		name             string
+		launchTemplate   string
+		instanceType     string
+		subnetID         string
+		wantInstanceType string
+		wantSubnetID     string
+		wantErr          bool
+	}{
+		{
+			name:             "no template",
+			instanceType:     "t3.micro",
+			subnetID:         "subnet-input",
+			wantInstanceType: "t3.micro",
+			wantSubnetID:     "subnet-input",
+		},
+		{
+			name:             "instance type from template",
+			launchTemplate:   "complete",
+			subnetID:         "subnet-input",
+			wantInstanceType: "mac1.metal",
+			wantSubnetID:     "subnet-input",
+		},
+		{
+			name:             "subnet from template's primary network interface",
+			launchTemplate:   "network-interfaces",
+			wantInstanceType: "mac1.metal",
+			wantSubnetID:     "subnet-template",
+		},
+		{
+			name:             "input overrides template",
+			launchTemplate:   "network-interfaces",
+			instanceType:     "t3.micro",
+			subnetID:         "subnet-input",
+			wantInstanceType: "t3.micro",
+			wantSubnetID:     "subnet-input",
+		},
+		{
+			name:           "template not found",
+			launchTemplate: "missing",
+			wantErr:        true,
+		},
+		{
+			name:           "invalid template reference",
+			launchTemplate: "complete:1:2",
+			wantErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeEC2{templates: testTemplates}
+
+			instanceType, subnetID, err := ResolveTemplatePlacement(context.Background(), client, tt.launchTemplate,
+				tt.instanceType, tt.subnetID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveTemplatePlacement() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if instanceType != tt.wantInstanceType || subnetID != tt.wantSubnetID {
+				t.Errorf("ResolveTemplatePlacement() = %v, %v, want %v, %v", instanceType, subnetID,
+					tt.wantInstanceType, tt.wantSubnetID)
+			}
+		})
+	}
+}
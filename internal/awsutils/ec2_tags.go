@@ -0,0 +1,172 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package awsutils
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	vkconfig "github.com/aws/aws-virtual-kubelet/internal/config"
+	"github.com/aws/aws-virtual-kubelet/internal/metrics"
+)
+
+// EC2 instance tags that record which pod an instance belongs to.  These allow the pod ↔ instance relationship to be
+//
+//	recovered from EC2 alone (see docs/rfcs/PodPersistence.md).
+const (
+	PodUIDTagKey       = "aws-virtual-kubelet/PodUID"
+	PodNamespaceTagKey = "aws-virtual-kubelet/PodNamespace"
+	PodNameTagKey      = "aws-virtual-kubelet/PodName"
+	NodeNameTagKey     = "aws-virtual-kubelet/NodeName"
+	ClusterNameTagKey  = "aws-virtual-kubelet/ClusterName"
+)
+
+// PodTags returns the tags associating an instance with a pod (and the node and cluster the pod is scheduled to)
+func PodTags(pod *corev1.Pod) []types.Tag {
+	return []types.Tag{
+		{Key: aws.String(PodUIDTagKey), Value: aws.String(string(pod.UID))},
+		{Key: aws.String(PodNamespaceTagKey), Value: aws.String(pod.Namespace)},
+		{Key: aws.String(PodNameTagKey), Value: aws.String(pod.Name)},
+		{Key: aws.String(NodeNameTagKey), Value: aws.String(pod.Spec.NodeName)},
+		{Key: aws.String(ClusterNameTagKey), Value: aws.String(vkconfig.Config().ClusterName)},
+	}
+}
+
+// GetTagValue returns the value of the tag with the given key (or an empty string if the tag isn't present)
+func GetTagValue(tags []types.Tag, key string) string {
+	for _, tag := range tags {
+		if aws.ToString(tag.Key) == key {
+			return aws.ToString(tag.Value)
+		}
+	}
+
+	return ""
+}
+
+// DescribePodInstances returns all (non-terminated) instances tagged as belonging to pods on the given node and cluster
+func DescribePodInstances(
+	ctx context.Context, ec2Client EC2API, clusterName string, nodeName string) ([]types.Instance, error) {
+	return describeInstances(ctx, ec2Client, []types.Filter{
+		{Name: aws.String("tag:" + ClusterNameTagKey), Values: []string{clusterName}},
+		{Name: aws.String("tag:" + NodeNameTagKey), Values: []string{nodeName}},
+		{Name: aws.String("tag-key"), Values: []string{PodUIDTagKey}},
+	})
+}
+
+// DescribePodUIDInstances returns the (non-terminated) instances tagged for the pod with the given UID in a cluster
+func DescribePodUIDInstances(
+	ctx context.Context, ec2Client EC2API, clusterName string, podUID string) ([]types.Instance, error) {
+	return describeInstances(ctx, ec2Client, []types.Filter{
+		{Name: aws.String("tag:" + ClusterNameTagKey), Values: []string{clusterName}},
+		{Name: aws.String("tag:" + PodUIDTagKey), Values: []string{podUID}},
+	})
+}
+
+// describeInstances returns the (non-terminated) instances matching filters, following pagination
+func describeInstances(ctx context.Context, ec2Client EC2API, filters []types.Filter) ([]types.Instance, error) {
+	input := &ec2.DescribeInstancesInput{
+		Filters: append(filters, types.Filter{
+			Name: aws.String("instance-state-name"), Values: []string{"pending", "running", "stopping", "stopped"},
+		}),
+	}
+
+	var instances []types.Instance
+	for {
+		resp, err := ec2Client.DescribeInstances(ctx, input)
+		if err != nil {
+			klog.ErrorS(err, "Unable to describe pod instances")
+			metrics.DescribeEC2Errors.Inc()
+			return nil, err
+		}
+
+		for _, reservation := range resp.Reservations {
+			instances = append(instances, reservation.Instances...)
+		}
+
+		if aws.ToString(resp.NextToken) == "" {
+			break
+		}
+		input.NextToken = resp.NextToken
+	}
+
+	return instances, nil
+}
+
+// ParseTagsAnnotation parses a pod's tags annotation (a JSON object of tag keys to values), trimming whitespace from
+//
+//	keys and values.  An empty annotation has no tags.
+func ParseTagsAnnotation(annotationValue string) (map[string]string, error) {
+	tags := map[string]string{}
+	if annotationValue == "" {
+		return tags, nil
+	}
+
+	rawTags := map[string]string{}
+	if err := json.Unmarshal([]byte(annotationValue), &rawTags); err != nil {
+		return tags, err
+	}
+
+	for key, value := range rawTags {
+		tags[strings.Trim(key, " ")] = strings.Trim(value, " ")
+	}
+
+	return tags, nil
+}
+
+// UpdateInstanceTags creates or updates tags that are new or changed and deletes tags that were removed
+func UpdateInstanceTags(
+	ctx context.Context, ec2Client EC2API, instanceID string, oldTags map[string]string, newTags map[string]string) error {
+	var createTags []types.Tag
+	for key, value := range newTags {
+		if oldValue, ok := oldTags[key]; !ok || oldValue != value {
+			createTags = append(createTags, types.Tag{Key: aws.String(key), Value: aws.String(value)})
+		}
+	}
+
+	var deleteTags []types.Tag
+	for key := range oldTags {
+		if _, ok := newTags[key]; !ok {
+			deleteTags = append(deleteTags, types.Tag{Key: aws.String(key)})
+		}
+	}
+
+	if len(createTags) > 0 {
+		klog.InfoS("Updating instance tags", "instance", instanceID, "tags", len(createTags))
+		_, err := ec2Client.CreateTags(ctx, &ec2.CreateTagsInput{
+			Resources: []string{instanceID},
+			Tags:      createTags,
+		})
+		if err != nil {
+			klog.ErrorS(err, "Unable to create or update instance tags", "instance", instanceID)
+			return err
+		}
+	}
+
+	if len(deleteTags) > 0 {
+		klog.InfoS("Deleting instance tags", "instance", instanceID, "tags", len(deleteTags))
+		_, err := ec2Client.DeleteTags(ctx, &ec2.DeleteTagsInput{
+			Resources: []string{instanceID},
+			Tags:      deleteTags,
+		})
+		if err != nil {
+			klog.ErrorS(err, "Unable to delete instance tags", "instance", instanceID)
+			return err
+		}
+	}
+
+	return nil
+}
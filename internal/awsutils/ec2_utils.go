@@ -11,9 +11,8 @@ package awsutils
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
-	"strconv"
+	"fmt"
 	"strings"
 
 	"k8s.io/klog/v2"
@@ -22,7 +21,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/aws/aws-virtual-kubelet/internal/metrics"
-	util "github.com/aws/aws-virtual-kubelet/internal/utils"
+	"github.com/aws/aws-virtual-kubelet/internal/poderrors"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -58,8 +57,7 @@ func CreateNetworkInterface(tagValue string, subnetId string, ec2Client EC2API)
 	result, err := ec2Client.CreateNetworkInterface(context.TODO(), input)
 	if err != nil {
 		metrics.CreateENIErrors.Inc()
-		return "", "", errors.New("unable to create Network Interface , tag : " + tagValue + ", " +
-			err.Error())
+		return "", "", fmt.Errorf("unable to create network interface with tag %v: %w", tagValue, err)
 	}
 	klog.Info("created a new Network Interface, DNS : ", *result.NetworkInterface.PrivateDnsName)
 	return *result.NetworkInterface.PrivateDnsName, *result.NetworkInterface.NetworkInterfaceId, nil
@@ -78,7 +76,7 @@ func DeleteNetworkInterface(tagValue string, ec2Client EC2API) error {
 	// find eni id for the given tag name
 	_, eniId, err := GetNetworkInterfaceByTagName(tagValue, ec2Client)
 	if err != nil {
-		return errors.New("Problem finding the eni id for tag =" + tagValue + " " + err.Error())
+		return fmt.Errorf("unable to find network interface with tag %v: %w", tagValue, err)
 	}
 	if eniId == "" {
 		return nil
@@ -89,7 +87,7 @@ func DeleteNetworkInterface(tagValue string, ec2Client EC2API) error {
 	_, err = ec2Client.DeleteNetworkInterface(context.TODO(), input)
 	if err != nil {
 		metrics.DeleteENIErrors.Inc()
-		return errors.New("unable to delete Network Interface : " + eniId)
+		return fmt.Errorf("unable to delete network interface %v: %w", eniId, err)
 	}
 	klog.Info("deleted Network Interface, eniId : ", eniId)
 	return nil
@@ -122,7 +120,8 @@ func GetNetworkInterfaceByTagName(tagValue string, ec2Client EC2API) (DNS string
 	if err != nil {
 		klog.Error(err)
 		metrics.DescribeENIErrors.Inc()
-		return "", "", errors.New(err.Error() + " , Input tagValue :" + tagValue)
+		// wrap (rather than replace) the error so callers can inspect the underlying API error
+		return "", "", fmt.Errorf("unable to describe network interfaces with tag %v: %w", tagValue, err)
 	}
 	for _, r := range result.NetworkInterfaces {
 		dnsOut = *r.PrivateDnsName
@@ -152,16 +151,18 @@ func GetInstanceStatusById(instanceId string, ec2Client EC2API) (status string,
 	if err != nil {
 		klog.Error(err)
 		metrics.DescribeEC2Errors.Inc()
-		return "", "", errors.New(err.Error() + " , Input instanceId :" + instanceId)
+		return "", "", fmt.Errorf("unable to describe instance %v: %w", instanceId, err)
 	}
 	//throw error if number of elements are not equal to 1
 	if len(result.Reservations) != 1 {
 		klog.Error("DescribeInstances expect 1 Reservations, but got : ", len(result.Reservations), result)
-		return "", "", errors.New("DescribeInstances expect 1 Reservations, but got" + strconv.Itoa(len(result.Reservations)))
+		return "", "", fmt.Errorf("DescribeInstances for instance %v expected 1 reservation, but got %d", instanceId,
+			len(result.Reservations))
 	}
 	if len(result.Reservations[0].Instances) != 1 {
 		klog.Error("DescribeInstances expect 1 instance, but got : ", len(result.Reservations[0].Instances), result)
-		return "", "", errors.New("DescribeInstances expect 1 instance, but got" + strconv.Itoa(len(result.Reservations[0].Instances)))
+		return "", "", fmt.Errorf("DescribeInstances for instance %v expected 1 instance, but got %d", instanceId,
+			len(result.Reservations[0].Instances))
 	}
 	instance := result.Reservations[0].Instances[0]
 	if instance.PrivateIpAddress != nil {
@@ -172,16 +173,9 @@ func GetInstanceStatusById(instanceId string, ec2Client EC2API) (status string,
 	return state, privateIpOut, nil
 }
 
-// CreateEC2 generates a new EC2 instance based upon the input values provided.
-func CreateEC2(ctx context.Context, pod *corev1.Pod, userData string, presignBucket string, presignKey string) (string, error) {
-	ec2Client, err := NewEc2Client()
-	if err != nil {
-		return "", err
-	}
-
-	annotationValue := pod.Annotations["compute.amazonaws.com/tags"]
-	annotationTags := make(map[string]string)
-	json.Unmarshal([]byte(annotationValue), &annotationTags)
+// CreateEC2 generates a new EC2 instance for a pod based upon its resolved compute spec (see ResolveComputeSpec).
+func CreateEC2(ctx context.Context, ec2Client EC2API, pod *corev1.Pod, spec *ComputeSpec, userData string,
+	optFns ...RunInstancesOption) (string, error) {
 	var tagsInput []types.TagSpecification = []types.TagSpecification{{
 		ResourceType: "instance",
 		Tags:         []types.Tag{},
@@ -189,33 +183,41 @@ func CreateEC2(ctx context.Context, pod *corev1.Pod, userData string, presignBuc
 	var tags []types.Tag
 
 	// Loop through tags to unwrangle and assign to []types.TagSpecification
-	for key, value := range annotationTags {
+	for key, value := range spec.Tags {
 		// Append each individual tag to the Tag List
 		tags = append(tags, types.Tag{
-			Key:   aws.String(strings.Trim(key, " ")),
-			Value: aws.String(strings.Trim(value, " ")),
+			Key:   aws.String(key),
+			Value: aws.String(value),
 		})
 	}
+	// tag the instance with its pod so the association survives provider restarts (and k8s forgetting the pod)
+	tags = append(tags, PodTags(pod)...)
 	tagsInput[0].Tags = tags
-	if len(tags) == 0 {
-		tagsInput = nil
-	}
 
-	// Split security group into array of []string
-	securityGroups := util.TrimmedStringSplit(pod.Annotations["compute.amazonaws.com/security-groups"], ",")
+	// the pod's compute spec (and the capacity type and placement options) override the launch template's parameters
+	launchTemplateOpts, err := LaunchTemplateOptions(spec.LaunchTemplate)
+	if err != nil {
+		return "", err
+	}
+	capacityTypeOpts, err := CapacityTypeOptions(spec.CapacityType)
+	if err != nil {
+		return "", err
+	}
+	optFns = append(append(launchTemplateOpts, capacityTypeOpts...), optFns...)
 
-	// Generate RunInstancesInput
+	// Generate RunInstancesInput (empty spec settings are left unset)
 	resp, err := EC2RunInstancesUtil(
 		ctx,
-		pod.Annotations["compute.amazonaws.com/instance-profile"],
-		pod.Annotations["compute.amazonaws.com/image-id"],
-		pod.Annotations["compute.amazonaws.com/instance-type"],
-		pod.Annotations["compute.amazonaws.com/key-pair"],
-		securityGroups,
-		pod.Annotations["compute.amazonaws.com/subnet-id"],
+		spec.IamInstanceProfile,
+		spec.ImageID,
+		spec.InstanceType,
+		spec.KeyPair,
+		spec.SecurityGroups,
+		spec.SubnetID,
 		tagsInput,
 		userData,
 		ec2Client,
+		optFns...,
 	)
 
 	if err != nil {
@@ -233,13 +235,12 @@ func CreateEC2(ctx context.Context, pod *corev1.Pod, userData string, presignBuc
 		klog.InfoS("RunInstances launched an instance", "instance-id", instanceID)
 	}
 
-	pod.Annotations["compute.amazonaws.com/instance-id"] = *resp.Instances[0].InstanceId
-
 	metrics.EC2Launched.Inc()
 	return *resp.Instances[0].InstanceId, err
 }
 
-func TerminateEC2(ctx context.Context, instanceID string) (string, error) {
+// TerminateEC2 terminates an EC2 instance (an instance that no longer exists isn't an error)
+func TerminateEC2(ctx context.Context, ec2Client EC2API, instanceID string) (string, error) {
 	if instanceID == "" {
 		return "instance-id-not-set", nil
 	}
@@ -248,11 +249,10 @@ func TerminateEC2(ctx context.Context, instanceID string) (string, error) {
 		InstanceIds: []string{instanceID},
 	}
 
-	ec2Client, err := NewEc2Client()
-
 	resp, err := ec2Client.TerminateInstances(ctx, &terminateInstanceInput)
 	if err != nil {
-		if strings.Contains(err.Error(), "InvalidInstanceID.NotFound") {
+		// the instance is already gone
+		if poderrors.Is(err, poderrors.CategoryNotFound) {
 			return "", nil
 		}
 		metrics.EC2TerminationErrors.Inc()
@@ -294,40 +294,38 @@ func UpdateInstanceSecurityGroups(ctx context.Context, ec2Client EC2API, instanc
 }
 
 // GetPrivateIP gets private ip of the EC2 instance
-func GetPrivateIP(instanceID string) (privateIp string, err error) {
-	ec2Client, err := NewEc2Client()
-	if err != nil {
-		return "", err
-	}
+func GetPrivateIP(ctx context.Context, ec2Client EC2API, instanceID string) (privateIp string, err error) {
 	input := &ec2.DescribeInstancesInput{
 		InstanceIds: []string{instanceID},
 	}
 	klog.Infof("ec2 instance %v waiting for describe operations", instanceID)
 
 	// begin waiter code.
-	err = ec2Client.NewInstanceRunningWaiter(*input)
+	err = ec2Client.NewInstanceRunningWaiter(ctx, *input)
 	if err != nil {
 		klog.Errorf("error waiting for instance %v running status , error : %v", instanceID, err)
-		return "", errors.New(err.Error() + " , Input instanceId :" + instanceID)
+		return "", fmt.Errorf("error waiting for instance %v to be running: %w", instanceID, err)
 	}
 	klog.Infof("ec2 instance %v is now ready for describe operations", instanceID)
 	// end waiter code.
 
 	var state, privateIpOut string
-	result, err := ec2Client.DescribeInstances(context.TODO(), input)
+	result, err := ec2Client.DescribeInstances(ctx, input)
 	if err != nil {
 		klog.Error(err)
 		metrics.DescribeEC2Errors.Inc()
-		return "", errors.New(err.Error() + " , Input instanceId :" + instanceID)
+		return "", fmt.Errorf("unable to describe instance %v: %w", instanceID, err)
 	}
 	//throw error if number of reservations / instances are not equal to 1
 	if len(result.Reservations) != 1 {
 		klog.Error("DescribeInstances call expected 1 Reservations, but got : ", len(result.Reservations), result)
-		return "", errors.New("DescribeInstances call expected 1 Reservations, but got : " + strconv.Itoa(len(result.Reservations)))
+		return "", fmt.Errorf("DescribeInstances for instance %v expected 1 reservation, but got %d", instanceID,
+			len(result.Reservations))
 	}
 	if len(result.Reservations[0].Instances) != 1 {
 		klog.Error("DescribeInstances call expected 1 instance, but got : ", len(result.Reservations[0].Instances), result)
-		return "", errors.New("DescribeInstances expected 1 instance, but got" + strconv.Itoa(len(result.Reservations[0].Instances)))
+		return "", fmt.Errorf("DescribeInstances for instance %v expected 1 instance, but got %d", instanceID,
+			len(result.Reservations[0].Instances))
 	}
 	instance := result.Reservations[0].Instances[0]
 	if instance.PrivateIpAddress != nil {
@@ -335,40 +333,47 @@ func GetPrivateIP(instanceID string) (privateIp string, err error) {
 		privateIpOut = *instance.PrivateIpAddress
 	} else {
 		klog.Errorf("PrivateIpAddress is nil for instance : %v ", instanceID)
-		return "", errors.New("PrivateIpAddress is nil for instance : " + instanceID)
+		return "", fmt.Errorf("instance %v has no private IP address", instanceID)
 	}
 	klog.Infof("found results : instanceStatus is : %v , privateIp is : %v, for the given instanceId : %v", state, privateIpOut, instanceID)
 	return privateIpOut, nil
 }
 
-//UpdateInstanceProfile updates Pod EC2 IAM Association based on input name
-//func (p *EC2Provider) UpdateInstanceProfile(ctx context.Context, instanceID string, instanceProfile string) (err error) {
-//	klog.Infof("Updating Instance Profile for %s to %s", instanceID, instanceProfile)
-//	//https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_DescribeIamInstanceProfileAssociations.html
-//	// First, get association from above
-//	input := ec2.DescribeIamInstanceProfileAssociationsInput{
-//		Filters: []types.Filter{{
-//			Name:   aws.String("instance-id"),
-//			Values: []string{instanceID},
-//		},
-//			{
-//				Name:   aws.String("state"),
-//				Values: []string{"associated"},
-//			},
-//		},
-//	}
-//	resp, err := p.client.DescribeIamInstanceProfileAssociations(ctx, &input)
-//	//https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_ReplaceIamInstanceProfileAssociation.html
-//	// Then, use above to update association
-//	if err != nil {
-//		klog.Errorf("unable to describe IAM Instance Profile Associations with error %v", err)
-//	}
-//	replaceInput := ec2.ReplaceIamInstanceProfileAssociationInput{
-//		AssociationId:      resp.IamInstanceProfileAssociations[0].AssociationId,
-//		IamInstanceProfile: &types.IamInstanceProfileSpecification{Name: aws.String(instanceProfile)},
-//	}
-//	_, err = p.client.ReplaceIamInstanceProfileAssociation(ctx, &replaceInput)
-//	if err != nil {
-//		klog.Error("unable to replace IAM Instance Profile Associations with error ", err)
-//	}
-//	return err
+// UpdateInstanceProfile replaces the IAM instance profile associated with an instance with the named profile
+func UpdateInstanceProfile(ctx context.Context, ec2Client EC2API, instanceID string, instanceProfile string) error {
+	klog.Infof("Updating Instance Profile for %s to %s", instanceID, instanceProfile)
+	//https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_DescribeIamInstanceProfileAssociations.html
+	// First, get the current association
+	input := ec2.DescribeIamInstanceProfileAssociationsInput{
+		Filters: []types.Filter{
+			{
+				Name:   aws.String("instance-id"),
+				Values: []string{instanceID},
+			},
+			{
+				Name:   aws.String("state"),
+				Values: []string{"associated"},
+			},
+		},
+	}
+	resp, err := ec2Client.DescribeIamInstanceProfileAssociations(ctx, &input)
+	if err != nil {
+		klog.Errorf("unable to describe IAM Instance Profile Associations with error %v", err)
+		return err
+	}
+	if len(resp.IamInstanceProfileAssociations) == 0 {
+		return fmt.Errorf("instance %v has no IAM Instance Profile association to replace", instanceID)
+	}
+
+	//https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_ReplaceIamInstanceProfileAssociation.html
+	// Then, use the above to replace the association
+	replaceInput := ec2.ReplaceIamInstanceProfileAssociationInput{
+		AssociationId:      resp.IamInstanceProfileAssociations[0].AssociationId,
+		IamInstanceProfile: &types.IamInstanceProfileSpecification{Name: aws.String(instanceProfile)},
+	}
+	_, err = ec2Client.ReplaceIamInstanceProfileAssociation(ctx, &replaceInput)
+	if err != nil {
+		klog.Error("unable to replace IAM Instance Profile Associations with error ", err)
+	}
+	return err
+}
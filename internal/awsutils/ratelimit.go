@@ -0,0 +1,56 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package awsutils
+
+import (
+	"context"
+	"sync"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+	"golang.org/x/time/rate"
+
+	vkconfig "github.com/aws/aws-virtual-kubelet/internal/config"
+)
+
+var (
+	apiRateLimitersOnce sync.Once
+	// apiRateLimiters are the token buckets of each rate limited API action, shared by all clients
+	apiRateLimiters map[string]*rate.Limiter
+)
+
+// rateLimiter returns the token bucket of an API action (or nil if the action isn't rate limited)
+func rateLimiter(action string) *rate.Limiter {
+	apiRateLimitersOnce.Do(func() {
+		apiRateLimiters = map[string]*rate.Limiter{}
+		for name, limit := range vkconfig.Config().LaunchQueueConfig.APIRateLimits {
+			apiRateLimiters[name] = rate.NewLimiter(rate.Limit(limit.QPS), limit.Burst)
+		}
+	})
+
+	return apiRateLimiters[action]
+}
+
+// addRateLimitMiddleware adds a middleware that waits for a token from the API action's bucket before each attempt
+//
+//	(after the retry middleware, so retries and waiter polls are rate limited too)
+func addRateLimitMiddleware(stack *middleware.Stack) error {
+	return stack.Finalize.Add(middleware.FinalizeMiddlewareFunc("RateLimit",
+		func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (
+			middleware.FinalizeOutput, middleware.Metadata, error) {
+			if limiter := rateLimiter(awsmiddleware.GetOperationName(ctx)); limiter != nil {
+				if err := limiter.Wait(ctx); err != nil {
+					return middleware.FinalizeOutput{}, middleware.Metadata{}, err
+				}
+			}
+
+			return next.HandleFinalize(ctx, in)
+		}), middleware.After)
+}
@@ -11,16 +11,11 @@ package awsutils
 
 import (
 	"context"
-	"errors"
-	"net"
-	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
-	"github.com/aws/aws-sdk-go-v2/aws/transport/http"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	vkconfig "github.com/aws/aws-virtual-kubelet/internal/config"
-	"k8s.io/klog"
 )
 
 // S3Client Holds a S3 Client and Presign Client
@@ -29,31 +24,18 @@ type S3Client struct {
 	PresignSvc *s3.PresignClient
 }
 
-// NewS3Client Generates a new S3 Client to be utilized across the program.
-func NewS3Client() (*S3Client, error) {
-	// Initialize client session configuration.
-	// See the following links for an explanation of the timeout options
-	// https://aws.github.io/aws-sdk-go-v2/docs/configuring-sdk/custom-http/
-	// https://aws.github.io/aws-sdk-go-v2/docs/configuring-sdk/retries-timeouts/
-	vkcfg := vkconfig.Config()
-	httpClient := http.NewBuildableClient().WithTimeout(time.Second * time.Duration(vkcfg.AWSClientTimeoutSeconds)).WithDialerOptions(func(d *net.Dialer) {
-		d.KeepAlive = -1
-		d.Timeout = time.Second * time.Duration(vkcfg.AWSClientDialerTimeoutSeconds)
+// newS3Client creates a S3 client (and presign client) in the configured region from the shared SDK config
+func newS3Client(cfg aws.Config) *S3Client {
+	svc := s3.NewFromConfig(cfg, func(o *s3.Options) {
+		o.Region = vkconfig.Config().Region
+		o.APIOptions = append(o.APIOptions, apiOptions...)
 	})
-	cfg, err := config.LoadDefaultConfig(context.TODO(), config.WithHTTPClient(httpClient))
-	if err != nil {
-		klog.Fatalf("unable to load SDK config, %v", err)
-		return nil, errors.New("unable to find load SDK config : ")
-	}
-
-	svc := s3.NewFromConfig(cfg)
 	presignSvc := s3.NewPresignClient(svc)
 
-	// Create the AWS service client.
 	return &S3Client{
 		Svc:        svc,
 		PresignSvc: presignSvc,
-	}, nil
+	}
 }
 
 // PresignGetObject Returns the HTTP response of Getting a presigned URL to a S3 Object
@@ -0,0 +1,160 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package awsutils
+
+import (
+	"context"
+	b64 "encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	dto "github.com/prometheus/client_model/go"
+
+	vkconfig "github.com/aws/aws-virtual-kubelet/internal/config"
+	"github.com/aws/aws-virtual-kubelet/internal/metrics"
+)
+
+// initTestConfig initializes the global config (with defaults applied) for a test
+func initTestConfig(t *testing.T, pc vkconfig.ProviderConfig) {
+	t.Helper()
+
+	if pc.ManagementSubnet == "" {
+		pc.ManagementSubnet = "subnet-management"
+	}
+	if err := vkconfig.InitConfig(&vkconfig.DirectLoader{DirectConfig: pc}); err != nil {
+		t.Fatalf("unable to initialize config: %v", err)
+	}
+}
+
+// fakeS3 is a S3API that returns a fixed presigned URL (or error)
+type fakeS3 struct {
+	url   string
+	err   error
+	input *s3.GetObjectInput
+}
+
+func (f *fakeS3) PresignGetObject(ctx context.Context, params *s3.GetObjectInput) (*v4.PresignedHTTPRequest, error) {
+	f.input = params
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &v4.PresignedHTTPRequest{URL: f.url}, nil
+}
+
+// requestCount returns how many AWS API requests were recorded for a service's operation
+func requestCount(t *testing.T, service string, operation string) uint64 {
+	t.Helper()
+
+	var m dto.Metric
+	if err := metrics.AWSAPIRequestSeconds.WithLabelValues(service, operation).(interface {
+		Write(*dto.Metric) error
+	}).Write(&m); err != nil {
+		t.Fatalf("unable to read request metric: %v", err)
+	}
+	return m.GetHistogram().GetSampleCount()
+}
+
+func TestNewS3Client(t *testing.T) {
+	initTestConfig(t, vkconfig.ProviderConfig{Region: "us-west-2"})
+
+	cfg := aws.Config{
+		Region:      "us-east-1",
+		Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+	}
+	client := newS3Client(cfg)
+
+	before := requestCount(t, "S3", "GetObject")
+
+	req, err := client.PresignGetObject(context.Background(), &s3.GetObjectInput{
+		Bucket: aws.String("bootstrap-bucket"),
+		Key:    aws.String("bootstrap-agent"),
+	})
+	if err != nil {
+		t.Fatalf("PresignGetObject: unexpected error %v", err)
+	}
+
+	// the configured region overrides the SDK config's
+	if !strings.Contains(req.URL, "us-west-2") {
+		t.Errorf("expected URL in the configured region (us-west-2), got %v", req.URL)
+	}
+	if !strings.Contains(req.URL, "bootstrap-agent") {
+		t.Errorf("expected URL for the requested key, got %v", req.URL)
+	}
+
+	// the shared API options are added to the client's operations (presigning makes no request, so only metrics
+	// and tracing apply)
+	if after := requestCount(t, "S3", "GetObject"); after != before+1 {
+		t.Errorf("expected the presign request to be recorded by the metrics middleware (count %d, was %d)",
+			after, before)
+	}
+}
+
+func TestGenerateVKVMUserData(t *testing.T) {
+	tests := []struct {
+		name    string
+		s3      *fakeS3
+		want    UserData
+		wantErr bool
+	}{
+		{
+			name: "presigned URL",
+			s3:   &fakeS3{url: "https://bootstrap-bucket.s3.amazonaws.com/bootstrap-agent?X-Amz-Signature=x&y=z"},
+			want: UserData{
+				VmInit:         "vm-init",
+				BootstrapAgent: "bootstrap-agent <init>",
+				PresignedURL:   "https://bootstrap-bucket.s3.amazonaws.com/bootstrap-agent?X-Amz-Signature=x&y=z",
+			},
+		},
+		{
+			name:    "presign error",
+			s3:      &fakeS3{err: errors.New("presign failed")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userData, err := GenerateVKVMUserData(context.Background(), tt.s3, "bootstrap-bucket",
+				"bootstrap-agent", "vm-init", "bootstrap-agent <init>")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GenerateVKVMUserData() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if aws.ToString(tt.s3.input.Bucket) != "bootstrap-bucket" || aws.ToString(tt.s3.input.Key) != "bootstrap-agent" {
+				t.Errorf("expected the bootstrap agent's bucket and key to be presigned, got %v/%v",
+					aws.ToString(tt.s3.input.Bucket), aws.ToString(tt.s3.input.Key))
+			}
+			if tt.wantErr {
+				return
+			}
+
+			// user data is encoded twice (once for the agent, once for the EC2 API)
+			decoded, err := b64.StdEncoding.DecodeString(userData)
+			if err == nil {
+				decoded, err = b64.StdEncoding.DecodeString(string(decoded))
+			}
+			if err != nil {
+				t.Fatalf("unable to decode user data: %v", err)
+			}
+
+			var got UserData
+			if err = json.Unmarshal(decoded, &got); err != nil {
+				t.Fatalf("unable to parse user data %q: %v", decoded, err)
+			}
+			if got != tt.want {
+				t.Errorf("GenerateVKVMUserData() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
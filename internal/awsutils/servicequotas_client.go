@@ -0,0 +1,38 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package awsutils
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/servicequotas"
+	vkconfig "github.com/aws/aws-virtual-kubelet/internal/config"
+)
+
+// ServiceQuotasClient holds a Service Quotas client
+type ServiceQuotasClient struct {
+	Svc *servicequotas.Client
+}
+
+// newServiceQuotasClient creates a Service Quotas client in the configured region from the shared SDK config
+func newServiceQuotasClient(cfg aws.Config) *ServiceQuotasClient {
+	return &ServiceQuotasClient{
+		Svc: servicequotas.NewFromConfig(cfg, func(o *servicequotas.Options) {
+			o.Region = vkconfig.Config().Region
+			o.APIOptions = append(o.APIOptions, apiOptions...)
+		}),
+	}
+}
+
+// GetServiceQuota retrieves the applied value of a service quota
+func (client *ServiceQuotasClient) GetServiceQuota(ctx context.Context, params *servicequotas.GetServiceQuotaInput) (*servicequotas.GetServiceQuotaOutput, error) {
+	return client.Svc.GetServiceQuota(ctx, params)
+}
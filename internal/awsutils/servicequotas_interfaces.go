@@ -0,0 +1,22 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package awsutils
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/servicequotas"
+)
+
+// ServiceQuotasAPI defines the interface for Service Quotas API actions for either Mock or official AWS Client usage.
+type ServiceQuotasAPI interface {
+	// GetServiceQuota retrieves the applied value of a service quota
+	GetServiceQuota(ctx context.Context, params *servicequotas.GetServiceQuotaInput) (*servicequotas.GetServiceQuotaOutput, error)
+}
@@ -12,10 +12,6 @@ package config
 // Ec2Provider configuration defaults.
 const (
 	DefaultOperatingSystem = "Linux"
-	DefaultCpuCapacity     = "20"
-	DefaultMemoryCapacity  = "40Gi"
-	DefaultStorageCapacity = "40Gi"
-	DefaultPodCapacity     = "200"
 	DefaultConfigLocation  = "/etc/config/config.json"
 )
 
@@ -38,14 +34,53 @@ type ProviderConfig struct {
 	AWSClientDialerTimeoutSeconds int `default:"5"`
 	// Displays a status message in the log every interval
 	StatusIntervalSeconds int `default:"15"`
+	// Frequency to check node health (ENI, EC2 API access, and VKVMAgent connectivity) and report changes to k8s
+	NodeStatusIntervalSeconds int `default:"30"`
 
 	HealthConfig              HealthConfig
 	VKVMAgentConnectionConfig VkvmaConfig
+	StatsConfig               StatsConfig
+	CapacityConfig            CapacityConfig
+	OrphanCollectorConfig     OrphanCollectorConfig
+	LaunchRetryConfig         LaunchRetryConfig
+	CompletionConfig          CompletionConfig
+	EventsConfig              EventsConfig
+	FinalizerConfig           FinalizerConfig
+	LaunchQueueConfig         LaunchQueueConfig
+	AWSClientConfig           AWSClientConfig
+	TracingConfig             TracingConfig
+	HostPoolConfig            HostPoolConfig
+	SpotConfig                SpotConfig
 
 	// Optional sub-configs
 	VMConfig       VMConfig         `default:"{}"`
 	BootstrapAgent BootstrapAgent   `default:"{}"`
 	WarmPoolConfig []WarmPoolConfig `default:"-"`
+
+	// Named pod instance settings, selected by a pod's compute.amazonaws.com/profile annotation
+	ComputeProfiles map[string]ComputeProfile
+	// Profile used by pods without a compute.amazonaws.com/profile annotation (empty means no profile)
+	DefaultComputeProfile string
+}
+
+// ComputeProfile is a named set of pod instance settings.  A pod's compute.amazonaws.com/* annotations override the
+//
+//	profile's settings one by one (e.g. a pod can select a profile and only override its instance type).
+type ComputeProfile struct {
+	// AMI ID to launch instances with
+	ImageID string
+	// Instance type to launch
+	InstanceType string
+	// Subnets to launch instances in (one is chosen at random for each instance)
+	Subnets []string
+	// Security groups to set on instances
+	SecurityGroups []string
+	// Instance profile to associate with instances
+	IamInstanceProfile string
+	// Key pair to launch instances with
+	KeyPair string
+	// Tags to apply to instances (merged with the pod's tags annotation, whose values take precedence)
+	Tags map[string]string
 }
 
 // VMConfig defines Default configurations for EC2 Instances if not otherwise specified.
@@ -78,14 +113,32 @@ type WarmPoolConfig struct {
 	SecurityGroups []string
 	// Key pair used to launch warm pool instances
 	KeyPair string
-	// AMI ID to use for creation of warm pool instances
+	// AMI ID to use for creation of warm pool instances (optional with a launch template)
 	ImageID string
-	// Instance type to use for warm pool instances
+	// Instance type to use for warm pool instances (optional with a launch template)
 	InstanceType string
-	// Subnets to launch warm pool instances in
+	// Subnets to launch warm pool instances in (optional with a launch template)
 	Subnets []string
+	// Reset (via the VKVMAgent) and reuse instances when their pod is deleted, rather than terminating them
+	Recycle bool `default:"false"`
+	// Maximum number of times an instance is reused before it is terminated instead (0 means no limit)
+	MaxReuseCount int `default:"0"`
+	// How long the VKVMAgent may take to reset an instance before it is terminated instead (0 means 600)
+	ResetTimeoutSeconds int `default:"600"`
+	// Launch warm pool instances as on-demand or spot instances
+	CapacityType string `default:"on-demand"`
+	// Launch template (<id or name>[:<version>]) to launch warm pool instances from (the settings above override it)
+	LaunchTemplate string
 }
 
+// Instance capacity types (set by the compute.amazonaws.com/capacity-type annotation or WarmPoolConfig.CapacityType)
+const (
+	// CapacityTypeOnDemand launches on-demand instances
+	CapacityTypeOnDemand = "on-demand"
+	// CapacityTypeSpot launches spot instances (which EC2 can interrupt, see SpotConfig)
+	CapacityTypeSpot = "spot"
+)
+
 // HealthConfig contains podMonitor health monitoring settings and defaults
 type HealthConfig struct {
 	// Consecutive failure results required before reporting unhealthy status back to provider
@@ -96,6 +149,166 @@ type HealthConfig struct {
 	StreamRetryIntervalSeconds int `default:"10"`
 }
 
+// CapacityConfig contains settings used to calculate the node's advertised capacity and allocatable resources
+type CapacityConfig struct {
+	// CPU capacity of the node (in vCPUs), further limited by the vCPU service quotas below
+	CPU string `default:"20"`
+	// Memory capacity of the node
+	Memory string `default:"40Gi"`
+	// Storage capacity of the node
+	Storage string `default:"40Gi"`
+	// Maximum number of pods the node will accept
+	Pods string `default:"200"`
+	// EC2 Service Quotas codes for vCPU limits that apply to instances launched by this node (e.g. "L-1216C47A" for
+	//  Running On-Demand Standard instances).  The sum of these quotas limits the node's CPU capacity.
+	VCPUQuotaCodes []string `default:"[\"L-1216C47A\"]"`
+	// Don't check vCPU service quotas (CPU capacity is then only limited by the CPU setting above)
+	IgnoreServiceQuotas bool `default:"false"`
+	// How often to refresh service quota values (quotas change infrequently)
+	QuotaRefreshIntervalSeconds int `default:"3600"`
+}
+
+// LaunchRetryConfig contains settings for retrying failed pod provisioning (EC2 launch, agent connection, and
+//
+//	application launch)
+type LaunchRetryConfig struct {
+	// Maximum number of provisioning attempts per pod before the pod is marked Failed
+	MaxAttempts int `default:"5"`
+	// Delay before the first retry
+	InitialBackoffSeconds int `default:"10"`
+	// Maximum delay between retries
+	MaxBackoffSeconds int `default:"300"`
+	// Factor the delay is multiplied by after each failed attempt
+	BackoffMultiplier float64 `default:"2"`
+}
+
+// Instance policies for completed pods
+const (
+	// CompletionPolicyTerminate terminates a completed pod's instance
+	CompletionPolicyTerminate = "Terminate"
+	// CompletionPolicyRelease returns a completed pod's warm pool instance to the pool (other instances are terminated)
+	CompletionPolicyRelease = "Release"
+	// CompletionPolicyRetain keeps a completed pod's instance until the pod is deleted
+	CompletionPolicyRetain = "Retain"
+)
+
+// CompletionConfig contains settings for pods whose containers have all terminated (e.g. pods created by Jobs)
+type CompletionConfig struct {
+	// What to do with a completed pod's instance (Terminate, Release, or Retain)
+	InstancePolicy string `default:"Terminate"`
+}
+
+// EventsConfig contains settings for the Kubernetes events emitted on pods as they are provisioned, monitored, and
+//
+//	terminated.  Similar events are aggregated and each pod's events are rate limited (see
+//	k8s.io/client-go/tools/record.CorrelatorOptions).
+type EventsConfig struct {
+	// Emit pod events (visible via `kubectl describe pod`)
+	Enabled bool `default:"true"`
+	// Number of events that can be emitted for a pod at once before rate limiting applies
+	BurstSize int `default:"25"`
+	// Rate (events per second) at which a pod's burst allowance refills
+	QPS float32 `default:"0.0033"`
+	// Number of similar events (same reason, different messages) after which they are combined into one
+	MaxSimilarEvents int `default:"10"`
+	// Interval over which similar events are combined
+	AggregationIntervalSeconds int `default:"600"`
+}
+
+// FinalizerConfig contains settings for the finalizer that keeps a pod (in k8s) until its instance is cleaned up
+type FinalizerConfig struct {
+	// Add the cleanup finalizer to pods and reconcile pods deleted (e.g. force deleted) without the provider's cleanup
+	Enabled bool `default:"true"`
+	// How often to look for deleted pods whose cleanup hasn't completed
+	ReconcileIntervalSeconds int `default:"60"`
+}
+
+// LaunchQueueConfig contains settings for admitting pod instance launches and rate limiting EC2 API calls, so scaling
+//
+//	up many pods at once doesn't cause EC2 API throttling
+type LaunchQueueConfig struct {
+	// Maximum number of pod instances launched (from RunInstances until the instance is running) at once (0 means no
+	// limit).  Other pods wait in the launch queue.
+	MaxInFlightLaunches int `default:"10"`
+	// Token bucket for each EC2 API action (e.g. RunInstances) keyed by action name.  Actions without a bucket aren't
+	// rate limited.  Setting this replaces the defaults.
+	APIRateLimits map[string]APIRateLimit `default:"{\"RunInstances\":{\"QPS\":2,\"Burst\":5},\"DescribeInstances\":{\"QPS\":20,\"Burst\":100},\"CreateTags\":{\"QPS\":10,\"Burst\":100},\"TerminateInstances\":{\"QPS\":5,\"Burst\":100}}"`
+}
+
+// APIRateLimit is a token bucket that limits the rate of calls to an API action
+type APIRateLimit struct {
+	// Rate (calls per second) at which the bucket refills
+	QPS float64
+	// Number of calls that can be made at once (the bucket size)
+	Burst int
+}
+
+// AWSClientConfig contains settings for the shared AWS service clients
+type AWSClientConfig struct {
+	// Maximum number of attempts (including the first) of each AWS API call, using the SDK's adaptive retry mode
+	MaxAttempts int `default:"5"`
+	// Maximum backoff between attempts
+	MaxBackoffSeconds int `default:"20"`
+}
+
+// TracingConfig contains settings for exporting OpenTelemetry traces of pod operations, AWS API calls, and VKVMAgent
+//
+//	RPCs
+type TracingConfig struct {
+	// Trace exporter ("none" to disable exporting or "otlp" to export to an OTLP collector via gRPC)
+	Exporter string `default:"none"`
+	// OTLP collector endpoint (host:port)
+	Endpoint string `default:"localhost:4317"`
+	// Connect to the OTLP collector without TLS
+	Insecure bool `default:"false"`
+	// Fraction of traces to sample (from 0 to 1)
+	SampleRatio float64 `default:"1"`
+	// Service name traces are reported with
+	ServiceName string `default:"aws-virtual-kubelet"`
+}
+
+// HostPoolConfig contains settings for launching instance types that require a dedicated host (e.g. EC2 Mac instances)
+//
+//	on hosts allocated (and released) by the provider
+type HostPoolConfig struct {
+	// Launch the instance types below on dedicated hosts from the provider's host pool
+	Enabled bool `default:"false"`
+	// Instance types launched on dedicated hosts (one instance per host)
+	InstanceTypes []string `default:"[\"mac1.metal\",\"mac2.metal\"]"`
+	// Maximum number of hosts the pool allocates (0 means no limit)
+	MaxHosts int `default:"0"`
+	// Minimum time a host must be allocated before it can be released (EC2 Mac hosts have a 24 hour minimum)
+	MinimumAllocationHours int `default:"24"`
+	// How long a host must be idle (without an instance, and not being scrubbed) before it is released
+	IdleReleaseMinutes int `default:"60"`
+	// How long to wait for a newly allocated host to become available
+	AllocationTimeoutSeconds int `default:"600"`
+	// How often host states are refreshed (and idle hosts released)
+	ReconcileIntervalSeconds int `default:"300"`
+}
+
+// OrphanCollectorConfig contains settings for finding and terminating pod instances whose pod no longer exists
+type OrphanCollectorConfig struct {
+	// Periodically look for (and terminate) orphaned pod instances (opt-in, since a misidentified orphan is terminated)
+	Enabled bool `default:"false"`
+	// Log orphaned instances that would be terminated without terminating them
+	DryRun bool `default:"false"`
+	// How often to look for orphaned instances
+	IntervalSeconds int `default:"300"`
+	// How long an instance must remain orphaned before it is terminated
+	GracePeriodSeconds int `default:"600"`
+	// Instances with this tag (any value) are never terminated by the collector
+	ExclusionTagKey string `default:"aws-virtual-kubelet/OrphanCollectorExclude"`
+}
+
+// StatsConfig contains settings for collecting pod resource usage stats from VKVMAgents
+type StatsConfig struct {
+	// Maximum number of pods to request stats from concurrently
+	MaxConcurrentRequests int `default:"10"`
+	// Time allowed for each pod's stats request to complete (pods that don't respond in time are omitted)
+	TimeoutSeconds int `default:"5"`
+}
+
 // VkvmaConfig contains VKVMAgent connection and related settings
 type VkvmaConfig struct {
 	Port int `default:"8200"`
@@ -137,3 +350,14 @@ var config *ProviderConfig
 func Config() *ProviderConfig {
 	return config
 }
+
+// SpotConfig contains settings for pods running on spot instances, which are evicted when the VKVMAgent reports that
+//
+//	their instance will be interrupted
+type SpotConfig struct {
+	// Also evict pods when their instance receives a rebalance recommendation (an early warning of interruption)
+	EvictOnRebalanceRecommendation bool `default:"false"`
+	// Time reserved before an interruption for the pod's deletion to finish (the pod's grace period is shortened so
+	//	its application is stopped this long before the instance is interrupted)
+	EvictionMarginSeconds int `default:"10"`
+}
@@ -17,7 +17,10 @@ import (
 	"reflect"
 	"strings"
 
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/klog/v2"
+
+	"github.com/aws/aws-virtual-kubelet/internal/utils"
 )
 
 // Loader is a generic interface describing the functions of a config loader
@@ -83,8 +86,24 @@ func validate(pc *ProviderConfig) error {
 	if pc.ManagementSubnet == "" {
 		errs = append(errs, "ManagementSubnet is required")
 	}
+	if pc.NodeStatusIntervalSeconds < 1 {
+		errs = append(errs, "NodeStatusIntervalSeconds must be at least 1")
+	}
 
 	errs = validateWarmPoolConfig(pc, errs)
+	errs = validateStatsConfig(pc, errs)
+	errs = validateCapacityConfig(pc, errs)
+	errs = validateOrphanCollectorConfig(pc, errs)
+	errs = validateLaunchRetryConfig(pc, errs)
+	errs = validateCompletionConfig(pc, errs)
+	errs = validateEventsConfig(pc, errs)
+	errs = validateFinalizerConfig(pc, errs)
+	errs = validateLaunchQueueConfig(pc, errs)
+	errs = validateAWSClientConfig(pc, errs)
+	errs = validateTracingConfig(pc, errs)
+	errs = validateHostPoolConfig(pc, errs)
+	errs = validateSpotConfig(pc, errs)
+	errs = validateComputeProfiles(pc, errs)
 
 	if len(errs) > 0 {
 		return fmt.Errorf("config validation failed: %v", strings.Join(errs, ", "))
@@ -98,16 +117,214 @@ func validateWarmPoolConfig(pc *ProviderConfig, errs []string) []string {
 	// if any warm pool configs are provided, validate required members for each
 	if pc.WarmPoolConfig != nil && len(pc.WarmPoolConfig) > 0 {
 		for i, wpc := range pc.WarmPoolConfig {
-			if wpc.ImageID == "" {
-				errs = append(errs, fmt.Sprintf("WarmPoolConfig.ImageID is required for WarmPoolConfig[%d]", i))
+			// a launch template can provide the image, instance type, and network settings instead
+			if wpc.LaunchTemplate != "" {
+				if _, err := utils.ParseLaunchTemplate(wpc.LaunchTemplate); err != nil {
+					errs = append(errs, fmt.Sprintf("WarmPoolConfig.LaunchTemplate is invalid for WarmPoolConfig[%d]: %v",
+						i, err))
+				}
+			} else {
+				if wpc.ImageID == "" {
+					errs = append(errs, fmt.Sprintf("WarmPoolConfig.ImageID is required for WarmPoolConfig[%d]", i))
+				}
+				if wpc.InstanceType == "" {
+					errs = append(errs, fmt.Sprintf("WarmPoolConfig.InstanceType is required for WarmPoolConfig[%d]", i))
+				}
+				if len(wpc.Subnets) == 0 {
+					errs = append(errs, fmt.Sprintf("WarmPoolConfig.Subnets can't be empty for WarmPoolConfig[%d]", i))
+				}
 			}
-			if wpc.InstanceType == "" {
-				errs = append(errs, fmt.Sprintf("WarmPoolConfig.InstanceType is required for WarmPoolConfig[%d]", i))
+			if wpc.MaxReuseCount < 0 || wpc.ResetTimeoutSeconds < 0 {
+				errs = append(errs, fmt.Sprintf(
+					"WarmPoolConfig.MaxReuseCount and ResetTimeoutSeconds can't be negative for WarmPoolConfig[%d]", i))
 			}
-			if len(wpc.Subnets) == 0 {
-				errs = append(errs, fmt.Sprintf("WarmPoolConfig.Subnets can't be empty for WarmPoolConfig[%d]", i))
+			switch wpc.CapacityType {
+			case "", CapacityTypeOnDemand, CapacityTypeSpot:
+			default:
+				errs = append(errs, fmt.Sprintf("WarmPoolConfig.CapacityType %q must be %v or %v for WarmPoolConfig[%d]",
+					wpc.CapacityType, CapacityTypeOnDemand, CapacityTypeSpot, i))
 			}
 		}
 	}
 	return errs
 }
+
+// validateStatsConfig checks the stats sub-configuration for errors
+func validateStatsConfig(pc *ProviderConfig, errs []string) []string {
+	if pc.StatsConfig.MaxConcurrentRequests < 1 || pc.StatsConfig.TimeoutSeconds < 1 {
+		errs = append(errs, "StatsConfig.MaxConcurrentRequests and TimeoutSeconds must be at least 1")
+	}
+	return errs
+}
+
+// validateCapacityConfig checks the capacity sub-configuration for errors
+func validateCapacityConfig(pc *ProviderConfig, errs []string) []string {
+	quantities := map[string]string{
+		"CPU":     pc.CapacityConfig.CPU,
+		"Memory":  pc.CapacityConfig.Memory,
+		"Storage": pc.CapacityConfig.Storage,
+		"Pods":    pc.CapacityConfig.Pods,
+	}
+
+	for _, name := range []string{"CPU", "Memory", "Storage", "Pods"} {
+		if value := quantities[name]; value != "" {
+			if _, err := resource.ParseQuantity(value); err != nil {
+				errs = append(errs, fmt.Sprintf("CapacityConfig.%v is not a valid quantity: %v", name, err))
+			}
+		}
+	}
+	return errs
+}
+
+// validateOrphanCollectorConfig checks the orphan collector sub-configuration for errors
+func validateOrphanCollectorConfig(pc *ProviderConfig, errs []string) []string {
+	if pc.OrphanCollectorConfig.IntervalSeconds < 0 {
+		errs = append(errs, "OrphanCollectorConfig.IntervalSeconds can't be negative")
+	} else if pc.OrphanCollectorConfig.Enabled && pc.OrphanCollectorConfig.IntervalSeconds < 1 {
+		errs = append(errs, "OrphanCollectorConfig.IntervalSeconds must be at least 1")
+	}
+	if pc.OrphanCollectorConfig.GracePeriodSeconds < 0 {
+		errs = append(errs, "OrphanCollectorConfig.GracePeriodSeconds can't be negative")
+	}
+	return errs
+}
+
+// validateLaunchRetryConfig checks the launch retry sub-configuration for errors
+func validateLaunchRetryConfig(pc *ProviderConfig, errs []string) []string {
+	lrc := pc.LaunchRetryConfig
+	if lrc.MaxAttempts < 0 {
+		errs = append(errs, "LaunchRetryConfig.MaxAttempts can't be negative")
+	}
+	if lrc.InitialBackoffSeconds < 0 || lrc.MaxBackoffSeconds < 0 {
+		errs = append(errs, "LaunchRetryConfig backoff seconds can't be negative")
+	}
+	if lrc.MaxBackoffSeconds != 0 && lrc.InitialBackoffSeconds > lrc.MaxBackoffSeconds {
+		errs = append(errs, "LaunchRetryConfig.InitialBackoffSeconds can't exceed MaxBackoffSeconds")
+	}
+	if lrc.BackoffMultiplier != 0 && lrc.BackoffMultiplier < 1 {
+		errs = append(errs, "LaunchRetryConfig.BackoffMultiplier must be at least 1")
+	}
+	return errs
+}
+
+// validateCompletionConfig checks the completion sub-configuration for errors
+func validateCompletionConfig(pc *ProviderConfig, errs []string) []string {
+	switch pc.CompletionConfig.InstancePolicy {
+	case "", CompletionPolicyTerminate, CompletionPolicyRelease, CompletionPolicyRetain:
+	default:
+		errs = append(errs, fmt.Sprintf("CompletionConfig.InstancePolicy must be one of %v, %v, or %v (got %q)",
+			CompletionPolicyTerminate, CompletionPolicyRelease, CompletionPolicyRetain,
+			pc.CompletionConfig.InstancePolicy))
+	}
+	return errs
+}
+
+// validateEventsConfig checks the events sub-configuration for errors
+func validateEventsConfig(pc *ProviderConfig, errs []string) []string {
+	ec := pc.EventsConfig
+	if ec.BurstSize < 0 || ec.QPS < 0 || ec.MaxSimilarEvents < 0 || ec.AggregationIntervalSeconds < 0 {
+		errs = append(errs, "EventsConfig values can't be negative")
+	}
+	return errs
+}
+
+// validateFinalizerConfig checks the finalizer sub-configuration for errors
+func validateFinalizerConfig(pc *ProviderConfig, errs []string) []string {
+	if pc.FinalizerConfig.Enabled && pc.FinalizerConfig.ReconcileIntervalSeconds < 1 {
+		errs = append(errs, "FinalizerConfig.ReconcileIntervalSeconds must be at least 1")
+	}
+	return errs
+}
+
+// validateLaunchQueueConfig checks the launch queue sub-configuration for errors
+func validateLaunchQueueConfig(pc *ProviderConfig, errs []string) []string {
+	if pc.LaunchQueueConfig.MaxInFlightLaunches < 0 {
+		errs = append(errs, "LaunchQueueConfig.MaxInFlightLaunches can't be negative")
+	}
+	for action, limit := range pc.LaunchQueueConfig.APIRateLimits {
+		if limit.QPS <= 0 || limit.Burst < 1 {
+			errs = append(errs, fmt.Sprintf(
+				"LaunchQueueConfig.APIRateLimits[%v] must have a positive QPS and a Burst of at least 1", action))
+		}
+	}
+	return errs
+}
+
+// validateAWSClientConfig checks the AWS client sub-configuration for errors
+func validateAWSClientConfig(pc *ProviderConfig, errs []string) []string {
+	// NOTE zero values leave the SDK defaults in place
+	if pc.AWSClientConfig.MaxAttempts < 0 || pc.AWSClientConfig.MaxBackoffSeconds < 0 {
+		errs = append(errs, "AWSClientConfig.MaxAttempts and MaxBackoffSeconds can't be negative")
+	}
+	return errs
+}
+
+// validateTracingConfig checks the tracing sub-configuration for errors
+func validateTracingConfig(pc *ProviderConfig, errs []string) []string {
+	switch pc.TracingConfig.Exporter {
+	// NOTE an empty exporter (e.g. a config constructed in code) is the same as "none"
+	case "", "none":
+	case "otlp":
+		if pc.TracingConfig.Endpoint == "" {
+			errs = append(errs, "TracingConfig.Endpoint is required for the otlp exporter")
+		}
+	default:
+		errs = append(errs, fmt.Sprintf("TracingConfig.Exporter %q must be none or otlp", pc.TracingConfig.Exporter))
+	}
+	if pc.TracingConfig.SampleRatio < 0 || pc.TracingConfig.SampleRatio > 1 {
+		errs = append(errs, "TracingConfig.SampleRatio must be from 0 to 1")
+	}
+	return errs
+}
+
+// validateHostPoolConfig checks the host pool sub-configuration for errors
+func validateHostPoolConfig(pc *ProviderConfig, errs []string) []string {
+	hpc := pc.HostPoolConfig
+	if !hpc.Enabled {
+		return errs
+	}
+	if len(hpc.InstanceTypes) == 0 {
+		errs = append(errs, "HostPoolConfig.InstanceTypes can't be empty")
+	}
+	if hpc.MaxHosts < 0 || hpc.MinimumAllocationHours < 0 || hpc.IdleReleaseMinutes < 0 {
+		errs = append(errs, "HostPoolConfig.MaxHosts, MinimumAllocationHours, and IdleReleaseMinutes can't be negative")
+	}
+	if hpc.AllocationTimeoutSeconds < 1 || hpc.ReconcileIntervalSeconds < 1 {
+		errs = append(errs, "HostPoolConfig.AllocationTimeoutSeconds and ReconcileIntervalSeconds must be at least 1")
+	}
+	return errs
+}
+
+// validateSpotConfig checks the spot sub-configuration for errors
+func validateSpotConfig(pc *ProviderConfig, errs []string) []string {
+	if pc.SpotConfig.EvictionMarginSeconds < 0 {
+		errs = append(errs, "SpotConfig.EvictionMarginSeconds can't be negative")
+	}
+	return errs
+}
+
+// validateComputeProfiles checks the compute profiles (and the default profile) for errors
+func validateComputeProfiles(pc *ProviderConfig, errs []string) []string {
+	for name, profile := range pc.ComputeProfiles {
+		if name == "" {
+			errs = append(errs, "ComputeProfiles names can't be empty")
+		}
+		for _, value := range append(append([]string{}, profile.Subnets...), profile.SecurityGroups...) {
+			if value == "" {
+				errs = append(errs, fmt.Sprintf("ComputeProfiles[%v] Subnets and SecurityGroups can't contain empty values",
+					name))
+				break
+			}
+		}
+		if _, ok := profile.Tags[""]; ok {
+			errs = append(errs, fmt.Sprintf("ComputeProfiles[%v].Tags keys can't be empty", name))
+		}
+	}
+	if pc.DefaultComputeProfile != "" {
+		if _, ok := pc.ComputeProfiles[pc.DefaultComputeProfile]; !ok {
+			errs = append(errs, fmt.Sprintf("DefaultComputeProfile %q isn't in ComputeProfiles",
+				pc.DefaultComputeProfile))
+		}
+	}
+	return errs
+}
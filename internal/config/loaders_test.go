@@ -87,7 +87,9 @@ func TestDirectLoader_validate(t *testing.T) {
 			fields: fields{},
 			args: args{
 				pc: &ProviderConfig{
-					ManagementSubnet: ".",
+					ManagementSubnet:          ".",
+					NodeStatusIntervalSeconds: 30,
+					StatsConfig:               StatsConfig{MaxConcurrentRequests: 10, TimeoutSeconds: 5},
 				},
 			},
 			wantErr: false,
@@ -166,7 +168,9 @@ func TestFileLoader_validate(t *testing.T) {
 			name: "Valid config",
 			args: args{
 				pc: &ProviderConfig{
-					ManagementSubnet: ".",
+					ManagementSubnet:          ".",
+					NodeStatusIntervalSeconds: 30,
+					StatsConfig:               StatsConfig{MaxConcurrentRequests: 10, TimeoutSeconds: 5},
 				},
 			},
 			wantErr: false,
@@ -198,7 +202,9 @@ func Test_validate(t *testing.T) {
 			name: "Valid config",
 			args: args{
 				pc: &ProviderConfig{
-					ManagementSubnet: ".",
+					ManagementSubnet:          ".",
+					NodeStatusIntervalSeconds: 30,
+					StatsConfig:               StatsConfig{MaxConcurrentRequests: 10, TimeoutSeconds: 5},
 				},
 			},
 			wantErr: false,
@@ -222,7 +228,9 @@ func Test_validate(t *testing.T) {
 			args: args{
 
 				pc: &ProviderConfig{
-					ManagementSubnet: ".",
+					ManagementSubnet:          ".",
+					NodeStatusIntervalSeconds: 30,
+					StatsConfig:               StatsConfig{MaxConcurrentRequests: 10, TimeoutSeconds: 5},
 					WarmPoolConfig: []WarmPoolConfig{
 						{
 							ImageID:      "ami-badf005ba117ab1e5",
@@ -240,9 +248,346 @@ func Test_validate(t *testing.T) {
 			name: "Warm Pool config with missing required values",
 			args: args{
 
+				pc: &ProviderConfig{
+					ManagementSubnet:          ".",
+					NodeStatusIntervalSeconds: 30,
+					StatsConfig:               StatsConfig{MaxConcurrentRequests: 10, TimeoutSeconds: 5},
+					WarmPoolConfig:            []WarmPoolConfig{{}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Warm Pool config with negative max reuse count",
+			args: args{
+
+				pc: &ProviderConfig{
+					ManagementSubnet:          ".",
+					NodeStatusIntervalSeconds: 30,
+					StatsConfig:               StatsConfig{MaxConcurrentRequests: 10, TimeoutSeconds: 5},
+					WarmPoolConfig: []WarmPoolConfig{
+						{
+							ImageID:       "ami-badf005ba117ab1e5",
+							InstanceType:  "m72.ginormous",
+							Subnets:       []string{"sg-badf005ba117ab1e5"},
+							Recycle:       true,
+							MaxReuseCount: -1,
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Zero node status interval",
+			args: args{
 				pc: &ProviderConfig{
 					ManagementSubnet: ".",
-					WarmPoolConfig:   []WarmPoolConfig{{}},
+					StatsConfig:      StatsConfig{MaxConcurrentRequests: 10, TimeoutSeconds: 5},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Stats config with negative max concurrent requests",
+			args: args{
+				pc: &ProviderConfig{
+					ManagementSubnet:          ".",
+					NodeStatusIntervalSeconds: 30,
+					StatsConfig:               StatsConfig{MaxConcurrentRequests: -1, TimeoutSeconds: 5},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Valid config with Capacity",
+			args: args{
+				pc: &ProviderConfig{
+					ManagementSubnet:          ".",
+					NodeStatusIntervalSeconds: 30,
+					StatsConfig:               StatsConfig{MaxConcurrentRequests: 10, TimeoutSeconds: 5},
+					CapacityConfig: CapacityConfig{
+						CPU:    "96",
+						Memory: "384Gi",
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Capacity config with invalid quantity",
+			args: args{
+				pc: &ProviderConfig{
+					ManagementSubnet:          ".",
+					NodeStatusIntervalSeconds: 30,
+					StatsConfig:               StatsConfig{MaxConcurrentRequests: 10, TimeoutSeconds: 5},
+					CapacityConfig: CapacityConfig{
+						Memory: "lots",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Orphan collector config with negative grace period",
+			args: args{
+				pc: &ProviderConfig{
+					ManagementSubnet:          ".",
+					NodeStatusIntervalSeconds: 30,
+					StatsConfig:               StatsConfig{MaxConcurrentRequests: 10, TimeoutSeconds: 5},
+					OrphanCollectorConfig: OrphanCollectorConfig{
+						GracePeriodSeconds: -1,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Enabled orphan collector config without interval",
+			args: args{
+				pc: &ProviderConfig{
+					ManagementSubnet:          ".",
+					NodeStatusIntervalSeconds: 30,
+					StatsConfig:               StatsConfig{MaxConcurrentRequests: 10, TimeoutSeconds: 5},
+					OrphanCollectorConfig: OrphanCollectorConfig{
+						Enabled:         true,
+						IntervalSeconds: 0,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Valid config with LaunchRetry",
+			args: args{
+				pc: &ProviderConfig{
+					ManagementSubnet:          ".",
+					NodeStatusIntervalSeconds: 30,
+					StatsConfig:               StatsConfig{MaxConcurrentRequests: 10, TimeoutSeconds: 5},
+					LaunchRetryConfig: LaunchRetryConfig{
+						MaxAttempts:           3,
+						InitialBackoffSeconds: 5,
+						MaxBackoffSeconds:     60,
+						BackoffMultiplier:     1.5,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "LaunchRetry config with multiplier below 1",
+			args: args{
+				pc: &ProviderConfig{
+					ManagementSubnet:          ".",
+					NodeStatusIntervalSeconds: 30,
+					StatsConfig:               StatsConfig{MaxConcurrentRequests: 10, TimeoutSeconds: 5},
+					LaunchRetryConfig: LaunchRetryConfig{
+						BackoffMultiplier: 0.5,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Events config with negative burst size",
+			args: args{
+				pc: &ProviderConfig{
+					ManagementSubnet:          ".",
+					NodeStatusIntervalSeconds: 30,
+					StatsConfig:               StatsConfig{MaxConcurrentRequests: 10, TimeoutSeconds: 5},
+					EventsConfig: EventsConfig{
+						Enabled:   true,
+						BurstSize: -1,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Finalizer config with zero reconcile interval",
+			args: args{
+				pc: &ProviderConfig{
+					ManagementSubnet:          ".",
+					NodeStatusIntervalSeconds: 30,
+					StatsConfig:               StatsConfig{MaxConcurrentRequests: 10, TimeoutSeconds: 5},
+					FinalizerConfig: FinalizerConfig{
+						Enabled: true,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Launch queue config with zero burst",
+			args: args{
+				pc: &ProviderConfig{
+					ManagementSubnet:          ".",
+					NodeStatusIntervalSeconds: 30,
+					StatsConfig:               StatsConfig{MaxConcurrentRequests: 10, TimeoutSeconds: 5},
+					LaunchQueueConfig: LaunchQueueConfig{
+						APIRateLimits: map[string]APIRateLimit{
+							"RunInstances": {QPS: 2},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "AWS client config with negative max attempts",
+			args: args{
+				pc: &ProviderConfig{
+					ManagementSubnet:          ".",
+					NodeStatusIntervalSeconds: 30,
+					StatsConfig:               StatsConfig{MaxConcurrentRequests: 10, TimeoutSeconds: 5},
+					AWSClientConfig: AWSClientConfig{
+						MaxAttempts: -1,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Tracing config with unknown exporter",
+			args: args{
+				pc: &ProviderConfig{
+					ManagementSubnet:          ".",
+					NodeStatusIntervalSeconds: 30,
+					StatsConfig:               StatsConfig{MaxConcurrentRequests: 10, TimeoutSeconds: 5},
+					TracingConfig: TracingConfig{
+						Exporter: "jaeger",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Host pool config without instance types",
+			args: args{
+				pc: &ProviderConfig{
+					ManagementSubnet:          ".",
+					NodeStatusIntervalSeconds: 30,
+					StatsConfig:               StatsConfig{MaxConcurrentRequests: 10, TimeoutSeconds: 5},
+					HostPoolConfig: HostPoolConfig{
+						Enabled:                  true,
+						AllocationTimeoutSeconds: 600,
+						ReconcileIntervalSeconds: 300,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Warm Pool config with unknown capacity type",
+			args: args{
+				pc: &ProviderConfig{
+					ManagementSubnet:          ".",
+					NodeStatusIntervalSeconds: 30,
+					StatsConfig:               StatsConfig{MaxConcurrentRequests: 10, TimeoutSeconds: 5},
+					WarmPoolConfig: []WarmPoolConfig{
+						{
+							ImageID:      "ami-badf005ba117ab1e5",
+							InstanceType: "m72.ginormous",
+							Subnets:      []string{"sg-badf005ba117ab1e5"},
+							CapacityType: "reserved",
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Valid Warm Pool config with launch template",
+			args: args{
+				pc: &ProviderConfig{
+					ManagementSubnet:          ".",
+					NodeStatusIntervalSeconds: 30,
+					StatsConfig:               StatsConfig{MaxConcurrentRequests: 10, TimeoutSeconds: 5},
+					WarmPoolConfig: []WarmPoolConfig{
+						{
+							LaunchTemplate: "lt-0123456789abcdef0:3",
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Warm Pool config with invalid launch template version",
+			args: args{
+				pc: &ProviderConfig{
+					ManagementSubnet:          ".",
+					NodeStatusIntervalSeconds: 30,
+					StatsConfig:               StatsConfig{MaxConcurrentRequests: 10, TimeoutSeconds: 5},
+					WarmPoolConfig: []WarmPoolConfig{
+						{
+							LaunchTemplate: "mac-builder:latest",
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Valid compute profiles with default profile",
+			args: args{
+				pc: &ProviderConfig{
+					ManagementSubnet:          ".",
+					NodeStatusIntervalSeconds: 30,
+					StatsConfig:               StatsConfig{MaxConcurrentRequests: 10, TimeoutSeconds: 5},
+					ComputeProfiles: map[string]ComputeProfile{
+						"mac-builder": {
+							ImageID:        "ami-badf005ba117ab1e5",
+							InstanceType:   "mac2.metal",
+							Subnets:        []string{"subnet-badf005ba117ab1e5"},
+							SecurityGroups: []string{"sg-badf005ba117ab1e5"},
+							Tags:           map[string]string{"team": "builds"},
+						},
+					},
+					DefaultComputeProfile: "mac-builder",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Default compute profile that isn't defined",
+			args: args{
+				pc: &ProviderConfig{
+					ManagementSubnet:          ".",
+					NodeStatusIntervalSeconds: 30,
+					StatsConfig:               StatsConfig{MaxConcurrentRequests: 10, TimeoutSeconds: 5},
+					ComputeProfiles: map[string]ComputeProfile{
+						"mac-builder": {InstanceType: "mac2.metal"},
+					},
+					DefaultComputeProfile: "linux-builder",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Compute profile with an empty subnet",
+			args: args{
+				pc: &ProviderConfig{
+					ManagementSubnet:          ".",
+					NodeStatusIntervalSeconds: 30,
+					StatsConfig:               StatsConfig{MaxConcurrentRequests: 10, TimeoutSeconds: 5},
+					ComputeProfiles: map[string]ComputeProfile{
+						"mac-builder": {Subnets: []string{""}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Completion config with unknown instance policy",
+			args: args{
+				pc: &ProviderConfig{
+					ManagementSubnet:          ".",
+					NodeStatusIntervalSeconds: 30,
+					StatsConfig:               StatsConfig{MaxConcurrentRequests: 10, TimeoutSeconds: 5},
+					CompletionConfig: CompletionConfig{
+						InstancePolicy: "Recycle",
+					},
 				},
 			},
 			wantErr: true,
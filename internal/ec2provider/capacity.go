@@ -0,0 +1,278 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package ec2provider
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/servicequotas"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/klog/v2"
+
+	"github.com/aws/aws-virtual-kubelet/internal/awsutils"
+	"github.com/aws/aws-virtual-kubelet/internal/config"
+	"github.com/aws/aws-virtual-kubelet/internal/metrics"
+)
+
+// ec2ServiceCode is the Service Quotas service code for EC2
+const ec2ServiceCode = "ec2"
+
+// capacityManager calculates the node's capacity (from config and EC2 vCPU service quotas) and allocatable resources
+//
+//	(capacity less the resources of the instance type each pod runs on)
+type capacityManager struct {
+	ec2Client   awsutils.EC2API
+	quotaClient awsutils.ServiceQuotasAPI
+
+	mu sync.Mutex
+	// vcpuQuota is the total of the configured vCPU quotas (nil until successfully retrieved)
+	vcpuQuota *resource.Quantity
+	// quotaCheckTime is when quotas were last retrieved (successfully or not)
+	quotaCheckTime time.Time
+	// instanceTypes caches the resources (cpu and memory) of each instance type
+	instanceTypes map[string]corev1.ResourceList
+	// instanceTypeByID caches the instance type of instances belonging to pods without an instance-type annotation
+	instanceTypeByID map[string]string
+}
+
+func newCapacityManager(ec2Client awsutils.EC2API, quotaClient awsutils.ServiceQuotasAPI) *capacityManager {
+	return &capacityManager{
+		ec2Client:        ec2Client,
+		quotaClient:      quotaClient,
+		instanceTypes:    map[string]corev1.ResourceList{},
+		instanceTypeByID: map[string]string{},
+	}
+}
+
+// Resources returns the node's capacity and its allocatable resources given the pods placed on it.  Completed pods
+//
+//	don't count against allocatable resources (their instances are terminated or released).
+func (cm *capacityManager) Resources(
+	ctx context.Context, placedPods []*corev1.Pod) (corev1.ResourceList, corev1.ResourceList) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	capacity := cm.capacity(ctx)
+	allocatable := capacity.DeepCopy()
+
+	var pods []*corev1.Pod
+	for _, pod := range placedPods {
+		if !podCompleted(pod) {
+			pods = append(pods, pod)
+		}
+	}
+
+	cm.lookupInstanceTypes(ctx, pods)
+
+	for _, pod := range pods {
+		for name, used := range cm.podResources(pod) {
+			available, ok := allocatable[name]
+			if !ok {
+				continue
+			}
+			available.Sub(used)
+			allocatable[name] = available
+		}
+
+		pods := allocatable[corev1.ResourcePods]
+		pods.Sub(resource.MustParse("1"))
+		allocatable[corev1.ResourcePods] = pods
+	}
+
+	// the node may be over-committed (e.g. if capacity is reduced after pods are placed)
+	for name, available := range allocatable {
+		if available.Sign() < 0 {
+			allocatable[name] = *resource.NewQuantity(0, available.Format)
+		}
+	}
+
+	return capacity, allocatable
+}
+
+// capacity returns the configured capacity with CPU limited by vCPU service quotas (caller must hold the lock)
+func (cm *capacityManager) capacity(ctx context.Context) corev1.ResourceList {
+	cfg := config.Config().CapacityConfig
+	capacity := configuredCapacity(cfg)
+
+	if cfg.IgnoreServiceQuotas {
+		return capacity
+	}
+
+	if quota := cm.getVCPUQuota(ctx, cfg); quota != nil && quota.Cmp(capacity[corev1.ResourceCPU]) < 0 {
+		capacity[corev1.ResourceCPU] = quota.DeepCopy()
+	}
+
+	return capacity
+}
+
+// getVCPUQuota returns the total of the configured vCPU quotas, refreshing them if needed (caller must hold the lock).
+//
+//	If quotas can't be retrieved the previous value (if any) is retained.
+func (cm *capacityManager) getVCPUQuota(ctx context.Context, cfg config.CapacityConfig) *resource.Quantity {
+	refreshInterval := time.Duration(cfg.QuotaRefreshIntervalSeconds) * time.Second
+	if !cm.quotaCheckTime.IsZero() && time.Since(cm.quotaCheckTime) < refreshInterval {
+		return cm.vcpuQuota
+	}
+	cm.quotaCheckTime = time.Now()
+
+	var total float64
+	for _, code := range cfg.VCPUQuotaCodes {
+		out, err := cm.quotaClient.GetServiceQuota(ctx, &servicequotas.GetServiceQuotaInput{
+			ServiceCode: aws.String(ec2ServiceCode),
+			QuotaCode:   aws.String(code),
+		})
+		if err != nil || out.Quota == nil || out.Quota.Value == nil {
+			klog.ErrorS(err, "Unable to get vCPU service quota (retaining previous value)", "quotaCode", code)
+			metrics.ServiceQuotaErrors.Inc()
+			return cm.vcpuQuota
+		}
+		total += *out.Quota.Value
+	}
+
+	quota := resource.NewQuantity(int64(total), resource.DecimalSI)
+	klog.InfoS("Retrieved vCPU service quotas", "quotaCodes", cfg.VCPUQuotaCodes, "vCPUs", quota.String())
+	cm.vcpuQuota = quota
+
+	return cm.vcpuQuota
+}
+
+// lookupInstanceTypes retrieves (and caches) the instance type of pods' instances and the resources of those instance
+//
+//	types (caller must hold the lock)
+func (cm *capacityManager) lookupInstanceTypes(ctx context.Context, pods []*corev1.Pod) {
+	var unknownIDs []string
+	for _, pod := range pods {
+		instanceID := pod.Annotations["compute.amazonaws.com/instance-id"]
+		if awsutils.PodInstanceType(pod) != "" || instanceID == "" {
+			continue
+		}
+		if _, ok := cm.instanceTypeByID[instanceID]; !ok {
+			unknownIDs = append(unknownIDs, instanceID)
+		}
+	}
+
+	if len(unknownIDs) > 0 {
+		input := &ec2.DescribeInstancesInput{InstanceIds: unknownIDs}
+		for {
+			out, err := cm.ec2Client.DescribeInstances(ctx, input)
+			if err != nil {
+				klog.ErrorS(err, "Unable to describe pod instances", "instances", unknownIDs)
+				metrics.DescribeEC2Errors.Inc()
+				break
+			}
+			for _, reservation := range out.Reservations {
+				for _, instance := range reservation.Instances {
+					cm.instanceTypeByID[aws.ToString(instance.InstanceId)] = string(instance.InstanceType)
+				}
+			}
+			if aws.ToString(out.NextToken) == "" {
+				break
+			}
+			input.NextToken = out.NextToken
+		}
+	}
+
+	var unknownTypes []types.InstanceType
+	seen := map[string]bool{}
+	for _, pod := range pods {
+		instanceType := cm.podInstanceType(pod)
+		if instanceType == "" || seen[instanceType] {
+			continue
+		}
+		seen[instanceType] = true
+		if _, ok := cm.instanceTypes[instanceType]; !ok {
+			unknownTypes = append(unknownTypes, types.InstanceType(instanceType))
+		}
+	}
+
+	if len(unknownTypes) == 0 {
+		return
+	}
+
+	out, err := cm.ec2Client.DescribeInstanceTypes(ctx, &ec2.DescribeInstanceTypesInput{
+		InstanceTypes: unknownTypes,
+	})
+	if err != nil {
+		klog.ErrorS(err, "Unable to describe instance types", "instanceTypes", unknownTypes)
+		metrics.DescribeEC2Errors.Inc()
+		return
+	}
+
+	for _, info := range out.InstanceTypes {
+		resources := corev1.ResourceList{}
+		if info.VCpuInfo != nil && info.VCpuInfo.DefaultVCpus != nil {
+			resources[corev1.ResourceCPU] = *resource.NewQuantity(int64(*info.VCpuInfo.DefaultVCpus), resource.DecimalSI)
+		}
+		if info.MemoryInfo != nil && info.MemoryInfo.SizeInMiB != nil {
+			resources[corev1.ResourceMemory] = *resource.NewQuantity(*info.MemoryInfo.SizeInMiB*1024*1024, resource.BinarySI)
+		}
+		cm.instanceTypes[string(info.InstanceType)] = resources
+	}
+}
+
+// podInstanceType returns the instance type a pod runs (or will run) on, if known (caller must hold the lock)
+func (cm *capacityManager) podInstanceType(pod *corev1.Pod) string {
+	if instanceType := awsutils.PodInstanceType(pod); instanceType != "" {
+		return instanceType
+	}
+
+	return cm.instanceTypeByID[pod.Annotations["compute.amazonaws.com/instance-id"]]
+}
+
+// podResources returns the resources a pod consumes: those of its instance type if known, otherwise the sum of its
+//
+//	container requests (caller must hold the lock)
+func (cm *capacityManager) podResources(pod *corev1.Pod) corev1.ResourceList {
+	if resources, ok := cm.instanceTypes[cm.podInstanceType(pod)]; ok {
+		return resources
+	}
+
+	resources := corev1.ResourceList{}
+	for _, container := range pod.Spec.Containers {
+		for name, request := range container.Resources.Requests {
+			total := resources[name]
+			total.Add(request)
+			resources[name] = total
+		}
+	}
+
+	return resources
+}
+
+// configuredCapacity returns the node capacity set in config (not limited by service quotas)
+func configuredCapacity(cfg config.CapacityConfig) corev1.ResourceList {
+	return corev1.ResourceList{
+		corev1.ResourceCPU:     parseQuantity(cfg.CPU, "20"),
+		corev1.ResourceMemory:  parseQuantity(cfg.Memory, "40Gi"),
+		corev1.ResourceStorage: parseQuantity(cfg.Storage, "40Gi"),
+		corev1.ResourcePods:    parseQuantity(cfg.Pods, "200"),
+	}
+}
+
+// parseQuantity parses a (validated) config quantity, using the fallback value if it's unset
+func parseQuantity(value string, fallback string) resource.Quantity {
+	if value == "" {
+		value = fallback
+	}
+
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		klog.ErrorS(err, "Invalid capacity quantity, using fallback", "value", value, "fallback", fallback)
+		return resource.MustParse(fallback)
+	}
+
+	return quantity
+}
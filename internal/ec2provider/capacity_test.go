@@ -0,0 +1,274 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package ec2provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/servicequotas"
+	sqtypes "github.com/aws/aws-sdk-go-v2/service/servicequotas/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/aws-virtual-kubelet/internal/config"
+)
+
+// fakeServiceQuotas is a ServiceQuotasAPI that returns a fixed quota value (or error) for every quota code
+type fakeServiceQuotas struct {
+	value float64
+	err   error
+	calls int
+}
+
+func (f *fakeServiceQuotas) GetServiceQuota(ctx context.Context, params *servicequotas.GetServiceQuotaInput) (
+	*servicequotas.GetServiceQuotaOutput, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &servicequotas.GetServiceQuotaOutput{Quota: &sqtypes.ServiceQuota{Value: aws.Float64(f.value)}}, nil
+}
+
+// testInstanceTypes are the instance types known to the fake EC2 client
+var testInstanceTypes = []types.InstanceTypeInfo{
+	{
+		InstanceType: types.InstanceTypeT3Large,
+		VCpuInfo:     &types.VCpuInfo{DefaultVCpus: aws.Int32(2)},
+		MemoryInfo:   &types.MemoryInfo{SizeInMiB: aws.Int64(8192)},
+	},
+	{
+		InstanceType: types.InstanceTypeMac1Metal,
+		VCpuInfo:     &types.VCpuInfo{DefaultVCpus: aws.Int32(12)},
+		MemoryInfo:   &types.MemoryInfo{SizeInMiB: aws.Int64(32768)},
+	},
+}
+
+// capacityTestPod returns a pod with the given annotations and container requests
+func capacityTestPod(phase corev1.PodPhase, annotations map[string]string, requests corev1.ResourceList) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "default", Annotations: annotations},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{
+			Name:      "app",
+			Resources: corev1.ResourceRequirements{Requests: requests},
+		}}},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+}
+
+func TestCapacityManagerResources(t *testing.T) {
+	instanceTypeAnnotation := func(instanceType string) map[string]string {
+		return map[string]string{"compute.amazonaws.com/instance-type": instanceType}
+	}
+
+	tests := []struct {
+		name           string
+		capacityConfig config.CapacityConfig
+		quota          float64
+		quotaErr       error
+		describeErr    error
+		pods           []*corev1.Pod
+		wantCapacity   corev1.ResourceList
+		wantAllocated  corev1.ResourceList
+	}{
+		{
+			name:          "no pods",
+			quota:         100,
+			wantCapacity:  resourceList("20", "40Gi", "200"),
+			wantAllocated: resourceList("20", "40Gi", "200"),
+		},
+		{
+			name:  "pods reduce allocatable by their instance type's resources",
+			quota: 100,
+			pods: []*corev1.Pod{
+				capacityTestPod(corev1.PodRunning, instanceTypeAnnotation("t3.large"), nil),
+				capacityTestPod(corev1.PodPending, instanceTypeAnnotation("mac1.metal"), nil),
+			},
+			wantCapacity:  resourceList("20", "40Gi", "200"),
+			wantAllocated: resourceList("6", "0", "198"),
+		},
+		{
+			name:  "instance type of an instance launched without an instance type annotation",
+			quota: 100,
+			pods: []*corev1.Pod{
+				capacityTestPod(corev1.PodRunning,
+					map[string]string{"compute.amazonaws.com/instance-id": "i-0123456789abcdef0"}, nil),
+			},
+			wantCapacity:  resourceList("20", "40Gi", "200"),
+			wantAllocated: resourceList("18", "32Gi", "199"),
+		},
+		{
+			name:  "container requests are used when the instance type is unknown",
+			quota: 100,
+			pods: []*corev1.Pod{
+				capacityTestPod(corev1.PodPending, instanceTypeAnnotation("unknown.type"),
+					corev1.ResourceList{"cpu": resource.MustParse("500m"), "memory": resource.MustParse("1Gi")}),
+			},
+			wantCapacity:  resourceList("20", "40Gi", "200"),
+			wantAllocated: resourceList("19500m", "39Gi", "199"),
+		},
+		{
+			name:        "container requests are used when instance types can't be described",
+			quota:       100,
+			describeErr: errors.New("DescribeInstanceTypes failed"),
+			pods: []*corev1.Pod{
+				capacityTestPod(corev1.PodRunning, instanceTypeAnnotation("t3.large"),
+					corev1.ResourceList{"cpu": resource.MustParse("1")}),
+			},
+			wantCapacity:  resourceList("20", "40Gi", "200"),
+			wantAllocated: resourceList("19", "40Gi", "199"),
+		},
+		{
+			name:  "completed pods don't count",
+			quota: 100,
+			pods: []*corev1.Pod{
+				capacityTestPod(corev1.PodSucceeded, instanceTypeAnnotation("t3.large"), nil),
+				capacityTestPod(corev1.PodFailed, instanceTypeAnnotation("t3.large"), nil),
+			},
+			wantCapacity:  resourceList("20", "40Gi", "200"),
+			wantAllocated: resourceList("20", "40Gi", "200"),
+		},
+		{
+			name:  "over-committed node has no allocatable resources",
+			quota: 100,
+			pods: []*corev1.Pod{
+				capacityTestPod(corev1.PodRunning, instanceTypeAnnotation("mac1.metal"), nil),
+				capacityTestPod(corev1.PodRunning, instanceTypeAnnotation("mac1.metal"), nil),
+			},
+			wantCapacity:  resourceList("20", "40Gi", "200"),
+			wantAllocated: resourceList("0", "0", "198"),
+		},
+		{
+			name:          "vCPU quota limits CPU capacity",
+			quota:         8,
+			pods:          []*corev1.Pod{capacityTestPod(corev1.PodRunning, instanceTypeAnnotation("t3.large"), nil)},
+			wantCapacity:  resourceList("8", "40Gi", "200"),
+			wantAllocated: resourceList("6", "32Gi", "199"),
+		},
+		{
+			name:          "configured capacity is used if quotas can't be retrieved",
+			quotaErr:      errors.New("GetServiceQuota failed"),
+			wantCapacity:  resourceList("20", "40Gi", "200"),
+			wantAllocated: resourceList("20", "40Gi", "200"),
+		},
+		{
+			name:           "quotas are ignored",
+			capacityConfig: config.CapacityConfig{CPU: "4", Memory: "16Gi", Pods: "2", IgnoreServiceQuotas: true},
+			quota:          1,
+			pods:           []*corev1.Pod{capacityTestPod(corev1.PodRunning, instanceTypeAnnotation("t3.large"), nil)},
+			wantCapacity:   resourceList("4", "16Gi", "2"),
+			wantAllocated:  resourceList("2", "8Gi", "1"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTestConfig(t, config.ProviderConfig{CapacityConfig: tt.capacityConfig})
+
+			ec2Client := &fakeEC2{
+				instances: []types.Instance{{
+					InstanceId:   aws.String("i-0123456789abcdef0"),
+					InstanceType: types.InstanceTypeT3Large,
+				}},
+				instanceTypes: testInstanceTypes,
+				describeErr:   tt.describeErr,
+			}
+			cm := newCapacityManager(ec2Client, &fakeServiceQuotas{value: tt.quota, err: tt.quotaErr})
+
+			capacity, allocatable := cm.Resources(context.Background(), tt.pods)
+
+			assertResources(t, "capacity", capacity, tt.wantCapacity)
+			assertResources(t, "allocatable", allocatable, tt.wantAllocated)
+		})
+	}
+}
+
+func TestCapacityManagerCachesQuotas(t *testing.T) {
+	initTestConfig(t, config.ProviderConfig{})
+
+	quotaClient := &fakeServiceQuotas{value: 8}
+	cm := newCapacityManager(&fakeEC2{}, quotaClient)
+
+	for i := 0; i < 3; i++ {
+		capacity, _ := cm.Resources(context.Background(), nil)
+		assertResources(t, "capacity", capacity, corev1.ResourceList{"cpu": resource.MustParse("8")})
+	}
+
+	// quotas are only retrieved again after the refresh interval
+	if quotaClient.calls != 1 {
+		t.Errorf("expected quotas to be retrieved once, got %d calls", quotaClient.calls)
+	}
+
+	// a failed refresh retains the previous quota
+	refreshInterval := time.Duration(config.Config().CapacityConfig.QuotaRefreshIntervalSeconds) * time.Second
+	cm.quotaCheckTime = cm.quotaCheckTime.Add(-2 * refreshInterval)
+	quotaClient.err = errors.New("GetServiceQuota failed")
+	capacity, _ := cm.Resources(context.Background(), nil)
+	assertResources(t, "capacity", capacity, corev1.ResourceList{"cpu": resource.MustParse("8")})
+	if quotaClient.calls != 2 {
+		t.Errorf("expected quotas to be refreshed after the refresh interval, got %d calls", quotaClient.calls)
+	}
+}
+
+// resourceList returns a node's cpu, memory, and pods resources
+func resourceList(cpu string, memory string, pods string) corev1.ResourceList {
+	return corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse(cpu),
+		corev1.ResourceMemory: resource.MustParse(memory),
+		corev1.ResourcePods:   resource.MustParse(pods),
+	}
+}
+
+// assertResources checks the quantities of the wanted resources (other resources aren't checked)
+func assertResources(t *testing.T, name string, got corev1.ResourceList, want corev1.ResourceList) {
+	t.Helper()
+
+	for resourceName, wantQuantity := range want {
+		gotQuantity, ok := got[resourceName]
+		if !ok || gotQuantity.Cmp(wantQuantity) != 0 {
+			t.Errorf("expected %v %v %v, got %v", name, resourceName, wantQuantity.String(), gotQuantity.String())
+		}
+	}
+}
+
+func TestNodeResourcesRefresh(t *testing.T) {
+	initTestConfig(t, config.ProviderConfig{CapacityConfig: config.CapacityConfig{IgnoreServiceQuotas: true}})
+
+	p := newTestProvider(&fakeEC2{instanceTypes: testInstanceTypes})
+	p.capacityManager = newCapacityManager(p.computeManager.ec2Client, &fakeServiceQuotas{})
+	p.EniNode.resources = p.nodeResources
+
+	if _, err := p.EniNode.Configure(context.Background(), &corev1.Node{}); err != nil {
+		t.Fatalf("Configure: unexpected error %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	nodes := make(chan *corev1.Node, 1)
+	p.NotifyNodeStatus(ctx, func(node *corev1.Node) { nodes <- node })
+
+	// placing a pod refreshes node resources (without waiting for the next status check)
+	metaPod := cacheTestPod(t, p, corev1.PodPending, "")
+	metaPod.updatePod(func(pod *corev1.Pod) {
+		pod.Annotations["compute.amazonaws.com/instance-type"] = "t3.large"
+	})
+	p.EniNode.RequestStatusRefresh()
+
+	select {
+	case node := <-nodes:
+		assertResources(t, "allocatable", node.Status.Allocatable, resourceList("18", "32Gi", "199"))
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a node status notification")
+	}
+}
@@ -0,0 +1,87 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package ec2provider
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/aws/aws-virtual-kubelet/internal/config"
+	"github.com/aws/aws-virtual-kubelet/internal/metrics"
+	"github.com/aws/aws-virtual-kubelet/internal/utils"
+)
+
+// podCompleted returns true if a pod has reached a terminal phase (all its containers terminated and none will be
+//
+//	restarted)
+func podCompleted(pod *corev1.Pod) bool {
+	return pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+}
+
+// completePod stops monitoring a completed pod, then terminates, releases, or retains its instance according to the
+//
+//	completion config.  The pod stays cached (and keeps its final status) until k8s deletes it.
+func (p *Ec2Provider) completePod(metaPod *MetaPod) {
+	cfg := config.Config()
+
+	pod := metaPod.snapshot()
+	instanceID := pod.Annotations["compute.amazonaws.com/instance-id"]
+
+	klog.InfoS("Pod completed", "pod", klog.KObj(pod), "phase", pod.Status.Phase, "instance", instanceID,
+		"instancePolicy", cfg.CompletionConfig.InstancePolicy)
+	metrics.PodsCompleted.Inc()
+	p.recordEvent(pod, corev1.EventTypeNormal, eventReasonCompleted, "All containers terminated (pod %v)",
+		pod.Status.Phase)
+
+	// this is called from the pod's own monitor handler, which Stop waits for (so this must run asynchronously)
+	if err := p.stopPodMonitor(metaPod.ctx, metaPod); err != nil {
+		klog.ErrorS(err, "Could not stop pod monitoring", "pod", klog.KObj(pod))
+	}
+
+	if instanceID == "" {
+		return
+	}
+
+	// the pod's context is cancelled if it's deleted meanwhile (DeletePod then terminates the instance)
+	ctx := metaPod.ctx
+
+	var err error
+	switch cfg.CompletionConfig.InstancePolicy {
+	case config.CompletionPolicyRetain:
+		klog.InfoS("Retaining completed pod's instance until the pod is deleted", "pod", klog.KObj(pod),
+			"instance", instanceID)
+		return
+	case config.CompletionPolicyRelease:
+		// only warm pool instances can be released (and only if they can be reset)
+		if !utils.PodIsWarmPool(ctx, pod, len(p.warmPool.config)) ||
+			!p.warmPool.recycleInstance(ctx, instanceID, pod.Status.PodIP, true) {
+			err = p.computeManager.DeleteCompute(ctx, p, pod)
+		}
+	default:
+		err = p.computeManager.DeleteCompute(ctx, p, pod)
+	}
+
+	if err != nil {
+		// the instance is still associated with the pod, so it's terminated when the pod is deleted
+		klog.ErrorS(err, "Could not terminate or release completed pod's instance", "pod", klog.KObj(pod),
+			"instance", instanceID)
+		metrics.CompletedInstanceErrors.Inc()
+		return
+	}
+
+	p.recordEvent(pod, corev1.EventTypeNormal, eventReasonInstanceTerminated, "Released instance %v (%v policy)",
+		instanceID, cfg.CompletionConfig.InstancePolicy)
+
+	// the instance is gone, so DeletePod mustn't terminate (or recycle) it again
+	metaPod.updatePod(func(pod *corev1.Pod) {
+		delete(pod.Annotations, "compute.amazonaws.com/instance-id")
+	})
+	p.EniNode.RequestStatusRefresh()
+}
@@ -0,0 +1,324 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package ec2provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/golang/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/aws-virtual-kubelet/internal/awsutils"
+	"github.com/aws/aws-virtual-kubelet/internal/config"
+	"github.com/aws/aws-virtual-kubelet/internal/health"
+	"github.com/aws/aws-virtual-kubelet/internal/utils"
+	mock_vkvmaclient "github.com/aws/aws-virtual-kubelet/mocks/vkvmaclient"
+)
+
+// fakeEC2 is an EC2API that describes a fixed set of instances and instance types, and records launched and
+//
+//	terminated instances (other EC2API methods aren't implemented)
+type fakeEC2 struct {
+	awsutils.EC2API
+	mu            sync.Mutex
+	instances     []types.Instance
+	instanceTypes []types.InstanceTypeInfo
+	describeErr   error
+	terminateErr  error
+	terminated    []string
+	launches      []*ec2.RunInstancesInput
+}
+
+func (f *fakeEC2) RunInstances(ctx context.Context, input *ec2.RunInstancesInput) (*ec2.RunInstancesOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.launches = append(f.launches, input)
+	instanceID := fmt.Sprintf("i-%017d", len(f.launches))
+	return &ec2.RunInstancesOutput{Instances: []types.Instance{{InstanceId: aws.String(instanceID)}}}, nil
+}
+
+func (f *fakeEC2) DescribeInstances(ctx context.Context, input *ec2.DescribeInstancesInput) (
+	*ec2.DescribeInstancesOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.describeErr != nil {
+		return nil, f.describeErr
+	}
+
+	reservation := types.Reservation{}
+	for _, instance := range f.instances {
+		for _, instanceID := range input.InstanceIds {
+			if aws.ToString(instance.InstanceId) == instanceID {
+				reservation.Instances = append(reservation.Instances, instance)
+			}
+		}
+	}
+	return &ec2.DescribeInstancesOutput{Reservations: []types.Reservation{reservation}}, nil
+}
+
+func (f *fakeEC2) DescribeInstanceTypes(ctx context.Context, input *ec2.DescribeInstanceTypesInput) (
+	*ec2.DescribeInstanceTypesOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.describeErr != nil {
+		return nil, f.describeErr
+	}
+
+	output := &ec2.DescribeInstanceTypesOutput{}
+	for _, info := range f.instanceTypes {
+		for _, instanceType := range input.InstanceTypes {
+			if info.InstanceType == instanceType {
+				output.InstanceTypes = append(output.InstanceTypes, info)
+			}
+		}
+	}
+	return output, nil
+}
+
+func (f *fakeEC2) TerminateInstances(ctx context.Context, input *ec2.TerminateInstancesInput) (
+	*ec2.TerminateInstancesOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.terminateErr != nil {
+		return nil, f.terminateErr
+	}
+
+	output := &ec2.TerminateInstancesOutput{}
+	for _, instanceID := range input.InstanceIds {
+		f.terminated = append(f.terminated, instanceID)
+		output.TerminatingInstances = append(output.TerminatingInstances,
+			types.InstanceStateChange{InstanceId: aws.String(instanceID)})
+	}
+	return output, nil
+}
+
+// terminatedInstances returns the instances terminated so far
+func (f *fakeEC2) terminatedInstances() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]string(nil), f.terminated...)
+}
+
+// initTestConfig initializes the global config (with defaults applied) for a test
+func initTestConfig(t *testing.T, pc config.ProviderConfig) {
+	t.Helper()
+
+	if pc.ManagementSubnet == "" {
+		pc.ManagementSubnet = "subnet-management"
+	}
+	if err := config.InitConfig(&config.DirectLoader{DirectConfig: pc}); err != nil {
+		t.Fatalf("unable to initialize config: %v", err)
+	}
+}
+
+// newTestProvider returns a provider (without AWS or k8s clients) that launches and terminates instances with
+//
+//	ec2Client
+func newTestProvider(ec2Client awsutils.EC2API) *Ec2Provider {
+	p := &Ec2Provider{
+		EniNode:        &EniNode{refresh: make(chan struct{}, 1)},
+		pods:           NewPodCache(),
+		podNotifier:    func(*corev1.Pod) {},
+		computeManager: &computeManager{ec2Client: ec2Client},
+	}
+	p.warmPool = &WarmPoolManager{provider: p, ec2Client: ec2Client, config: config.Config().WarmPoolConfig}
+	p.ctx, p.cancel = context.WithCancel(context.Background())
+
+	return p
+}
+
+// cacheTestPod adds a running pod with an instance to a provider's cache
+func cacheTestPod(t *testing.T, p *Ec2Provider, phase corev1.PodPhase, instanceID string) *MetaPod {
+	t.Helper()
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "pod",
+			Namespace:   "default",
+			UID:         "uid",
+			Annotations: map[string]string{"compute.amazonaws.com/instance-id": instanceID},
+		},
+		Spec:   corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+		Status: corev1.PodStatus{Phase: phase, PodIP: "10.0.0.1"},
+	}
+
+	monitor, err := health.NewPodMonitor(pod, p.newCheckHandler())
+	if err != nil {
+		t.Fatalf("unable to create pod monitor: %v", err)
+	}
+
+	metaPod := NewMetaPod(pod, monitor, p.podNotifier)
+	metaPod.startContext(p.ctx)
+	p.pods.Set(utils.GetPodCacheKey(pod.Namespace, pod.Name), metaPod)
+
+	return metaPod
+}
+
+func TestCompletePodThenDeletePod(t *testing.T) {
+	initTestConfig(t, config.ProviderConfig{})
+
+	client := &fakeEC2{}
+	p := newTestProvider(client)
+	metaPod := cacheTestPod(t, p, corev1.PodSucceeded, "i-0123456789abcdef0")
+
+	p.completePod(metaPod)
+
+	if got := client.terminatedInstances(); len(got) != 1 || got[0] != "i-0123456789abcdef0" {
+		t.Fatalf("expected the completed pod's instance to be terminated, got %v", got)
+	}
+	if instanceID := metaPod.snapshot().Annotations["compute.amazonaws.com/instance-id"]; instanceID != "" {
+		t.Errorf("expected the cached pod's instance-id annotation to be removed, got %v", instanceID)
+	}
+
+	if err := p.DeletePod(context.Background(), metaPod.snapshot()); err != nil {
+		t.Fatalf("DeletePod: unexpected error %v", err)
+	}
+
+	// the instance was already terminated on completion, so deletion doesn't terminate it again
+	if got := client.terminatedInstances(); len(got) != 1 {
+		t.Errorf("expected the instance to be terminated once, got %v", got)
+	}
+	if p.pods.Get(utils.GetPodCacheKey("default", "pod")) != nil {
+		t.Error("expected the deleted pod to be removed from the cache")
+	}
+}
+
+func TestCompletePodInstancePolicy(t *testing.T) {
+	tests := []struct {
+		name           string
+		instancePolicy string
+		instanceID     string
+		// warmPool configures a warm pool (which the pod's instance belongs to)
+		warmPool       bool
+		resetErr       error
+		terminateErr   error
+		wantResets     int
+		wantTerminated []string
+		// wantInstance is whether the cached pod keeps its instance-id annotation (so DeletePod terminates it)
+		wantInstance bool
+	}{
+		{
+			name:           "terminates the instance by default",
+			instanceID:     "i-0123456789abcdef0",
+			wantTerminated: []string{"i-0123456789abcdef0"},
+		},
+		{
+			name:           "terminates the instance",
+			instancePolicy: config.CompletionPolicyTerminate,
+			instanceID:     "i-0123456789abcdef0",
+			warmPool:       true,
+			wantTerminated: []string{"i-0123456789abcdef0"},
+		},
+		{
+			name:           "keeps the instance if it can't be terminated",
+			instancePolicy: config.CompletionPolicyTerminate,
+			instanceID:     "i-0123456789abcdef0",
+			terminateErr:   errors.New("TerminateInstances failed"),
+			wantInstance:   true,
+		},
+		{
+			name:           "releases a warm pool instance to the pool",
+			instancePolicy: config.CompletionPolicyRelease,
+			instanceID:     "i-0123456789abcdef0",
+			warmPool:       true,
+			wantResets:     1,
+		},
+		{
+			name:           "terminates a warm pool instance that can't be reset",
+			instancePolicy: config.CompletionPolicyRelease,
+			instanceID:     "i-0123456789abcdef0",
+			warmPool:       true,
+			resetErr:       errors.New("ResetInstance failed"),
+			wantResets:     1,
+			wantTerminated: []string{"i-0123456789abcdef0"},
+		},
+		{
+			name:           "terminates an instance that isn't from a warm pool instead of releasing it",
+			instancePolicy: config.CompletionPolicyRelease,
+			instanceID:     "i-0123456789abcdef0",
+			wantTerminated: []string{"i-0123456789abcdef0"},
+		},
+		{
+			name:           "retains the instance",
+			instancePolicy: config.CompletionPolicyRetain,
+			instanceID:     "i-0123456789abcdef0",
+			warmPool:       true,
+			wantInstance:   true,
+		},
+		{
+			name:           "pod without an instance",
+			instancePolicy: config.CompletionPolicyTerminate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTestConfig(t, config.ProviderConfig{
+				CompletionConfig: config.CompletionConfig{InstancePolicy: tt.instancePolicy},
+			})
+			resetWarmPoolState()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			client := mock_vkvmaclient.NewMockGrpcClient(ctrl)
+			client.EXPECT().ResetInstance(gomock.Any()).Return(tt.resetErr).Times(tt.wantResets)
+
+			var configs []config.WarmPoolConfig
+			if tt.warmPool {
+				// NOTE completed pods' instances are released even if the pool doesn't recycle deleted pods' instances
+				configs = []config.WarmPoolConfig{{}}
+			}
+			ec2Client := &fakeUpdateEC2{
+				fakeEC2: &fakeEC2{
+					instances:    []types.Instance{warmPoolInstance("i-0123456789abcdef0", "0", "")},
+					terminateErr: tt.terminateErr,
+				},
+				createdTags: map[string]string{},
+			}
+			p := newTestWarmPool(ec2Client, configs, client)
+			metaPod := cacheTestPod(t, p, corev1.PodSucceeded, tt.instanceID)
+
+			p.completePod(metaPod)
+
+			if got := ec2Client.terminatedInstances(); !reflect.DeepEqual(got, tt.wantTerminated) {
+				t.Errorf("expected instances %v to be terminated, got %v", tt.wantTerminated, got)
+			}
+
+			instanceID := metaPod.snapshot().Annotations["compute.amazonaws.com/instance-id"]
+			if tt.wantInstance && instanceID != tt.instanceID {
+				t.Errorf("expected the cached pod to keep instance %v, got %q", tt.instanceID, instanceID)
+			} else if !tt.wantInstance && instanceID != "" {
+				t.Errorf("expected the cached pod's instance-id annotation to be removed, got %v", instanceID)
+			}
+
+			// a released instance is ready for the next pod
+			VKState.Lock()
+			_, ready := VKState.ReadyEC2["i-0123456789abcdef0"]
+			VKState.Unlock()
+			if wantReady := tt.wantResets > 0 && tt.resetErr == nil; ready != wantReady {
+				t.Errorf("expected the instance to be ready in the warm pool = %v, got %v", wantReady, ready)
+			}
+		})
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"github.com/aws/aws-virtual-kubelet/internal/awsutils"
+	"github.com/aws/aws-virtual-kubelet/internal/tracing"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -34,24 +35,32 @@ type ComputeManager interface {
 }
 
 type computeManager struct {
-	ec2Client *awsutils.Client
+	ec2Client awsutils.EC2API
+	s3Client  awsutils.S3API
 }
 
-func NewComputeManager(ctx context.Context) (*computeManager, error) {
-	ec2, err := awsutils.NewEc2Client()
-	if err != nil {
-		return nil, err
-	}
-
+func NewComputeManager(ec2Client awsutils.EC2API, s3Client awsutils.S3API) *computeManager {
 	return &computeManager{
-		ec2Client: ec2,
-	}, nil
+		ec2Client: ec2Client,
+		s3Client:  s3Client,
+	}
 }
 
 // GetCompute obtains compute for the given pod.  This compute may come from a Warm Pool, newly created EC2
 //
-//	instance, or other appropriate source
-func (c *computeManager) GetCompute(ctx context.Context, p *Ec2Provider, pod *corev1.Pod) (string, string, error) {
+//	instance, or other appropriate source.  The pod's instance-id annotation and IP are set (in the cache) once it has
+//	an instance.
+func (c *computeManager) GetCompute(ctx context.Context, p *Ec2Provider, metaPod *MetaPod) (
+	instanceID string, privateIP string, err error) {
+	pod := metaPod.snapshot()
+	ctx, span := tracing.Start(ctx, "GetCompute", tracing.PodAttributes(pod)...)
+	defer func() {
+		if instanceID != "" {
+			span.SetAttributes(tracing.InstanceAttribute(instanceID))
+		}
+		tracing.End(span, err)
+	}()
+
 	// if we already have an instance associated with this pod, just find and return that
 	if c.podHasInstance(ctx, pod) {
 		podInstanceID := pod.Annotations["compute.amazonaws.com/instance-id"]
@@ -68,8 +77,12 @@ func (c *computeManager) GetCompute(ctx context.Context, p *Ec2Provider, pod *co
 		instanceID, privateIP, instanceFound := p.warmPool.GetWarmPoolInstanceIfExist(ctx)
 
 		if instanceFound {
-			pod.Annotations["compute.amazonaws.com/instance-id"] = instanceID
-			pod.Status.PodIP = privateIP
+			p.recordEvent(pod, corev1.EventTypeNormal, eventReasonWarmPoolHit, "Assigned warm pool instance %v",
+				instanceID)
+			metaPod.updatePod(func(pod *corev1.Pod) {
+				pod.Annotations["compute.amazonaws.com/instance-id"] = instanceID
+				pod.Status.PodIP = privateIP
+			})
 			// NOTE pod notification will happen in upstream caller
 
 			// update EC2 tags to mark that the provisioning is in process
@@ -83,12 +96,13 @@ func (c *computeManager) GetCompute(ctx context.Context, p *Ec2Provider, pod *co
 			return instanceID, privateIP, nil
 		} else {
 			err := errors.New("no instance in 'Ready' state")
+			p.recordEvent(pod, corev1.EventTypeWarning, eventReasonWarmPoolMiss, "No warm pool instance is ready")
 			klog.Errorf("Pod %v(%v) is configured to use Warm Pool, but no instance was available: %v",
 				pod.Name, pod.Namespace, err)
 			return "", "", err
 		}
 	} else {
-		return c.createCompute(ctx, pod)
+		return c.createCompute(ctx, p, metaPod)
 	}
 }
 
@@ -97,13 +111,7 @@ func (c *computeManager) podHasInstance(ctx context.Context, pod *corev1.Pod) bo
 
 	// if we already created an instance for this pod (in which case the instance-id annotation will be set)
 	if podInstanceID != "" {
-		ec2Client, err := awsutils.NewEc2Client()
-		if err != nil {
-			// if we get an error trying to create an Ec2 client, assume we don't have a valid instance
-			return false
-		}
-
-		status, err := ec2Client.DescribeInstanceStatus(ctx, &ec2.DescribeInstanceStatusInput{
+		status, err := c.ec2Client.DescribeInstanceStatus(ctx, &ec2.DescribeInstanceStatusInput{
 			// uncomment to include non-Running instances (currently we only look for running instances)
 			//IncludeAllInstances: aws.Bool(true),
 			InstanceIds: []string{podInstanceID},
@@ -126,52 +134,164 @@ func (c *computeManager) podHasInstance(ctx context.Context, pod *corev1.Pod) bo
 }
 
 // DeleteCompute removes compute for the given pod. NOTE instances are terminated, even if they came from a warm pool
+//
+//	(see RecycleCompute)
 func (c *computeManager) DeleteCompute(ctx context.Context, p *Ec2Provider, pod *corev1.Pod) error {
 	return c.deleteCompute(ctx, pod)
 }
 
-// func (c *computeManager) createCompute(ctx context.Context, p *Ec2Provider, pod *corev1.Pod) (*interface{}, error) {
-func (c *computeManager) createCompute(ctx context.Context, pod *corev1.Pod) (string, string, error) {
+// RecycleCompute returns the given pod's instance to the warm pool if its pool recycles instances (and the instance
+//
+//	was reset successfully), and terminates it otherwise.  Returns true if the instance was recycled.
+func (c *computeManager) RecycleCompute(ctx context.Context, p *Ec2Provider, pod *corev1.Pod) (bool, error) {
+	if utils.PodIsWarmPool(ctx, pod, len(p.warmPool.config)) && p.warmPool.recycleInstance(ctx,
+		pod.Annotations["compute.amazonaws.com/instance-id"], pod.Status.PodIP, false) {
+		return true, nil
+	}
+
+	return false, c.deleteCompute(ctx, pod)
+}
+
+func (c *computeManager) createCompute(ctx context.Context, p *Ec2Provider, metaPod *MetaPod) (string, string,
+	error) {
 	cfg := config.Config()
+	pod := metaPod.snapshot()
+
+	// resolve the instance's settings from the pod's compute profile and annotations
+	spec, err := awsutils.ResolveComputeSpec(pod)
+	if err != nil {
+		p.recordEvent(pod, corev1.EventTypeWarning, eventReasonInstanceLaunchFailed,
+			"Unable to resolve compute spec: %v", err)
+		return "", "", err
+	}
+	p.recordComputeSpec(ctx, metaPod, spec)
+
+	// wait for a launch slot (held until the instance is running), so scaling up many pods at once doesn't cause EC2
+	//	API throttling
+	queued := false
+	queueCtx, queueSpan := tracing.Start(ctx, "LaunchQueue.Admit")
+	release, err := p.launchQueue.admit(queueCtx, func(position int) {
+		queued = true
+		metaPod.updatePod(func(pod *corev1.Pod) {
+			setPodCondition(pod, PodConditionEC2Launched, corev1.ConditionFalse, provisioningReasonQueued,
+				fmt.Sprintf("Waiting to launch instance (position %d in the launch queue)", position))
+		})
+		p.notifyPod(metaPod)
+	})
+	tracing.End(queueSpan, err)
+	if err != nil {
+		return "", "", err
+	}
+	defer release()
+
+	if queued {
+		metaPod.updatePod(func(pod *corev1.Pod) {
+			setPodCondition(pod, PodConditionEC2Launched, corev1.ConditionFalse, provisioningReasonInProgress,
+				"Launching instance")
+		})
+		p.notifyPod(metaPod)
+	}
 
 	klog.Info("Generating a fresh EC2 Instance")
 	finalUserData, err := awsutils.GenerateVKVMUserData(
 		ctx,
+		c.s3Client,
 		cfg.BootstrapAgent.S3Bucket,
 		cfg.BootstrapAgent.S3Key,
 		cfg.VMConfig.InitData,
 		cfg.BootstrapAgent.InitData,
 	)
+	if err != nil {
+		p.recordEvent(pod, corev1.EventTypeWarning, eventReasonInstanceLaunchFailed,
+			"Unable to generate instance user data: %v", err)
+		return "", "", err
+	}
+
+	// instance types that require a dedicated host are launched on one from the host pool (the instance type and subnet
+	//	may come from the launch template)
+	instanceType, subnetID, err := awsutils.ResolveTemplatePlacement(ctx, c.ec2Client, spec.LaunchTemplate,
+		spec.InstanceType, spec.SubnetID)
+	if err != nil {
+		p.recordEvent(pod, corev1.EventTypeWarning, eventReasonInstanceLaunchFailed,
+			"Unable to resolve launch template: %v", err)
+		return "", "", err
+	}
+	placement, launched, err := p.hostPool.placement(ctx, instanceType, subnetID)
+	if err != nil {
+		p.recordEvent(pod, corev1.EventTypeWarning, eventReasonInstanceLaunchFailed,
+			"Unable to get a dedicated host: %v", err)
+		return "", "", err
+	}
 
 	instanceID, err := awsutils.CreateEC2(
 		ctx,
+		c.ec2Client,
 		pod,
+		spec,
 		finalUserData,
-		cfg.BootstrapAgent.S3Bucket,
-		cfg.BootstrapAgent.S3Key,
+		placement...,
 	)
+	launched(instanceID)
 
 	// Await EC2 Launch
 	// NOTE This doesn't wait for EC2 launch, GetPrivateIP below is where the timeout is implemented
 	if err != nil {
-		return "", "", fmt.Errorf("failed to create ec2 instance, error : %v ", err.Error())
+		p.recordEvent(pod, corev1.EventTypeWarning, eventReasonInstanceLaunchFailed, "RunInstances failed: %v", err)
+		return "", "", fmt.Errorf("failed to create ec2 instance, error : %w", err)
 	}
 
-	privateIP, err := awsutils.GetPrivateIP(instanceID)
+	// the instance-id annotation is what cleanup relies on, so it's set as soon as the instance exists
+	metaPod.updatePod(func(pod *corev1.Pod) {
+		pod.Annotations["compute.amazonaws.com/instance-id"] = instanceID
+	})
+	p.recordEvent(pod, corev1.EventTypeNormal, eventReasonInstanceLaunched, "Launched instance %v", instanceID)
+
+	privateIP, err := awsutils.GetPrivateIP(ctx, c.ec2Client, instanceID)
 	if err != nil {
+		p.recordEvent(pod, corev1.EventTypeWarning, eventReasonInstanceLaunchFailed,
+			"Instance %v did not start: %v", instanceID, err)
 		return "", "", err
 	}
 
-	pod.Status.PodIP = privateIP
+	metaPod.updatePod(func(pod *corev1.Pod) {
+		pod.Status.PodIP = privateIP
+	})
 
 	return instanceID, privateIP, nil
 }
 
+// recordComputeSpec records a pod's resolved compute spec in its resolved spec annotation (in the cache and k8s)
+func (p *Ec2Provider) recordComputeSpec(ctx context.Context, metaPod *MetaPod, spec *awsutils.ComputeSpec) {
+	resolved := spec.String()
+
+	var pod *corev1.Pod
+	metaPod.updatePod(func(cached *corev1.Pod) {
+		if cached.Annotations == nil {
+			cached.Annotations = map[string]string{}
+		}
+		cached.Annotations[awsutils.ResolvedSpecAnnotation] = resolved
+		pod = cached.DeepCopy()
+	})
+
+	klog.InfoS("Resolved compute spec", "pod", klog.KObj(pod), "spec", resolved)
+
+	// VK only syncs pod status to k8s, so annotations have to be patched directly
+	if p.k8sClient == nil {
+		return
+	}
+
+	err := p.k8sClient.PatchPodAnnotations(ctx, pod.Namespace, pod.Name,
+		map[string]string{awsutils.ResolvedSpecAnnotation: resolved})
+	if err != nil {
+		klog.ErrorS(err, "Unable to update resolved spec annotation", "pod", klog.KObj(pod))
+	}
+}
+
 func (c *computeManager) deleteCompute(ctx context.Context, pod *corev1.Pod) error {
 
 	podInstanceID := pod.Annotations["compute.amazonaws.com/instance-id"]
 
-	instanceId, err := awsutils.TerminateEC2(ctx, podInstanceID)
+	instanceId, err := awsutils.TerminateEC2(ctx, c.ec2Client, podInstanceID)
 	if err != nil {
 		klog.Errorf("error terminating EC2 instance %v: %v", instanceId, err)
 	}
@@ -0,0 +1,76 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package ec2provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws/aws-virtual-kubelet/internal/config"
+)
+
+// fakeS3 is a S3API that returns a fixed presigned URL (or error)
+type fakeS3 struct {
+	err error
+}
+
+func (f *fakeS3) PresignGetObject(ctx context.Context, params *s3.GetObjectInput) (*v4.PresignedHTTPRequest, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &v4.PresignedHTTPRequest{URL: "https://bootstrap-bucket.s3.amazonaws.com/bootstrap-agent"}, nil
+}
+
+func TestCreateComputeUserDataError(t *testing.T) {
+	initTestConfig(t, config.ProviderConfig{})
+
+	ec2Client := &fakeEC2{}
+	p := newTestProvider(ec2Client)
+	p.computeManager.s3Client = &fakeS3{err: errors.New("presign failed")}
+	p.launchQueue = newLaunchQueue(0)
+	p.hostPool = newHostPool(ec2Client, "node")
+
+	metaPod := cacheTestPod(t, p, corev1.PodPending, "")
+	metaPod.updatePod(func(pod *corev1.Pod) {
+		pod.Annotations["compute.amazonaws.com/image-id"] = "ami-pod"
+		pod.Annotations["compute.amazonaws.com/instance-type"] = "t3.micro"
+		pod.Annotations["compute.amazonaws.com/subnet-id"] = "subnet-pod"
+	})
+
+	if _, _, err := p.computeManager.createCompute(context.Background(), p, metaPod); err == nil {
+		t.Fatal("expected createCompute to fail without user data")
+	}
+	if len(ec2Client.launches) != 0 {
+		t.Errorf("expected no instance to be launched without user data, got %d launches", len(ec2Client.launches))
+	}
+}
+
+func TestCreateWarmEC2UserDataError(t *testing.T) {
+	initTestConfig(t, config.ProviderConfig{})
+
+	ec2Client := &fakeEC2{}
+	p := newTestProvider(ec2Client)
+	p.warmPool.s3Client = &fakeS3{err: errors.New("presign failed")}
+	p.launchQueue = newLaunchQueue(0)
+	p.hostPool = newHostPool(ec2Client, "node")
+
+	wpConfig := config.WarmPoolConfig{ImageID: "ami-pool", InstanceType: "t3.micro", Subnets: []string{"subnet-pool"}}
+	if _, _, _, _, err := p.warmPool.CreateWarmEC2(context.Background(), wpConfig, nil); err == nil {
+		t.Fatal("expected CreateWarmEC2 to fail without user data")
+	}
+	if len(ec2Client.launches) != 0 {
+		t.Errorf("expected no instance to be launched without user data, got %d launches", len(ec2Client.launches))
+	}
+}
@@ -17,19 +17,20 @@ import (
 	"os"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-virtual-kubelet/internal/vkvmaclient"
 
+	"github.com/aws/aws-virtual-kubelet/internal/awsutils"
 	"github.com/aws/aws-virtual-kubelet/internal/health"
-
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"github.com/aws/aws-virtual-kubelet/internal/k8sutils"
 
 	"github.com/aws/aws-virtual-kubelet/internal/config"
 
-	vkvmagent_v0 "github.com/aws/aws-virtual-kubelet/proto/vkvmagent/v0"
-
 	"github.com/aws/aws-virtual-kubelet/internal/metrics"
+	"github.com/aws/aws-virtual-kubelet/internal/poderrors"
+	"github.com/aws/aws-virtual-kubelet/internal/tracing"
 
 	"github.com/aws/aws-virtual-kubelet/internal/utils"
 
@@ -42,9 +43,10 @@ import (
 	"github.com/virtual-kubelet/virtual-kubelet/errdefs"
 
 	"github.com/virtual-kubelet/virtual-kubelet/node/api"
-	"github.com/virtual-kubelet/virtual-kubelet/node/api/statsv1alpha1"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	utilexec "k8s.io/utils/exec"
 )
 
 // Ec2Provider implements PodLifecycleHandler which defines the interface used by the PodController to react to new and
@@ -67,10 +69,24 @@ type Ec2Provider struct {
 	pods               *PodCache
 	startTime          time.Time
 	podNotifier        func(*corev1.Pod)
+	awsClients         *awsutils.Clients
 	computeManager     *computeManager
 	podMonitor         *health.PodMonitor
-	defaultHandler     *health.CheckHandler
 	warmPool           *WarmPoolManager
+	capacityManager    *capacityManager
+	launchQueue        *launchQueue
+	hostPool           *hostPool
+	k8sClient          k8sutils.K8SAPI
+	eventRecorder      record.EventRecorder
+	// newPodClient creates a VKVMAgent client for a pod
+	newPodClient func(pod *corev1.Pod) vkvmaclient.GrpcClient
+	// ctx is the provider's root context (pod contexts are derived from it), cancelled by Shutdown
+	ctx    context.Context
+	cancel context.CancelFunc
+	// waitGroup tracks the provider's goroutines (including pods' goroutines)
+	waitGroup sync.WaitGroup
+	// shutdownTracing flushes and stops trace exporting
+	shutdownTracing func(context.Context) error
 }
 
 func NewEc2Provider(ctx context.Context, cfg provider.InitConfig, extCfg config.ExtendedConfig) (*Ec2Provider, error) {
@@ -91,8 +107,21 @@ func NewEc2Provider(ctx context.Context, cfg provider.InitConfig, extCfg config.
 	p := Ec2Provider{
 		rm: cfg.ResourceManager,
 	}
+	p.ctx, p.cancel = context.WithCancel(ctx)
+
+	p.shutdownTracing, err = tracing.Init(ctx, config.Config().TracingConfig)
+	if err != nil {
+		klog.ErrorS(err, "Unable to initialize tracing")
+		return nil, err
+	}
+
+	p.awsClients, err = awsutils.NewClients(ctx)
+	if err != nil {
+		klog.ErrorS(err, "Unable to create AWS clients")
+		return nil, err
+	}
 
-	p.EniNode, err = GetOrCreateEniNode(ctx)
+	p.EniNode, err = GetOrCreateEniNode(ctx, p.awsClients.EC2)
 	if err != nil {
 		klog.Errorf(
 			"Unable to get or create node (ENI).  Are the AWS credentials expired/invalid? %v",
@@ -102,25 +131,49 @@ func NewEc2Provider(ctx context.Context, cfg provider.InitConfig, extCfg config.
 	}
 	// set the provider local nodeName property
 	p.NodeName = p.EniNode.name
+	p.EniNode.agentCheck = p.checkAgentConnectivity
 
-	p.warmPool, err = NewWarmPool(ctx, &p)
-	if err != nil {
-		panic("handle warm pool instantiation error")
+	p.capacityManager = newCapacityManager(p.awsClients.EC2, p.awsClients.ServiceQuotas)
+	p.EniNode.resources = p.nodeResources
+
+	p.newPodClient = func(pod *corev1.Pod) vkvmaclient.GrpcClient { return vkvmaclient.NewVkvmaPodClient(pod) }
+
+	p.launchQueue = newLaunchQueue(config.Config().LaunchQueueConfig.MaxInFlightLaunches)
+
+	p.hostPool = newHostPool(p.awsClients.EC2, p.NodeName)
+	if config.Config().HostPoolConfig.Enabled {
+		p.goProvider(func() { p.hostPool.run(p.ctx) })
 	}
-	p.warmPool.fillAndMaintain()
 
-	p.computeManager, err = NewComputeManager(ctx)
+	p.warmPool = NewWarmPool(&p, p.awsClients.EC2, p.awsClients.S3)
+	p.warmPool.fillAndMaintain(p.ctx)
+
+	p.computeManager = NewComputeManager(p.awsClients.EC2, p.awsClients.S3)
+
+	k8sClient, err := k8sutils.NewK8sClient(extCfg.KubeConfigPath)
 	if err != nil {
-		panic("handle compute manager instantiation error")
+		klog.ErrorS(err,
+			"Unable to create k8s client (orphaned instance collection, finalizers, and annotation updates disabled)")
+	} else {
+		p.k8sClient = k8sClient
+	}
+
+	p.eventRecorder = p.newEventRecorder()
+
+	if config.Config().OrphanCollectorConfig.Enabled && p.k8sClient != nil {
+		orphanCollector := newOrphanCollector(&p, p.computeManager.ec2Client, p.k8sClient)
+		p.goProvider(func() { orphanCollector.run(p.ctx) })
 	}
 
-	p.defaultHandler = health.NewCheckHandler()
+	if config.Config().FinalizerConfig.Enabled && p.k8sClient != nil {
+		p.goProvider(func() { p.reconcileFinalizers(p.ctx) })
+	}
 
 	// start metrics endpoint
 	go metrics.ExposeMetrics()
 
 	// start status reporting loop
-	go p.statusLoop()
+	p.goProvider(func() { p.statusLoop(p.ctx) })
 
 	return &p, nil
 }
@@ -132,11 +185,14 @@ func NewEc2Provider(ctx context.Context, cfg provider.InitConfig, extCfg config.
 // PodLifecycleHandler methods (required)
 // See https://pkg.go.dev/github.com/virtual-kubelet/virtual-kubelet/node#PodLifecycleHandler
 
-func (p *Ec2Provider) CreatePod(ctx context.Context, pod *corev1.Pod) error {
+func (p *Ec2Provider) CreatePod(ctx context.Context, pod *corev1.Pod) (err error) {
 	klog.Infof("Received CreatePod request for pod %v(%v)", pod.Name, pod.Namespace)
 
+	ctx, span := tracing.Start(ctx, "CreatePod", tracing.PodAttributes(pod)...)
+	defer func() { tracing.End(span, err) }()
+
 	// create (but don't start) pod monitor
-	podMonitor, err := health.NewPodMonitor(pod, p.defaultHandler)
+	podMonitor, err := health.NewPodMonitor(pod, p.newCheckHandler())
 	if err != nil {
 		klog.ErrorS(err, "Can't create pod monitor", "pod", klog.KObj(pod))
 		return err
@@ -144,49 +200,26 @@ func (p *Ec2Provider) CreatePod(ctx context.Context, pod *corev1.Pod) error {
 
 	podKey := utils.GetPodCacheKey(pod.Namespace, pod.Name)
 
+	// record the pod as Pending, then provision it in the background (which can take many minutes)
+	pod.Status.Phase = corev1.PodPending
+	initProvisioningConditions(pod)
+
 	// add pod to cache
 	metaPod := NewMetaPod(pod, podMonitor, p.podNotifier)
+	metaPod.startContext(p.ctx)
+	provisionCtx, cancel := context.WithCancel(metaPod.ctx)
+	metaPod.startProvisioning(cancel)
 	p.pods.Set(podKey, metaPod)
 
-	// launch EC2
-	instanceID, privateIP, err := p.computeManager.GetCompute(ctx, p, pod)
-	if err != nil {
-		p.pods.Delete(podKey)
-
-		klog.ErrorS(err, "Error getting compute for pod", "pod", klog.KObj(pod))
-		return err
-	}
-
-	pod.Status.PodIP = privateIP
-	pod.Status.HostIP = privateIP
-
-	// launch application
-	// NOTE LaunchApplicationResponse is currently empty (so we discard it)
-	_, err = p.launchApplication(ctx, pod)
-	if err != nil {
-		klog.ErrorS(err, "Error launching application", "pod", klog.KObj(pod))
-		metrics.LaunchApplicationErrors.Inc()
-		return err
-	}
-
-	if utils.PodIsWarmPool(ctx, pod, len(p.warmPool.config)) {
-		// mark the instance as IN_USE
-		err = p.warmPool.updateEC2Tags(ctx, instanceID, "set_in_use", *pod)
-		if err != nil {
-			klog.ErrorS(err, "Can't update EC2 tags for Warm Pool", "instance",
-				instanceID, pod, "pod", klog.KObj(pod))
-			return err
-		}
-	}
-
-	// start monitoring
-	podMonitor.Start(ctx)
+	// the pod counts against node resources from now on
+	p.EniNode.RequestStatusRefresh()
 
 	// notify k8s with pod status update
 	p.podNotifier(pod)
 
-	// increment metric
-	metrics.PodsLaunched.Inc()
+	// provisioning is traced as part of this request (though it outlives the request's context)
+	provisionCtx = tracing.WithParent(provisionCtx, ctx)
+	p.goPod(metaPod, func() { p.provisionPod(metaPod.ctx, provisionCtx, metaPod) })
 
 	return nil
 }
@@ -194,72 +227,127 @@ func (p *Ec2Provider) CreatePod(ctx context.Context, pod *corev1.Pod) error {
 func (p *Ec2Provider) UpdatePod(ctx context.Context, pod *corev1.Pod) error {
 	klog.Infof("Received UpdatePod request for pod %v(%v)", pod.Name, pod.Namespace)
 
-	// update pod cache
 	podKey := utils.GetPodCacheKey(pod.Namespace, pod.Name)
-	err := p.pods.UpdatePod(podKey, pod)
-	if err != nil {
+
+	metaPod := p.pods.Get(podKey)
+	if metaPod == nil {
+		err := fmt.Errorf("can't find cache member with key %v to update", podKey)
 		klog.ErrorS(err, "Error updating pod cache", "pod", klog.KObj(pod))
 		return err
 	}
 
+	// the provisioning pod holds state (instance, IP, conditions) k8s doesn't have yet, so it can't be replaced
+	if metaPod.isProvisioning() {
+		klog.InfoS("Ignoring update for pod that is still provisioning", "pod", klog.KObj(pod))
+		return nil
+	}
+
+	// apply annotation changes to the pod's instance and update the cached pod
+	p.updatePod(ctx, metaPod, pod)
+
 	klog.Infof("Updated pod %v(%v)", pod.Name, pod.Namespace)
 
 	return nil
 }
 
-func (p *Ec2Provider) DeletePod(ctx context.Context, pod *corev1.Pod) error {
+func (p *Ec2Provider) DeletePod(ctx context.Context, pod *corev1.Pod) (err error) {
 	klog.InfoS("Received DeletePod request", "pod", klog.KObj(pod))
 
+	ctx, span := tracing.Start(ctx, "DeletePod", tracing.PodAttributes(pod)...)
+	defer func() { tracing.End(span, err) }()
+
 	podKey := utils.GetPodCacheKey(pod.Namespace, pod.Name)
 	metaPod := p.pods.Get(podKey)
+	if metaPod == nil {
+		// e.g. the pod was already deleted
+		klog.InfoS("Pod to delete not found", "pod", klog.KObj(pod))
+		return errdefs.NotFoundf("Pod %v(%v) does not exist", pod.Name, pod.Namespace)
+	}
 
-	var err error
-
-	// stop monitoring
+	// stop monitoring first, so the monitor's handlers don't start more of the pod's goroutines (restarts,
+	//	replacements, etc.) while they are being stopped
 	err = p.stopPodMonitor(ctx, metaPod)
 	if err != nil {
-		klog.ErrorS(err, "Could not stop pod monitoring", "pod", klog.KObj(metaPod.pod))
+		klog.ErrorS(err, "Could not stop pod monitoring", "pod", klog.KObj(pod))
 		return err
 	}
 
-	// terminate application
-	err = p.terminateApp(ctx, metaPod)
-	if err != nil {
-		klog.InfoS(
-			"⚠️  Could not terminate application...proceeding with EC2 termination", "pod", klog.KObj(metaPod.pod))
-		metrics.TerminateApplicationErrors.Inc()
-	}
-
-	// terminate EC2
-	err = p.computeManager.DeleteCompute(ctx, p, pod)
+	// cancel the pod's context (stopping provisioning, restarts, etc.) and wait for its goroutines to exit, so
+	//	resources aren't created after they are cleaned up below
+	err = metaPod.stopContext(ctx)
 	if err != nil {
-		klog.ErrorS(err, "Could not terminate EC2", "pod", klog.KObj(metaPod.pod))
+		klog.ErrorS(err, "Could not stop pod goroutines", "pod", klog.KObj(pod))
 		return err
 	}
 
-	// delete from cache
-	p.pods.Delete(podKey)
-
-	now := metav1.Now()
-
-	pod.Status.Phase = corev1.PodSucceeded
-	pod.Status.Reason = "ProviderPodDeleted"
+	// a replacement that was in progress may have started a new monitor (with the pod's now cancelled context)
+	_ = p.stopPodMonitor(ctx, metaPod)
+
+	// the pod's goroutines have exited, so the rest of the cleanup works from one snapshot of the cached pod
+	cached := metaPod.snapshot()
+
+	// terminate application gracefully (if it got as far as having compute), waiting up to the grace period for the
+	//	containers' final statuses
+	gracePeriod := terminationGracePeriod(pod)
+	gracePeriodExceeded := false
+	var containerStatuses []corev1.ContainerStatus
+
+	if podCompleted(cached) {
+		// the containers already terminated (and reported their final statuses)
+		containerStatuses = cached.Status.ContainerStatuses
+	} else if cached.Status.PodIP != "" {
+		p.recordEvent(cached, corev1.EventTypeNormal, eventReasonKilling,
+			"Stopping application (grace period %ds)", gracePeriod)
+		containerStatuses, err = p.terminateApp(ctx, metaPod, gracePeriod)
+		if err != nil {
+			if isDeadlineExceeded(err) {
+				gracePeriodExceeded = true
+				metrics.TerminationGracePeriodExceeded.Inc()
+				p.recordEvent(cached, corev1.EventTypeWarning, eventReasonGracePeriodExceeded,
+					"Application did not stop within the grace period (%ds)", gracePeriod)
+			}
+			klog.InfoS(
+				"⚠️  Could not terminate application...proceeding with EC2 termination", "pod", klog.KObj(cached),
+				"gracePeriodExceeded", gracePeriodExceeded)
+			metrics.TerminateApplicationErrors.Inc()
+		}
+	}
 
-	for idx := range pod.Status.ContainerStatuses {
-		pod.Status.ContainerStatuses[idx].Ready = false
-		pod.Status.ContainerStatuses[idx].State = corev1.ContainerState{
-			Terminated: &corev1.ContainerStateTerminated{
-				Message:    "EC2 Provider terminated container upon deletion",
-				FinishedAt: now,
-				Reason:     "ProviderPodContainerDeleted",
-			},
+	// terminate (or recycle) EC2 (the cached pod has the instance annotation, which k8s's copy of the pod may not)
+	// NOTE a pod whose provisioning failed has no instance
+	if cached.Annotations["compute.amazonaws.com/instance-id"] != "" {
+		instanceID := cached.Annotations["compute.amazonaws.com/instance-id"]
+		recycled, err := p.computeManager.RecycleCompute(ctx, p, cached)
+		if err != nil {
+			klog.ErrorS(err, "Could not terminate EC2", "pod", klog.KObj(cached))
+			return poderrors.Wrap(err)
+		}
+		if recycled {
+			p.recordEvent(cached, corev1.EventTypeNormal, eventReasonInstanceRecycled,
+				"Reset instance %v and returned it to the warm pool", instanceID)
+		} else {
+			p.recordEvent(cached, corev1.EventTypeNormal, eventReasonInstanceTerminated,
+				"Terminated instance %v", instanceID)
 		}
 	}
 
+	// let k8s delete the pod now that its instance is gone (if this fails the finalizer reconciler tries again)
+	finalizedPod := cached
+	if hasCleanupFinalizer(pod) {
+		finalizedPod = pod
+	}
+	if err = p.finalizePod(ctx, finalizedPod); err != nil {
+		klog.ErrorS(err, "Unable to remove cleanup finalizer from pod", "pod", klog.KObj(pod))
+	}
+
+	// delete from cache
+	p.pods.Delete(podKey)
+	p.EniNode.RequestStatusRefresh()
+
 	// notify k8s
-	p.notifyPodDelete(pod)
+	p.notifyPodDelete(pod, containerStatuses, unreportedContainerState(gracePeriodExceeded, gracePeriod))
 
-	klog.InfoS("Pod deleted", "pod", klog.KObj(metaPod.pod))
+	klog.InfoS("Pod deleted", "pod", klog.KObj(cached))
 
 	return nil
 }
@@ -272,7 +360,7 @@ func (p *Ec2Provider) GetPod(ctx context.Context, namespace, name string) (*core
 		klog.InfoS("GetPod request for non-existent pod", "namespace", namespace, "name", name, "pods", p.pods)
 		return nil, errdefs.NotFoundf("Pod %v(%v) does not exist", name, namespace)
 	}
-	return metaPod.pod, nil
+	return metaPod.snapshot(), nil
 }
 
 func (p *Ec2Provider) GetPodStatus(ctx context.Context, namespace, name string) (*corev1.PodStatus, error) {
@@ -282,7 +370,7 @@ func (p *Ec2Provider) GetPodStatus(ctx context.Context, namespace, name string)
 	if metaPod == nil {
 		return nil, errdefs.NotFoundf("Pod %v(%v) does not exist", name, namespace)
 	}
-	return &metaPod.pod.Status, nil
+	return &metaPod.snapshot().Status, nil
 }
 
 func (p *Ec2Provider) GetPods(ctx context.Context) ([]*corev1.Pod, error) {
@@ -317,57 +405,105 @@ func (p *Ec2Provider) ConfigureNode(ctx context.Context, node *corev1.Node) {
 	}
 }
 
-// Provider methods (optional)
-// See https://pkg.go.dev/github.com/virtual-kubelet/virtual-kubelet/node/nodeutil#Provider
+// NodeProvider methods (delegated to the EniNode)
+// See https://pkg.go.dev/github.com/virtual-kubelet/virtual-kubelet/node#NodeProvider
 
-func (p *Ec2Provider) GetContainerLogs(ctx context.Context, namespace, podName, containerName string, opts api.ContainerLogOpts) (io.ReadCloser, error) {
-	return nil, errdefs.NotFound("Not yet implemented")
+func (p *Ec2Provider) Ping(ctx context.Context) error {
+	return p.EniNode.Ping(ctx)
 }
 
-func (p *Ec2Provider) RunInContainer(ctx context.Context, namespace, podName, containerName string, cmd []string, attach api.AttachIO) error {
-	return errdefs.NotFound("Not yet implemented")
+func (p *Ec2Provider) NotifyNodeStatus(ctx context.Context, cb func(*corev1.Node)) {
+	p.EniNode.NotifyNodeStatus(ctx, cb)
 }
 
-func (p *Ec2Provider) GetStatsSummary(ctx context.Context) (*statsv1alpha1.Summary, error) {
-	return nil, errdefs.NotFound("Not yet implemented")
+// nodeResources returns the node's capacity and the resources still allocatable given the pods placed on it
+func (p *Ec2Provider) nodeResources(ctx context.Context) (corev1.ResourceList, corev1.ResourceList) {
+	var pods []*corev1.Pod
+	if p.pods != nil {
+		pods = p.pods.GetPodList()
+	}
+
+	return p.capacityManager.Resources(ctx, pods)
 }
 
-func (p *Ec2Provider) launchApplication(
-	ctx context.Context, pod *corev1.Pod) (*vkvmagent_v0.LaunchApplicationResponse, error) {
-	cfg := config.Config()
+// checkAgentConnectivity returns an error if the VKVMAgents of all pods with compute are unhealthy, which points to a
+//
+//	connectivity problem between this node and the instances rather than a problem with any individual pod
+func (p *Ec2Provider) checkAgentConnectivity() error {
+	if p.pods == nil {
+		return nil
+	}
+
+	var total, unhealthy int
+	for _, metaPod := range p.pods.GetList() {
+		monitor := metaPod.getMonitor()
+		if monitor == nil || metaPod.snapshot().Status.PodIP == "" {
+			continue
+		}
+		total++
+		if monitor.AgentUnhealthy() {
+			unhealthy++
+		}
+	}
 
-	var launchAppResp *vkvmagent_v0.LaunchApplicationResponse
+	if total > 0 && unhealthy == total {
+		return fmt.Errorf("VKVMAgent is unreachable for all %d pods", total)
+	}
 
-	vkvmaClient := vkvmaclient.NewVkvmaClient(pod.Status.PodIP, cfg.VKVMAgentConnectionConfig.Port)
+	return nil
+}
 
-	appClient, err := vkvmaClient.GetApplicationLifecycleClient(ctx)
-	if err != nil {
-		klog.ErrorS(err, "Error getting ApplicationLifecycleClient", "pod", klog.KObj(pod))
-		metrics.GRPCAppClientErrors.Inc()
-		err2 := p.computeManager.DeleteCompute(ctx, p, pod)
-		if err2 != nil {
-			klog.ErrorS(err2, "Error deleting compute while cleaning up failed CreatePod", "original error", err)
-		}
-		p.pods.Delete(utils.GetPodCacheKey(pod.Namespace, pod.Name))
+// Provider methods (optional)
+// See https://pkg.go.dev/github.com/virtual-kubelet/virtual-kubelet/node/nodeutil#Provider
 
+// GetContainerLogs streams a container's logs from the pod's VKVMAgent.  The returned reader owns the underlying gRPC
+//
+//	stream, which is ended when VK closes the reader (or the request context is cancelled).
+func (p *Ec2Provider) GetContainerLogs(ctx context.Context, namespace, podName, containerName string, opts api.ContainerLogOpts) (io.ReadCloser, error) {
+	klog.InfoS("Received GetContainerLogs request", "namespace", namespace, "name", podName,
+		"container", containerName, "opts", opts)
+
+	pod, err := p.getContainerPod(namespace, podName, containerName)
+	if err != nil {
 		return nil, err
 	}
 
-	launchAppResp, err = appClient.LaunchApplication(ctx, &vkvmagent_v0.LaunchApplicationRequest{
-		Pod: pod,
-	})
+	vkvmaClient := p.newPodClient(pod)
+
+	logs, err := vkvmaClient.GetContainerLogs(ctx, containerName, opts)
 	if err != nil {
-		klog.ErrorS(err, "Error Launching Application for pod", "pod", klog.KObj(pod))
+		klog.ErrorS(err, "Error getting container logs", "pod", klog.KObj(pod), "container", containerName)
+		metrics.GetContainerLogsErrors.Inc()
+		return nil, poderrors.Wrap(err)
+	}
 
-		err2 := p.computeManager.DeleteCompute(ctx, p, pod)
-		if err2 != nil {
-			klog.ErrorS(err2, "Error deleting compute while cleaning up failed CreatePod", "original error", err)
-		}
-		p.pods.Delete(utils.GetPodCacheKey(pod.Namespace, pod.Name))
+	return logs, nil
+}
 
-		return nil, err
+func (p *Ec2Provider) RunInContainer(ctx context.Context, namespace, podName, containerName string, cmd []string, attach api.AttachIO) error {
+	klog.InfoS("Received RunInContainer request", "namespace", namespace, "name", podName,
+		"container", containerName, "cmd", cmd, "tty", attach.TTY())
+
+	pod, err := p.getContainerPod(namespace, podName, containerName)
+	if err != nil {
+		return err
+	}
+
+	vkvmaClient := p.newPodClient(pod)
+
+	err = vkvmaClient.ExecInContainer(ctx, containerName, cmd, attach)
+	if err != nil {
+		// a non-zero exit from the command itself is a normal outcome (not an exec failure)
+		var exitErr utilexec.ExitError
+		if !errors.As(err, &exitErr) {
+			klog.ErrorS(err, "Error running command in container", "pod", klog.KObj(pod), "container", containerName)
+			metrics.ExecErrors.Inc()
+			return poderrors.Wrap(err)
+		}
+		return err
 	}
-	return launchAppResp, nil
+
+	return nil
 }
 
 // NOTE while klog flags are present in the command line options presented by `virtual-kubelet -h`, it does not process
@@ -397,10 +533,15 @@ func (p *Ec2Provider) recreatePod(ctx context.Context, pod *corev1.Pod) {
 
 	var err error
 
-	// stop monitoring
+	// stop monitoring and the old pod's goroutines (provisioning, restarts, etc.)
 	err = p.stopPodMonitor(ctx, metaPod)
 	if err != nil {
-		klog.ErrorS(err, "Could not stop pod monitoring", "pod", klog.KObj(metaPod.pod))
+		klog.ErrorS(err, "Could not stop pod monitoring", "pod", klog.KObj(pod))
+	}
+	if metaPod != nil {
+		if err = metaPod.stopContext(ctx); err != nil {
+			klog.ErrorS(err, "Could not stop pod goroutines", "pod", klog.KObj(pod))
+		}
 	}
 
 	// terminate EC2
@@ -409,8 +550,8 @@ func (p *Ec2Provider) recreatePod(ctx context.Context, pod *corev1.Pod) {
 		klog.Errorf("Error deleting compute: %v", err)
 	}
 
-	// notify k8s
-	p.notifyPodDelete(pod)
+	// notify k8s (the containers' final statuses are unknown since their instance is gone)
+	p.notifyPodDelete(pod, nil, unreportedContainerState(false, 0))
 
 	// delete from cache
 	p.pods.Delete(podKey)
@@ -433,61 +574,71 @@ func (p *Ec2Provider) stopPodMonitor(ctx context.Context, metaPod *MetaPod) erro
 	// stop pod monitor goroutine (if it exists)
 	var err error
 
-	if metaPod != nil && metaPod.monitor != nil {
-		metaPod.monitor.Stop()
+	if metaPod == nil {
+		err = errors.New("metaPod or metaPod.monitor is nil")
+	} else if monitor := metaPod.getMonitor(); monitor != nil {
+		monitor.Stop()
 	} else {
 		err = errors.New("metaPod or metaPod.monitor is nil")
 	}
 	return err
 }
 
-func (p *Ec2Provider) terminateApp(ctx context.Context, metaPod *MetaPod) error {
-	cfg := config.Config()
-
-	var termAppResp *vkvmagent_v0.TerminateApplicationResponse
+// getContainerPod returns the cached pod for a container that is ready to be reached via its VKVMAgent, or a NotFound
+//
+//	error if the pod or container doesn't exist (or the pod has no compute yet)
+func (p *Ec2Provider) getContainerPod(namespace, podName, containerName string) (*corev1.Pod, error) {
+	metaPod := p.pods.Get(utils.GetPodCacheKey(namespace, podName))
+	if metaPod == nil {
+		return nil, errdefs.NotFoundf("Pod %v(%v) does not exist", podName, namespace)
+	}
 
-	pod := metaPod.pod
+	pod := metaPod.snapshot()
 
-	vkvmaClient := vkvmaclient.NewVkvmaClient(pod.Status.PodIP, cfg.VKVMAgentConnectionConfig.Port)
+	if !podHasContainer(pod, containerName) {
+		return nil, errdefs.NotFoundf("Container %v does not exist in pod %v(%v)", containerName, podName, namespace)
+	}
 
-	appClient, err := vkvmaClient.GetApplicationLifecycleClient(ctx)
-	if err != nil {
-		klog.ErrorS(err, "Could not get ApplicationLifecycleClient", "pod", klog.KObj(metaPod.pod))
-		metrics.GRPCAppClientErrors.Inc()
-		return err
+	if pod.Status.PodIP == "" {
+		return nil, errdefs.NotFoundf("Pod %v(%v) does not have compute assigned yet", podName, namespace)
 	}
 
-	termAppResp, err = appClient.TerminateApplication(ctx, &vkvmagent_v0.TerminateApplicationRequest{})
-	if err != nil {
-		klog.ErrorS(err, "Could not Terminate Application", "pod", klog.KObj(metaPod.pod))
-		return err
+	return pod, nil
+}
+
+// podHasContainer returns true if the pod spec contains a container (or init container) with the given name
+func podHasContainer(pod *corev1.Pod, containerName string) bool {
+	for _, container := range pod.Spec.Containers {
+		if container.Name == containerName {
+			return true
+		}
 	}
+	for _, container := range pod.Spec.InitContainers {
+		if container.Name == containerName {
+			return true
+		}
+	}
+	return false
+}
 
-	klog.Infof("Application terminated with response: %+v", termAppResp)
-	return nil
+// notifyPod notifies VK of a cached pod's status, with a snapshot of the pod (VK reads the pod after the notification
+//
+//	returns, while the cached pod may be changing)
+func (p *Ec2Provider) notifyPod(metaPod *MetaPod) {
+	p.podNotifier(metaPod.snapshot())
 }
 
-func (p *Ec2Provider) notifyPodDelete(pod *corev1.Pod) {
-	pod.Status.Phase = corev1.PodSucceeded
+func (p *Ec2Provider) notifyPodDelete(pod *corev1.Pod, containerStatuses []corev1.ContainerStatus,
+	unreported corev1.ContainerStateTerminated) {
+	// set container statuses for termination (this also sets the phase)
+	setTerminatedContainerStatuses(pod, containerStatuses, unreported)
 	pod.Status.Reason = "ProviderPodDeleted"
 
-	// set container statuses for termination
-	for idx := range pod.Status.ContainerStatuses {
-		pod.Status.ContainerStatuses[idx].Ready = false
-		pod.Status.ContainerStatuses[idx].State = corev1.ContainerState{
-			Terminated: &corev1.ContainerStateTerminated{
-				Message:    "Pod deletion requested",
-				FinishedAt: metav1.Now(),
-			},
-		}
-	}
 	p.podNotifier(pod)
 }
 
 // PopulateCache enables loading of pod cache from k8s itself prior to k8s asking us for the list of pods 😵‍💫
 func (p *Ec2Provider) PopulateCache(cache *PodCache) {
-	var err error
-
 	metaPods := cache.GetList()
 
 	klog.Infof("Populating cache: loading %v pods and creating monitors", len(metaPods))
@@ -495,29 +646,43 @@ func (p *Ec2Provider) PopulateCache(cache *PodCache) {
 		klog.Infof("Recreating pod monitor for pod %v(%v) (populated from cache)",
 			metaPod.pod.Name, metaPod.pod.Namespace)
 
-		handler := health.NewCheckHandler()
-		metaPod.monitor, err = health.NewPodMonitor(metaPod.pod, handler)
+		metaPod.startContext(p.ctx)
+
+		monitor, err := health.NewPodMonitor(metaPod.pod, p.newCheckHandler())
 		if err != nil {
 			klog.Errorf("Can't create pod health monitor for pod %v(%v): %v",
 				metaPod.pod.Name, metaPod.pod.Namespace, err)
 		}
-		metaPod.monitor.Start(context.TODO())
+		metaPod.setMonitor(monitor)
+
+		// a completed pod has nothing left to monitor (and its instance may be gone)
+		if !podCompleted(metaPod.pod) {
+			monitor.Start(metaPod.ctx)
+			metaPod.startWatchingInstanceEvents(p)
+		}
 	}
 
 	p.pods = cache
 }
 
-func (p *Ec2Provider) statusLoop() {
+func (p *Ec2Provider) statusLoop(ctx context.Context) {
 	cfg := config.Config()
 
 	ticker := time.NewTicker(time.Duration(cfg.StatusIntervalSeconds) * time.Second)
+	defer ticker.Stop()
 
 	var monitors map[string]string
 	var monitorStates []string
 	var message string
 	var numPods int
 
-	for range ticker.C {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
 		if p.pods != nil {
 			numPods = 0
 			monitors = make(map[string]string)
@@ -525,10 +690,12 @@ func (p *Ec2Provider) statusLoop() {
 			// collect pod stats
 			for _, metaPod := range p.pods.GetList() {
 				// collect monitors for pod
-				for _, monitor := range metaPod.monitor.Monitors {
-					monitorStates = append(monitorStates, monitor.String())
+				if podMonitor := metaPod.getMonitor(); podMonitor != nil {
+					for _, monitor := range podMonitor.Monitors {
+						monitorStates = append(monitorStates, monitor.String())
+					}
 				}
-				monitors[metaPod.pod.Name] = strings.Join(monitorStates, ", ")
+				monitors[metaPod.snapshot().Name] = strings.Join(monitorStates, ", ")
 				monitorStates = nil // reset monitorStates for next (meta)pod
 				numPods++
 			}
@@ -0,0 +1,130 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package ec2provider
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/virtual-kubelet/virtual-kubelet/node/api"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws/aws-virtual-kubelet/internal/config"
+	"github.com/aws/aws-virtual-kubelet/internal/utils"
+	"github.com/aws/aws-virtual-kubelet/internal/vkvmaclient"
+	mock_vkvmaclient "github.com/aws/aws-virtual-kubelet/mocks/vkvmaclient"
+)
+
+// nopAttachIO is an api.AttachIO without any streams
+type nopAttachIO struct{}
+
+func (nopAttachIO) Stdin() io.Reader            { return nil }
+func (nopAttachIO) Stdout() io.WriteCloser      { return nil }
+func (nopAttachIO) Stderr() io.WriteCloser      { return nil }
+func (nopAttachIO) TTY() bool                   { return false }
+func (nopAttachIO) Resize() <-chan api.TermSize { return nil }
+
+func TestDeletePodStopsPodGoroutines(t *testing.T) {
+	initTestConfig(t, config.ProviderConfig{})
+
+	p := newTestProvider(&fakeEC2{})
+	metaPod := cacheTestPod(t, p, corev1.PodPending, "")
+	metaPod.updatePod(func(pod *corev1.Pod) {
+		// the pod has no compute yet (so there's no application to stop)
+		pod.Status.PodIP = ""
+	})
+
+	// a pod goroutine (e.g. provisioning) that runs until the pod is deleted
+	exited := false
+	p.goPod(metaPod, func() {
+		<-metaPod.ctx.Done()
+		exited = true
+	})
+
+	if err := p.DeletePod(context.Background(), metaPod.snapshot()); err != nil {
+		t.Fatalf("DeletePod: unexpected error %v", err)
+	}
+
+	// NOTE exited is only safe to read if DeletePod waited for the goroutine to exit
+	if !exited {
+		t.Error("expected DeletePod to wait for the pod's goroutines to exit")
+	}
+
+	started := false
+	p.goPod(metaPod, func() { started = true })
+	if started {
+		t.Error("expected no goroutine to be started for a deleted pod")
+	}
+}
+
+func TestRecreatePodStopsOldPod(t *testing.T) {
+	initTestConfig(t, config.ProviderConfig{})
+
+	p := newTestProvider(&fakeEC2{})
+	oldMetaPod := cacheTestPod(t, p, corev1.PodRunning, "")
+
+	// the old pod's context isn't derived from the provider's, so only recreatePod can cancel it
+	oldMetaPod.ctx, oldMetaPod.cancel = context.WithCancel(context.Background())
+	exited := false
+	p.goPod(oldMetaPod, func() {
+		<-oldMetaPod.ctx.Done()
+		exited = true
+	})
+
+	// the recreated pod isn't provisioned (its context, derived from the provider's, is already cancelled)
+	p.cancel()
+
+	p.recreatePod(context.Background(), oldMetaPod.snapshot())
+
+	if !exited {
+		t.Error("expected recreatePod to stop the old pod's goroutines")
+	}
+	if newMetaPod := p.pods.Get(utils.GetPodCacheKey("default", "pod")); newMetaPod == nil || newMetaPod == oldMetaPod {
+		t.Error("expected the pod to be recreated in the cache")
+	}
+}
+
+func TestContainerRequestsUsePodClient(t *testing.T) {
+	initTestConfig(t, config.ProviderConfig{})
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock_vkvmaclient.NewMockGrpcClient(ctrl)
+	client.EXPECT().GetContainerLogs(gomock.Any(), "app", gomock.Any()).Return(
+		io.NopCloser(strings.NewReader("logs")), nil)
+	client.EXPECT().ExecInContainer(gomock.Any(), "app", []string{"ls"}, gomock.Any()).Return(nil)
+
+	p := newTestProvider(&fakeEC2{})
+	var clientPods []string
+	p.newPodClient = func(pod *corev1.Pod) vkvmaclient.GrpcClient {
+		clientPods = append(clientPods, pod.Status.PodIP)
+		return client
+	}
+	cacheTestPod(t, p, corev1.PodRunning, "i-0123456789abcdef0")
+
+	logs, err := p.GetContainerLogs(context.Background(), "default", "pod", "app", api.ContainerLogOpts{})
+	if err != nil {
+		t.Fatalf("GetContainerLogs: unexpected error %v", err)
+	}
+	_ = logs.Close()
+
+	err = p.RunInContainer(context.Background(), "default", "pod", "app", []string{"ls"}, nopAttachIO{})
+	if err != nil {
+		t.Fatalf("RunInContainer: unexpected error %v", err)
+	}
+
+	if len(clientPods) != 2 || clientPods[0] != "10.0.0.1" || clientPods[1] != "10.0.0.1" {
+		t.Errorf("expected a pod client for the cached pod per request, got clients for %v", clientPods)
+	}
+}
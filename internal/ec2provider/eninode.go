@@ -11,18 +11,19 @@ package ec2provider
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-virtual-kubelet/internal/config"
 
 	"github.com/aws/aws-virtual-kubelet/internal/metrics"
+	"github.com/aws/aws-virtual-kubelet/internal/poderrors"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	"k8s.io/apimachinery/pkg/api/resource"
-
 	"k8s.io/klog/v2"
 
 	"github.com/aws/aws-virtual-kubelet/internal/awsutils"
@@ -31,22 +32,49 @@ import (
 	corev1 "k8s.io/api/core/v1"
 )
 
+// Node condition reasons and messages reported by the node status loop
+const (
+	nodeReadyReason             = "Virtual Kubelet is ready"
+	nodeReadyMessage            = "ok"
+	nodeEC2AuthFailureReason    = "EC2AuthFailure"
+	nodeENINotFoundReason       = "ENINotFound"
+	nodeENIReplacedReason       = "ENIReplaced"
+	nodeAgentsUnreachableReason = "AgentsUnreachable"
+)
+
 type EniNode struct {
-	name               string
-	hostname           string
-	lastTransitionTime time.Time
+	name     string
+	hostname string
+	// tagValue is the Name tag value of the ENI representing this node
+	tagValue string
+	// eniID is the ID of the ENI this node was created from
+	eniID     string
+	ec2Client awsutils.EC2API
+	// agentCheck (if set) returns an error when VKVMAgents are unreachable from this node
+	agentCheck func() error
+	// resources (if set) returns the node's current capacity and allocatable resources
+	resources func(ctx context.Context) (corev1.ResourceList, corev1.ResourceList)
+	// refresh receives requests to refresh node resources outside the regular status check interval
+	refresh chan struct{}
+
+	mu sync.Mutex
+	// node is the most recently configured node, used as the basis for status notifications
+	node *corev1.Node
+	// notify is the callback VK provides to receive node status changes
+	notify     func(*corev1.Node)
+	conditions []corev1.NodeCondition
+	// capacity and allocatable are the most recently reported node resources
+	capacity    corev1.ResourceList
+	allocatable corev1.ResourceList
+	// lastCheck is when the node status was last checked (successfully or not)
+	lastCheck time.Time
 }
 
-func GetOrCreateEniNode(ctx context.Context) (*EniNode, error) {
+func GetOrCreateEniNode(ctx context.Context, ec2Client awsutils.EC2API) (*EniNode, error) {
 	cfg := config.Config()
 	clusterName := cfg.ClusterName
 	subnetId := cfg.ManagementSubnet
 
-	ec2Client, err := awsutils.NewEc2Client()
-	if err != nil {
-		return nil, err
-	}
-
 	var eniTag string
 
 	// append unique, consistent value to ENI tag to allow multiple VKP processes to coexist
@@ -61,40 +89,70 @@ func GetOrCreateEniNode(ctx context.Context) (*EniNode, error) {
 		eniTag = clusterName
 	}
 
-	nodeName, err := getOrCreateNodeName(eniTag, subnetId, ec2Client)
+	nodeName, eniID, err := getOrCreateNodeName(eniTag, subnetId, ec2Client)
 	if err != nil {
 		return nil, err
 	}
 
+	now := time.Now()
+
 	return &EniNode{
-		name:               nodeName,
-		hostname:           nodeName,
-		lastTransitionTime: time.Now(),
+		name:       nodeName,
+		hostname:   nodeName,
+		tagValue:   eniTag,
+		eniID:      eniID,
+		ec2Client:  ec2Client,
+		refresh:    make(chan struct{}, 1),
+		conditions: newNodeConditions(v1.NewTime(now)),
+		lastCheck:  now,
 	}, nil
 }
 
 // getOrCreateNodeName gets private dns name of the network interface for the given parameters (creating one if needed)
-func getOrCreateNodeName(tagValue string, subnetId string, ec2Client awsutils.EC2API) (string, error) {
+//
+//	along with the ID of the network interface
+func getOrCreateNodeName(tagValue string, subnetId string, ec2Client awsutils.EC2API) (string, string, error) {
 	if tagValue == "" || subnetId == "" {
-		return "", errors.New("Parameters tagValue, subnetId  are required. ")
+		return "", "", errors.New("Parameters tagValue, subnetId  are required. ")
 	}
 
 	klog.Infof("Fetching ENI by Name Tag value '%v'", tagValue)
-	var privateIP, _, err = awsutils.GetNetworkInterfaceByTagName(tagValue, ec2Client)
+	var privateIP, eniID, err = awsutils.GetNetworkInterfaceByTagName(tagValue, ec2Client)
 	if err != nil {
 		metrics.NodeNameErrors.Inc()
-		return "", err
+		return "", "", err
 	}
 	if privateIP == "" {
 		klog.Infof("ENI with Name Tag value '%v' not found, creating...", tagValue)
-		privateIP, _, _ = awsutils.CreateNetworkInterface(tagValue, subnetId, ec2Client)
+		privateIP, eniID, _ = awsutils.CreateNetworkInterface(tagValue, subnetId, ec2Client)
 		klog.Infof("ENI created, private IP address: '%v'", privateIP)
 	}
 
 	// NOTE This fargate prefix must exist for interoperability with EKS
 	vkPodName := "fargate-" + privateIP
 
-	return vkPodName, nil
+	return vkPodName, eniID, nil
+}
+
+// newNodeConditions returns the initial (healthy) node conditions
+func newNodeConditions(transitionTime v1.Time) []corev1.NodeCondition {
+	conditions := []corev1.NodeCondition{
+		{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+		{Type: corev1.NodePIDPressure, Status: corev1.ConditionFalse},
+		{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionFalse},
+		{Type: corev1.NodeDiskPressure, Status: corev1.ConditionFalse},
+		{Type: corev1.NodeNetworkUnavailable, Status: corev1.ConditionFalse},
+		{Type: "KubeletConfigOk", Status: corev1.ConditionTrue},
+	}
+
+	for i := range conditions {
+		conditions[i].LastHeartbeatTime = transitionTime
+		conditions[i].LastTransitionTime = transitionTime
+		conditions[i].Reason = nodeReadyReason
+		conditions[i].Message = nodeReadyMessage
+	}
+
+	return conditions
 }
 
 func (en *EniNode) Configure(ctx context.Context, k8sNode *corev1.Node) (*corev1.Node, error) {
@@ -112,74 +170,19 @@ func (en *EniNode) Configure(ctx context.Context, k8sNode *corev1.Node) (*corev1
 		Architecture:    "amd64",
 	}
 
-	defaultCapacity := corev1.ResourceList{
-		"cpu":     resource.MustParse(config.DefaultCpuCapacity),
-		"memory":  resource.MustParse(config.DefaultMemoryCapacity),
-		"storage": resource.MustParse(config.DefaultStorageCapacity),
-		"pods":    resource.MustParse(config.DefaultPodCapacity),
-	}
-
-	lastHeartbeatTime := v1.Now()
-	lastTransitionTime := v1.NewTime(en.lastTransitionTime)
-	lastTransitionReason := "Virtual Kubelet is ready"
-	lastTransitionMessage := "ok"
-
-	readyConditions := []corev1.NodeCondition{
-		{
-			Type:               corev1.NodeReady,
-			Status:             corev1.ConditionTrue,
-			LastHeartbeatTime:  lastHeartbeatTime,
-			LastTransitionTime: lastTransitionTime,
-			Reason:             lastTransitionReason,
-			Message:            lastTransitionMessage,
-		},
-		{
-			Type:               corev1.NodePIDPressure,
-			Status:             corev1.ConditionFalse,
-			LastHeartbeatTime:  lastHeartbeatTime,
-			LastTransitionTime: lastTransitionTime,
-			Reason:             lastTransitionReason,
-			Message:            lastTransitionMessage,
-		},
-		{
-			Type:               corev1.NodeMemoryPressure,
-			Status:             corev1.ConditionFalse,
-			LastHeartbeatTime:  lastHeartbeatTime,
-			LastTransitionTime: lastTransitionTime,
-			Reason:             lastTransitionReason,
-			Message:            lastTransitionMessage,
-		},
-		{
-			Type:               corev1.NodeDiskPressure,
-			Status:             corev1.ConditionFalse,
-			LastHeartbeatTime:  lastHeartbeatTime,
-			LastTransitionTime: lastTransitionTime,
-			Reason:             lastTransitionReason,
-			Message:            lastTransitionMessage,
-		},
-		{
-			Type:               corev1.NodeNetworkUnavailable,
-			Status:             corev1.ConditionFalse,
-			LastHeartbeatTime:  lastHeartbeatTime,
-			LastTransitionTime: lastTransitionTime,
-			Reason:             lastTransitionReason,
-			Message:            lastTransitionMessage,
-		},
-		{
-			Type:               "KubeletConfigOk",
-			Status:             corev1.ConditionTrue,
-			LastHeartbeatTime:  lastHeartbeatTime,
-			LastTransitionTime: lastTransitionTime,
-			Reason:             lastTransitionReason,
-			Message:            lastTransitionMessage,
-		},
-	}
+	capacity, allocatable := en.getResources(ctx)
+
+	en.mu.Lock()
+	defer en.mu.Unlock()
+
+	conditions := en.copyConditions()
+	en.capacity, en.allocatable = capacity, allocatable
 
 	k8sNode.Status = corev1.NodeStatus{
-		Capacity:    defaultCapacity,
-		Allocatable: defaultCapacity,
+		Capacity:    capacity.DeepCopy(),
+		Allocatable: allocatable.DeepCopy(),
 		//Phase:           "",
-		Conditions: readyConditions,
+		Conditions: conditions,
 		Addresses:  nil,
 		//DaemonEndpoints: corev1.NodeDaemonEndpoints{},
 		NodeInfo: systemInfo,
@@ -189,16 +192,262 @@ func (en *EniNode) Configure(ctx context.Context, k8sNode *corev1.Node) (*corev1
 		//Config:          nil,
 	}
 
+	// save a copy of the node to base status notifications on
+	en.node = k8sNode.DeepCopy()
+
 	return k8sNode, nil
 }
 
 // NodeProvider methods (required)
 // See https://pkg.go.dev/github.com/virtual-kubelet/virtual-kubelet/node#NodeProvider
 
+// Ping checks that the node status loop is still running.  Node health problems are reported via node conditions
+//
+//	rather than Ping errors, since VK stops updating node status entirely while Ping fails.
 func (en *EniNode) Ping(ctx context.Context) error {
-	panic("What should this do? Check that ENI exists and/or is reachable?")
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	en.mu.Lock()
+	lastCheck := en.lastCheck
+	en.mu.Unlock()
+
+	maxAge := nodeStatusMaxMissedChecks * nodeStatusInterval()
+	if time.Since(lastCheck) > maxAge {
+		return fmt.Errorf("node status hasn't been checked since %v", lastCheck.Format(time.RFC3339))
+	}
+
+	return nil
 }
 
+// NotifyNodeStatus saves the callback used to report node status changes and starts the node status loop
 func (en *EniNode) NotifyNodeStatus(ctx context.Context, cb func(*corev1.Node)) {
-	panic("Save the passed-in callback and use it to notify VK of any node status changes")
+	klog.Info("NotifyNodeStatus notifier callback function set")
+
+	en.mu.Lock()
+	en.notify = cb
+	en.mu.Unlock()
+
+	go en.statusLoop(ctx)
+}
+
+// RequestStatusRefresh asks the node status loop to refresh node resources (e.g. after a pod is placed or removed).
+//
+//	Requests made while one is already pending are coalesced.
+func (en *EniNode) RequestStatusRefresh() {
+	select {
+	case en.refresh <- struct{}{}:
+	default:
+	}
+}
+
+// nodeStatusMaxMissedChecks is the number of status check intervals that can pass without a check before Ping fails
+const nodeStatusMaxMissedChecks = 3
+
+func nodeStatusInterval() time.Duration {
+	return time.Duration(config.Config().NodeStatusIntervalSeconds) * time.Second
+}
+
+// statusLoop periodically checks node status until the context is cancelled
+func (en *EniNode) statusLoop(ctx context.Context) {
+	ticker := time.NewTicker(nodeStatusInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			klog.Info("Node status loop stopped")
+			return
+		case <-ticker.C:
+			en.checkStatus(ctx)
+		case <-en.refresh:
+			en.refreshResources(ctx)
+		}
+	}
+}
+
+// checkStatus checks the ENI, EC2 API access, and VKVMAgent connectivity.  NodeReady and NetworkUnavailable conditions
+//
+//	are updated to reflect the results (and node resources refreshed) and VK is notified if anything changed.
+func (en *EniNode) checkStatus(ctx context.Context) {
+	readyStatus, readyReason, readyMessage := corev1.ConditionTrue, nodeReadyReason, nodeReadyMessage
+	networkStatus, networkReason, networkMessage := corev1.ConditionFalse, nodeReadyReason, nodeReadyMessage
+
+	_, eniID, err := awsutils.GetNetworkInterfaceByTagName(en.tagValue, en.ec2Client)
+
+	switch {
+	case poderrors.Is(err, poderrors.CategoryAuth):
+		metrics.NodeStatusCheckErrors.Inc()
+		klog.ErrorS(err, "EC2 API authorization failed checking node ENI.  Are the AWS credentials expired/invalid?")
+		readyStatus, readyReason, readyMessage = corev1.ConditionFalse, nodeEC2AuthFailureReason, err.Error()
+		// the ENI can't be checked so its current status is retained
+		networkStatus, networkReason, networkMessage = en.conditionState(corev1.NodeNetworkUnavailable)
+	case err != nil:
+		// a transient failure (throttling, timeout, etc.) doesn't tell us anything about node health
+		metrics.NodeStatusCheckErrors.Inc()
+		klog.ErrorS(err, "Unable to check node ENI (node conditions unchanged)", "tag", en.tagValue)
+		en.checked()
+		en.refreshResources(ctx)
+		return
+	case eniID == "":
+		message := fmt.Sprintf("ENI with Name tag %v not found", en.tagValue)
+		networkStatus, networkReason, networkMessage = corev1.ConditionTrue, nodeENINotFoundReason, message
+		readyStatus, readyReason, readyMessage = corev1.ConditionFalse, nodeENINotFoundReason, message
+	case en.eniID != "" && eniID != en.eniID:
+		message := fmt.Sprintf("ENI with Name tag %v was replaced (expected %v, found %v)",
+			en.tagValue, en.eniID, eniID)
+		networkStatus, networkReason, networkMessage = corev1.ConditionTrue, nodeENIReplacedReason, message
+		readyStatus, readyReason, readyMessage = corev1.ConditionFalse, nodeENIReplacedReason, message
+	}
+
+	// agent connectivity only affects readiness if nothing more fundamental is wrong
+	if readyStatus == corev1.ConditionTrue && en.agentCheck != nil {
+		if err := en.agentCheck(); err != nil {
+			klog.ErrorS(err, "VKVMAgent connectivity check failed")
+			readyStatus, readyReason, readyMessage = corev1.ConditionFalse, nodeAgentsUnreachableReason, err.Error()
+		}
+	}
+
+	capacity, allocatable := en.getResources(ctx)
+
+	en.mu.Lock()
+	en.lastCheck = time.Now()
+	now := v1.NewTime(en.lastCheck)
+	changed := en.setCondition(corev1.NodeReady, readyStatus, readyReason, readyMessage, now)
+	changed = en.setCondition(corev1.NodeNetworkUnavailable, networkStatus, networkReason, networkMessage, now) || changed
+	changed = en.setResources(capacity, allocatable) || changed
+	notify, node := en.notify, en.statusNode(changed)
+	en.mu.Unlock()
+
+	if node == nil || notify == nil {
+		return
+	}
+
+	klog.InfoS("Node status changed", "node", en.name, "ready", readyStatus, "reason", readyReason,
+		"networkUnavailable", networkStatus, "allocatable", allocatable)
+	notify(node)
+}
+
+// refreshResources recalculates node resources and notifies VK if they changed
+func (en *EniNode) refreshResources(ctx context.Context) {
+	capacity, allocatable := en.getResources(ctx)
+
+	en.mu.Lock()
+	changed := en.setResources(capacity, allocatable)
+	notify, node := en.notify, en.statusNode(changed)
+	en.mu.Unlock()
+
+	if node == nil || notify == nil {
+		return
+	}
+
+	klog.V(1).InfoS("Node resources changed", "node", en.name, "capacity", capacity, "allocatable", allocatable)
+	notify(node)
+}
+
+// getResources returns the node's current capacity and allocatable resources (falling back to configured capacity)
+func (en *EniNode) getResources(ctx context.Context) (corev1.ResourceList, corev1.ResourceList) {
+	if en.resources != nil {
+		return en.resources(ctx)
+	}
+
+	capacity := configuredCapacity(config.Config().CapacityConfig)
+	return capacity, capacity.DeepCopy()
+}
+
+// setResources updates node resources (caller must hold the lock) and returns whether they changed
+func (en *EniNode) setResources(capacity corev1.ResourceList, allocatable corev1.ResourceList) bool {
+	changed := !resourcesEqual(en.capacity, capacity) || !resourcesEqual(en.allocatable, allocatable)
+	en.capacity, en.allocatable = capacity, allocatable
+
+	return changed
+}
+
+// statusNode returns a copy of the configured node with current conditions and resources if changed is true (or nil
+//
+//	otherwise).  The caller must hold the lock.
+func (en *EniNode) statusNode(changed bool) *corev1.Node {
+	if !changed || en.node == nil {
+		return nil
+	}
+
+	node := en.node.DeepCopy()
+	node.Status.Conditions = en.copyConditions()
+	node.Status.Capacity = en.capacity.DeepCopy()
+	node.Status.Allocatable = en.allocatable.DeepCopy()
+
+	return node
+}
+
+// resourcesEqual returns true if both resource lists contain the same quantities
+func resourcesEqual(a corev1.ResourceList, b corev1.ResourceList) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for name, quantity := range a {
+		other, ok := b[name]
+		if !ok || quantity.Cmp(other) != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// checked records that a status check was attempted
+func (en *EniNode) checked() {
+	en.mu.Lock()
+	en.lastCheck = time.Now()
+	en.mu.Unlock()
+}
+
+// conditionState returns the current status, reason, and message of a condition
+func (en *EniNode) conditionState(
+	conditionType corev1.NodeConditionType) (corev1.ConditionStatus, string, string) {
+	en.mu.Lock()
+	defer en.mu.Unlock()
+
+	for _, c := range en.conditions {
+		if c.Type == conditionType {
+			return c.Status, c.Reason, c.Message
+		}
+	}
+
+	return corev1.ConditionUnknown, "", ""
+}
+
+// setCondition updates a condition (caller must hold the lock).  The transition time only changes when the status
+//
+//	does, and the return value indicates whether the status, reason, or message changed.
+func (en *EniNode) setCondition(conditionType corev1.NodeConditionType, status corev1.ConditionStatus,
+	reason string, message string, now v1.Time) bool {
+	for i := range en.conditions {
+		c := &en.conditions[i]
+		if c.Type != conditionType {
+			continue
+		}
+
+		changed := c.Status != status || c.Reason != reason || c.Message != message
+		if c.Status != status {
+			c.LastTransitionTime = now
+		}
+		c.Status = status
+		c.Reason = reason
+		c.Message = message
+		c.LastHeartbeatTime = now
+
+		return changed
+	}
+
+	return false
+}
+
+// copyConditions returns a copy of the current node conditions (caller must hold the lock)
+func (en *EniNode) copyConditions() []corev1.NodeCondition {
+	conditions := make([]corev1.NodeCondition, len(en.conditions))
+	copy(conditions, en.conditions)
+
+	return conditions
 }
@@ -0,0 +1,246 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package ec2provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/aws-virtual-kubelet/internal/awsutils"
+	"github.com/aws/aws-virtual-kubelet/internal/config"
+)
+
+// fakeENIEC2 returns the node ENI with eniID (if set) or fails with err
+type fakeENIEC2 struct {
+	awsutils.EC2API
+	eniID string
+	err   error
+}
+
+func (f *fakeENIEC2) DescribeNetworkInterfaces(ctx context.Context,
+	input *ec2.DescribeNetworkInterfacesInput) (*ec2.DescribeNetworkInterfacesOutput, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	output := &ec2.DescribeNetworkInterfacesOutput{}
+	if f.eniID != "" {
+		output.NetworkInterfaces = []types.NetworkInterface{{
+			NetworkInterfaceId: aws.String(f.eniID),
+			PrivateDnsName:     aws.String("ip-10-0-0-1.ec2.internal"),
+		}}
+	}
+	return output, nil
+}
+
+// nodeCondition returns a node's condition of the given type (or nil if it has none)
+func nodeCondition(node *corev1.Node, conditionType corev1.NodeConditionType) *corev1.NodeCondition {
+	for i := range node.Status.Conditions {
+		if node.Status.Conditions[i].Type == conditionType {
+			return &node.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// newTestEniNode returns a configured node for the ENI eni-0123456789abcdef0 that records status notifications
+func newTestEniNode(t *testing.T, ec2Client awsutils.EC2API, notified *[]*corev1.Node) *EniNode {
+	en := &EniNode{
+		name:       "fargate-10.0.0.1",
+		hostname:   "fargate-10.0.0.1",
+		tagValue:   "cluster-vk-0",
+		eniID:      "eni-0123456789abcdef0",
+		ec2Client:  ec2Client,
+		refresh:    make(chan struct{}, 1),
+		conditions: newNodeConditions(v1.Now()),
+		lastCheck:  time.Now(),
+	}
+	if _, err := en.Configure(context.Background(), &corev1.Node{}); err != nil {
+		t.Fatalf("Configure: unexpected error %v", err)
+	}
+	en.notify = func(node *corev1.Node) { *notified = append(*notified, node) }
+
+	return en
+}
+
+func TestCheckStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		eniID      string
+		err        error
+		agentErr   error
+		networkSet bool
+		// wantReady and wantNetwork are the NodeReady and NetworkUnavailable condition statuses after the check
+		wantReady   corev1.ConditionStatus
+		wantNetwork corev1.ConditionStatus
+		wantReason  string
+		wantNotify  bool
+	}{
+		{
+			name:        "node with its ENI is ready",
+			eniID:       "eni-0123456789abcdef0",
+			wantReady:   corev1.ConditionTrue,
+			wantNetwork: corev1.ConditionFalse,
+			wantReason:  nodeReadyReason,
+		},
+		{
+			name:        "node without an ENI isn't ready",
+			wantReady:   corev1.ConditionFalse,
+			wantNetwork: corev1.ConditionTrue,
+			wantReason:  nodeENINotFoundReason,
+			wantNotify:  true,
+		},
+		{
+			name:        "node whose ENI was replaced isn't ready",
+			eniID:       "eni-0fedcba9876543210",
+			wantReady:   corev1.ConditionFalse,
+			wantNetwork: corev1.ConditionTrue,
+			wantReason:  nodeENIReplacedReason,
+			wantNotify:  true,
+		},
+		{
+			name:        "node whose agents are unreachable isn't ready",
+			eniID:       "eni-0123456789abcdef0",
+			agentErr:    errors.New("connection refused"),
+			wantReady:   corev1.ConditionFalse,
+			wantNetwork: corev1.ConditionFalse,
+			wantReason:  nodeAgentsUnreachableReason,
+			wantNotify:  true,
+		},
+		{
+			name:        "node without EC2 API access isn't ready",
+			err:         &smithy.GenericAPIError{Code: "AuthFailure", Message: "test"},
+			wantReady:   corev1.ConditionFalse,
+			wantNetwork: corev1.ConditionFalse,
+			wantReason:  nodeEC2AuthFailureReason,
+			wantNotify:  true,
+		},
+		{
+			name:        "node without EC2 API access keeps its network status",
+			err:         &smithy.GenericAPIError{Code: "AuthFailure", Message: "test"},
+			networkSet:  true,
+			wantReady:   corev1.ConditionFalse,
+			wantNetwork: corev1.ConditionTrue,
+			wantReason:  nodeEC2AuthFailureReason,
+			wantNotify:  true,
+		},
+		{
+			name:        "transient failure leaves conditions unchanged",
+			err:         errors.New("request timed out"),
+			wantReady:   corev1.ConditionTrue,
+			wantNetwork: corev1.ConditionFalse,
+			wantReason:  nodeReadyReason,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTestConfig(t, config.ProviderConfig{})
+
+			var notified []*corev1.Node
+			en := newTestEniNode(t, &fakeENIEC2{eniID: tt.eniID, err: tt.err}, &notified)
+			en.agentCheck = func() error { return tt.agentErr }
+			if tt.networkSet {
+				en.setCondition(corev1.NodeNetworkUnavailable, corev1.ConditionTrue, nodeENINotFoundReason, "", v1.Now())
+			}
+			en.lastCheck = time.Now().Add(-time.Hour)
+
+			en.checkStatus(context.Background())
+
+			node := &corev1.Node{Status: corev1.NodeStatus{Conditions: en.copyConditions()}}
+			if ready := nodeCondition(node, corev1.NodeReady); ready.Status != tt.wantReady ||
+				ready.Reason != tt.wantReason {
+				t.Errorf("expected NodeReady %v (%v), got %v (%v)", tt.wantReady, tt.wantReason, ready.Status,
+					ready.Reason)
+			}
+			if network := nodeCondition(node, corev1.NodeNetworkUnavailable); network.Status != tt.wantNetwork {
+				t.Errorf("expected NetworkUnavailable %v, got %v", tt.wantNetwork, network.Status)
+			}
+
+			if tt.wantNotify != (len(notified) == 1) {
+				t.Fatalf("expected notification %v, got %v notification(s)", tt.wantNotify, len(notified))
+			}
+			if tt.wantNotify {
+				if ready := nodeCondition(notified[0], corev1.NodeReady); ready.Reason != tt.wantReason {
+					t.Errorf("expected notified node to have NodeReady reason %v, got %v", tt.wantReason,
+						ready.Reason)
+				}
+			}
+
+			if err := en.Ping(context.Background()); err != nil {
+				t.Errorf("expected a checked node to respond to Ping, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCheckStatusRecovers(t *testing.T) {
+	initTestConfig(t, config.ProviderConfig{})
+
+	ec2Client := &fakeENIEC2{}
+	var notified []*corev1.Node
+	en := newTestEniNode(t, ec2Client, &notified)
+
+	en.checkStatus(context.Background())
+
+	ec2Client.eniID = "eni-0123456789abcdef0"
+	en.checkStatus(context.Background())
+
+	// an unchanged status isn't reported again
+	en.checkStatus(context.Background())
+
+	if len(notified) != 2 {
+		t.Fatalf("expected 2 notifications, got %v", len(notified))
+	}
+	ready := nodeCondition(notified[1], corev1.NodeReady)
+	if ready.Status != corev1.ConditionTrue || ready.Reason != nodeReadyReason {
+		t.Errorf("expected the node to be ready again, got %v (%v)", ready.Status, ready.Reason)
+	}
+}
+
+func TestPingStaleStatus(t *testing.T) {
+	initTestConfig(t, config.ProviderConfig{NodeStatusIntervalSeconds: 10})
+
+	var notified []*corev1.Node
+	en := newTestEniNode(t, &fakeENIEC2{}, &notified)
+
+	en.lastCheck = time.Now().Add(-20 * time.Second)
+	if err := en.Ping(context.Background()); err != nil {
+		t.Errorf("expected Ping to succeed within %v missed checks, got %v", nodeStatusMaxMissedChecks, err)
+	}
+
+	en.lastCheck = time.Now().Add(-time.Minute)
+	if err := en.Ping(context.Background()); err == nil {
+		t.Error("expected Ping to fail when node status hasn't been checked recently")
+	}
+}
+
+func TestRequestStatusRefresh(t *testing.T) {
+	initTestConfig(t, config.ProviderConfig{})
+
+	var notified []*corev1.Node
+	en := newTestEniNode(t, &fakeENIEC2{}, &notified)
+
+	// requests made while one is pending don't block
+	en.RequestStatusRefresh()
+	en.RequestStatusRefresh()
+
+	if len(en.refresh) != 1 {
+		t.Errorf("expected refresh requests to be coalesced, got %v pending", len(en.refresh))
+	}
+}
@@ -0,0 +1,76 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package ec2provider
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/aws/aws-virtual-kubelet/internal/config"
+)
+
+// eventComponent is the source component of the events the provider emits
+const eventComponent = "aws-virtual-kubelet"
+
+// Pod event reasons (the kubelet's reasons are used where there's an equivalent)
+const (
+	eventReasonInstanceLaunched        = "InstanceLaunched"
+	eventReasonInstanceLaunchFailed    = "InstanceLaunchFailed"
+	eventReasonWarmPoolHit             = "WarmPoolHit"
+	eventReasonWarmPoolMiss            = "WarmPoolMiss"
+	eventReasonAgentConnected          = "AgentConnected"
+	eventReasonAgentUnreachable        = "AgentUnreachable"
+	eventReasonApplicationLaunched     = "Started"
+	eventReasonApplicationLaunchFailed = "Failed"
+	eventReasonProvisioningRetry       = "ProvisioningRetry"
+	eventReasonProvisioningFailed      = "ProvisioningFailed"
+	eventReasonUnhealthy               = "Unhealthy"
+	eventReasonBackOff                 = "BackOff"
+	eventReasonReplacingInstance       = "ReplacingInstance"
+	eventReasonCompleted               = "Completed"
+	eventReasonKilling                 = "Killing"
+	eventReasonGracePeriodExceeded     = "GracePeriodExceeded"
+	eventReasonInstanceTerminated      = "InstanceTerminated"
+	eventReasonInstanceRecycled        = "InstanceRecycled"
+	eventReasonSpotInterruption        = "SpotInterruption"
+	eventReasonRebalanceRecommendation = "RebalanceRecommendation"
+	eventReasonEvicted                 = "Evicted"
+	eventReasonEvictionFailed          = "EvictionFailed"
+)
+
+// newEventRecorder creates the provider's event recorder (or returns nil if events are disabled or there's no k8s
+//
+//	client to emit them with)
+func (p *Ec2Provider) newEventRecorder() record.EventRecorder {
+	cfg := config.Config().EventsConfig
+	if !cfg.Enabled || p.k8sClient == nil {
+		return nil
+	}
+
+	return p.k8sClient.NewEventRecorder(
+		corev1.EventSource{Component: eventComponent, Host: p.NodeName},
+		record.CorrelatorOptions{
+			BurstSize:            cfg.BurstSize,
+			QPS:                  cfg.QPS,
+			MaxEvents:            cfg.MaxSimilarEvents,
+			MaxIntervalInSeconds: cfg.AggregationIntervalSeconds,
+		},
+	)
+}
+
+// recordEvent emits an event on a pod (if events are enabled)
+func (p *Ec2Provider) recordEvent(pod *corev1.Pod, eventType string, reason string, messageFmt string,
+	args ...interface{}) {
+	if p.eventRecorder == nil {
+		return
+	}
+
+	p.eventRecorder.Eventf(pod, eventType, reason, messageFmt, args...)
+}
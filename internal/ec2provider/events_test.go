@@ -0,0 +1,120 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package ec2provider
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/aws/aws-virtual-kubelet/internal/config"
+	"github.com/aws/aws-virtual-kubelet/internal/k8sutils"
+)
+
+// fakeEventsK8sClient records the event recorders created (other K8SAPI methods aren't implemented)
+type fakeEventsK8sClient struct {
+	k8sutils.K8SAPI
+	sources []corev1.EventSource
+	options []record.CorrelatorOptions
+}
+
+func (f *fakeEventsK8sClient) NewEventRecorder(source corev1.EventSource,
+	options record.CorrelatorOptions) record.EventRecorder {
+	f.sources = append(f.sources, source)
+	f.options = append(f.options, options)
+	return record.NewFakeRecorder(10)
+}
+
+func TestNewEventRecorder(t *testing.T) {
+	tests := []struct {
+		name         string
+		disabled     bool
+		noK8sClient  bool
+		wantRecorder bool
+	}{
+		{
+			name:         "recorder is created with the configured rate limits",
+			wantRecorder: true,
+		},
+		{
+			name:     "no recorder is created if events are disabled",
+			disabled: true,
+		},
+		{
+			name:        "no recorder is created without a k8s client",
+			noK8sClient: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTestConfig(t, config.ProviderConfig{EventsConfig: config.EventsConfig{
+				BurstSize:                  5,
+				QPS:                        0.5,
+				MaxSimilarEvents:           3,
+				AggregationIntervalSeconds: 60,
+			}})
+			// NOTE events can't be disabled via the initial config (the default replaces false)
+			config.Config().EventsConfig.Enabled = !tt.disabled
+
+			k8sClient := &fakeEventsK8sClient{}
+			p := &Ec2Provider{NodeName: "fargate-10.0.0.1"}
+			if !tt.noK8sClient {
+				p.k8sClient = k8sClient
+			}
+
+			recorder := p.newEventRecorder()
+			if (recorder != nil) != tt.wantRecorder {
+				t.Fatalf("expected recorder %v, got %v", tt.wantRecorder, recorder)
+			}
+			if !tt.wantRecorder {
+				if len(k8sClient.sources) != 0 {
+					t.Error("expected no recorder to be created")
+				}
+				return
+			}
+
+			wantSource := corev1.EventSource{Component: eventComponent, Host: "fargate-10.0.0.1"}
+			if len(k8sClient.sources) != 1 || k8sClient.sources[0] != wantSource {
+				t.Errorf("expected a recorder for source %+v, got %+v", wantSource, k8sClient.sources)
+			}
+			if len(k8sClient.options) != 1 {
+				t.Fatalf("expected a single recorder, got %v", len(k8sClient.options))
+			}
+			if options := k8sClient.options[0]; options.BurstSize != 5 || options.QPS != 0.5 ||
+				options.MaxEvents != 3 || options.MaxIntervalInSeconds != 60 {
+				t.Errorf("expected the configured correlator options, got %+v", options)
+			}
+		})
+	}
+}
+
+func TestRecordEvent(t *testing.T) {
+	pod := &corev1.Pod{}
+
+	// events aren't recorded (and don't fail) without a recorder
+	p := &Ec2Provider{}
+	p.recordEvent(pod, corev1.EventTypeNormal, eventReasonKilling, "Stopping application (grace period %ds)", 30)
+
+	recorder := record.NewFakeRecorder(10)
+	p.eventRecorder = recorder
+	p.recordEvent(pod, corev1.EventTypeWarning, eventReasonEvicted, "Evicted from instance %v",
+		"i-0123456789abcdef0")
+
+	want := "Warning Evicted Evicted from instance i-0123456789abcdef0"
+	select {
+	case event := <-recorder.Events:
+		if event != want {
+			t.Errorf("expected event %q, got %q", want, event)
+		}
+	default:
+		t.Errorf("expected event %q to be recorded", want)
+	}
+}
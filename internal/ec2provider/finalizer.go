@@ -0,0 +1,154 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package ec2provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/aws/aws-virtual-kubelet/internal/awsutils"
+	"github.com/aws/aws-virtual-kubelet/internal/config"
+	"github.com/aws/aws-virtual-kubelet/internal/metrics"
+	"github.com/aws/aws-virtual-kubelet/internal/utils"
+)
+
+// cleanupFinalizer keeps a pod in k8s until the provider has terminated its instance (or confirmed it doesn't exist)
+const cleanupFinalizer = "compute.amazonaws.com/ec2-cleanup"
+
+// hasCleanupFinalizer returns true if a pod has the cleanup finalizer
+func hasCleanupFinalizer(pod *corev1.Pod) bool {
+	for _, finalizer := range pod.Finalizers {
+		if finalizer == cleanupFinalizer {
+			return true
+		}
+	}
+
+	return false
+}
+
+// addCleanupFinalizer adds the cleanup finalizer to a pod (in k8s and the cache).  This must happen before an instance
+//
+//	is launched for the pod, so the instance is cleaned up even if the provider crashes before annotating the pod
+//	with it.
+func (p *Ec2Provider) addCleanupFinalizer(ctx context.Context, metaPod *MetaPod) error {
+	pod := metaPod.snapshot()
+	if !config.Config().FinalizerConfig.Enabled || p.k8sClient == nil || hasCleanupFinalizer(pod) {
+		return nil
+	}
+
+	if err := p.k8sClient.AddPodFinalizer(ctx, pod.Namespace, pod.Name, cleanupFinalizer); err != nil {
+		metrics.FinalizerErrors.Inc()
+		return err
+	}
+
+	metaPod.updatePod(func(pod *corev1.Pod) {
+		pod.Finalizers = append(pod.Finalizers, cleanupFinalizer)
+	})
+
+	return nil
+}
+
+// finalizePod terminates any instances still tagged for a deleted pod (e.g. one launched just before the provider
+//
+//	crashed, which the pod was never annotated with), then removes the cleanup finalizer so k8s can delete the pod.
+//	The finalizer is left in place if any instance can't be terminated (the reconciler tries again later).
+func (p *Ec2Provider) finalizePod(ctx context.Context, pod *corev1.Pod) error {
+	if p.k8sClient == nil || !hasCleanupFinalizer(pod) {
+		return nil
+	}
+
+	instances, err := awsutils.DescribePodUIDInstances(ctx, p.computeManager.ec2Client, config.Config().ClusterName,
+		string(pod.UID))
+	if err != nil {
+		metrics.FinalizerErrors.Inc()
+		return err
+	}
+
+	for _, instance := range instances {
+		instanceID := aws.ToString(instance.InstanceId)
+		klog.InfoS("Terminating instance of deleted pod", "pod", klog.KObj(pod), "instance", instanceID)
+		if _, err = awsutils.TerminateEC2(ctx, p.computeManager.ec2Client, instanceID); err != nil {
+			metrics.FinalizerErrors.Inc()
+			return err
+		}
+	}
+
+	if err = p.k8sClient.RemovePodFinalizer(ctx, pod.Namespace, pod.Name, cleanupFinalizer); err != nil {
+		metrics.FinalizerErrors.Inc()
+		return err
+	}
+
+	klog.InfoS("Removed cleanup finalizer from pod", "pod", klog.KObj(pod), "instancesTerminated", len(instances))
+
+	return nil
+}
+
+// reconcileFinalizers periodically finalizes deleted pods the provider no longer has (e.g. force deleted pods, or
+//
+//	pods deleted while the provider was down) until the context is cancelled
+func (p *Ec2Provider) reconcileFinalizers(ctx context.Context) {
+	cfg := config.Config().FinalizerConfig
+
+	klog.InfoS("Starting cleanup finalizer reconciler", "interval", cfg.ReconcileIntervalSeconds)
+
+	ticker := time.NewTicker(time.Duration(cfg.ReconcileIntervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			klog.Info("Cleanup finalizer reconciler stopped")
+			return
+		case <-ticker.C:
+			p.finalizeDeletedPods(ctx)
+		}
+	}
+}
+
+// finalizeDeletedPods finalizes the node's deleted pods that still have the cleanup finalizer but aren't cached (pods
+//
+//	the provider has are finalized by DeletePod)
+func (p *Ec2Provider) finalizeDeletedPods(ctx context.Context) {
+	// the cache isn't loaded until startup completes
+	if p.pods == nil {
+		return
+	}
+
+	podList, err := p.k8sClient.GetPods(ctx, p.NodeName)
+	if err != nil {
+		klog.ErrorS(err, "Unable to list pods to reconcile cleanup finalizers")
+		metrics.FinalizerErrors.Inc()
+		return
+	}
+
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.DeletionTimestamp == nil || !hasCleanupFinalizer(pod) {
+			continue
+		}
+
+		if metaPod := p.pods.Get(utils.GetPodCacheKey(pod.Namespace, pod.Name)); metaPod != nil &&
+			metaPod.snapshot().UID == pod.UID {
+			continue
+		}
+
+		klog.InfoS("Cleaning up deleted pod the provider didn't delete", "pod", klog.KObj(pod),
+			"deletionTimestamp", pod.DeletionTimestamp)
+		if err = p.finalizePod(ctx, pod); err != nil {
+			klog.ErrorS(err, "Unable to clean up deleted pod (will retry)", "pod", klog.KObj(pod))
+			continue
+		}
+		metrics.DeletedPodsFinalized.Inc()
+	}
+}
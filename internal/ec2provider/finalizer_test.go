@@ -0,0 +1,292 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package ec2provider
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/aws/aws-virtual-kubelet/internal/awsutils"
+	"github.com/aws/aws-virtual-kubelet/internal/config"
+	"github.com/aws/aws-virtual-kubelet/internal/k8sutils"
+)
+
+// fakeFinalizerK8sClient lists pods and records finalizer changes (other K8SAPI methods aren't implemented)
+type fakeFinalizerK8sClient struct {
+	k8sutils.K8SAPI
+	pods      []corev1.Pod
+	addErr    error
+	removeErr error
+	added     []string
+	removed   []string
+}
+
+func (f *fakeFinalizerK8sClient) GetPods(ctx context.Context, nodeName string) (*corev1.PodList, error) {
+	return &corev1.PodList{Items: f.pods}, nil
+}
+
+func (f *fakeFinalizerK8sClient) AddPodFinalizer(ctx context.Context, namespace string, podName string,
+	finalizer string) error {
+	if f.addErr != nil {
+		return f.addErr
+	}
+	f.added = append(f.added, podName)
+	return nil
+}
+
+func (f *fakeFinalizerK8sClient) RemovePodFinalizer(ctx context.Context, namespace string, podName string,
+	finalizer string) error {
+	if f.removeErr != nil {
+		return f.removeErr
+	}
+	f.removed = append(f.removed, podName)
+	return nil
+}
+
+// fakePodUIDEC2 describes instances by their pod UID tag
+type fakePodUIDEC2 struct {
+	*fakeEC2
+}
+
+func (f *fakePodUIDEC2) DescribeInstances(ctx context.Context, input *ec2.DescribeInstancesInput) (
+	*ec2.DescribeInstancesOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.describeErr != nil {
+		return nil, f.describeErr
+	}
+
+	var podUIDs []string
+	for _, filter := range input.Filters {
+		if aws.ToString(filter.Name) == "tag:"+awsutils.PodUIDTagKey {
+			podUIDs = filter.Values
+		}
+	}
+
+	reservation := ec2types.Reservation{}
+	for _, instance := range f.instances {
+		for _, tag := range instance.Tags {
+			if aws.ToString(tag.Key) != awsutils.PodUIDTagKey {
+				continue
+			}
+			for _, podUID := range podUIDs {
+				if aws.ToString(tag.Value) == podUID {
+					reservation.Instances = append(reservation.Instances, instance)
+				}
+			}
+		}
+	}
+	return &ec2.DescribeInstancesOutput{Reservations: []ec2types.Reservation{reservation}}, nil
+}
+
+// podUIDInstance returns an instance tagged with a pod's UID
+func podUIDInstance(instanceID string, podUID string) ec2types.Instance {
+	return ec2types.Instance{
+		InstanceId: aws.String(instanceID),
+		Tags:       []ec2types.Tag{{Key: aws.String(awsutils.PodUIDTagKey), Value: aws.String(podUID)}},
+	}
+}
+
+// deletedPod returns a deleted pod with the given UID (and the cleanup finalizer, if finalizer is true)
+func deletedPod(name string, uid types.UID, finalizer bool) corev1.Pod {
+	deletionTimestamp := metav1.Now()
+	pod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:              name,
+		Namespace:         "default",
+		UID:               uid,
+		DeletionTimestamp: &deletionTimestamp,
+	}}
+	if finalizer {
+		pod.Finalizers = []string{cleanupFinalizer}
+	}
+	return pod
+}
+
+func TestAddCleanupFinalizer(t *testing.T) {
+	tests := []struct {
+		name          string
+		disabled      bool
+		noK8sClient   bool
+		hasFinalizer  bool
+		addErr        error
+		wantErr       bool
+		wantAdded     bool
+		wantFinalizer bool
+	}{
+		{
+			name:          "finalizer is added to the pod",
+			wantAdded:     true,
+			wantFinalizer: true,
+		},
+		{
+			name:          "finalizer isn't added again",
+			hasFinalizer:  true,
+			wantFinalizer: true,
+		},
+		{
+			name:     "finalizer isn't added if disabled",
+			disabled: true,
+		},
+		{
+			name:        "finalizer isn't added without a k8s client",
+			noK8sClient: true,
+		},
+		{
+			name:    "failure to add the finalizer is returned",
+			addErr:  errors.New("update failed"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTestConfig(t, config.ProviderConfig{})
+			// NOTE the finalizer can't be disabled via the initial config (the default replaces false)
+			config.Config().FinalizerConfig.Enabled = !tt.disabled
+
+			k8sClient := &fakeFinalizerK8sClient{addErr: tt.addErr}
+			p := newTestProvider(&fakeEC2{})
+			if !tt.noK8sClient {
+				p.k8sClient = k8sClient
+			}
+			metaPod := cacheTestPod(t, p, corev1.PodPending, "")
+			if tt.hasFinalizer {
+				metaPod.updatePod(func(pod *corev1.Pod) {
+					pod.Finalizers = []string{cleanupFinalizer}
+				})
+			}
+
+			err := p.addCleanupFinalizer(context.Background(), metaPod)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("addCleanupFinalizer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if added := len(k8sClient.added) == 1; added != tt.wantAdded {
+				t.Errorf("expected the finalizer to be added in k8s %v, got %v", tt.wantAdded, k8sClient.added)
+			}
+			pod := metaPod.snapshot()
+			if hasCleanupFinalizer(pod) != tt.wantFinalizer {
+				t.Errorf("expected the cached pod to have the finalizer %v, got %v", tt.wantFinalizer, pod.Finalizers)
+			}
+			if len(pod.Finalizers) > 1 {
+				t.Errorf("expected the finalizer to be added once, got %v", pod.Finalizers)
+			}
+		})
+	}
+}
+
+func TestFinalizePod(t *testing.T) {
+	tests := []struct {
+		name         string
+		noFinalizer  bool
+		describeErr  error
+		terminateErr error
+		removeErr    error
+		wantErr      bool
+		// wantTerminated are the instances terminated
+		wantTerminated []string
+		wantRemoved    bool
+	}{
+		{
+			name:           "pod's instances are terminated and the finalizer removed",
+			wantTerminated: []string{"i-0123456789abcdef0"},
+			wantRemoved:    true,
+		},
+		{
+			name:        "pod without the finalizer is left alone",
+			noFinalizer: true,
+		},
+		{
+			name:        "finalizer is kept if the pod's instances can't be found",
+			describeErr: errors.New("DescribeInstances failed"),
+			wantErr:     true,
+		},
+		{
+			name:         "finalizer is kept if the pod's instances can't be terminated",
+			terminateErr: errors.New("TerminateInstances failed"),
+			wantErr:      true,
+		},
+		{
+			name:           "failure to remove the finalizer is returned",
+			removeErr:      errors.New("update failed"),
+			wantErr:        true,
+			wantTerminated: []string{"i-0123456789abcdef0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTestConfig(t, config.ProviderConfig{})
+
+			ec2Client := &fakePodUIDEC2{fakeEC2: &fakeEC2{
+				instances: []ec2types.Instance{
+					podUIDInstance("i-0123456789abcdef0", "uid"),
+					podUIDInstance("i-0fedcba9876543210", "other-uid"),
+				},
+				describeErr:  tt.describeErr,
+				terminateErr: tt.terminateErr,
+			}}
+			k8sClient := &fakeFinalizerK8sClient{removeErr: tt.removeErr}
+			p := newTestProvider(ec2Client)
+			p.k8sClient = k8sClient
+
+			pod := deletedPod("pod", "uid", !tt.noFinalizer)
+
+			err := p.finalizePod(context.Background(), &pod)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("finalizePod() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if terminated := ec2Client.terminatedInstances(); !reflect.DeepEqual(terminated, tt.wantTerminated) {
+				t.Errorf("expected instances %v to be terminated, got %v", tt.wantTerminated, terminated)
+			}
+			if removed := len(k8sClient.removed) == 1; removed != tt.wantRemoved {
+				t.Errorf("expected the finalizer to be removed %v, got %v", tt.wantRemoved, k8sClient.removed)
+			}
+		})
+	}
+}
+
+func TestFinalizeDeletedPods(t *testing.T) {
+	initTestConfig(t, config.ProviderConfig{})
+
+	ec2Client := &fakePodUIDEC2{fakeEC2: &fakeEC2{}}
+	p := newTestProvider(ec2Client)
+	// the cached pod (default/pod with UID "uid") is finalized by DeletePod
+	cacheTestPod(t, p, corev1.PodRunning, "i-0123456789abcdef0")
+
+	running := deletedPod("running", "running-uid", true)
+	running.DeletionTimestamp = nil
+
+	k8sClient := &fakeFinalizerK8sClient{pods: []corev1.Pod{
+		deletedPod("pod", "uid", true),
+		deletedPod("force-deleted", "force-deleted-uid", true),
+		deletedPod("pod", "recreated-uid", true),
+		deletedPod("unfinalized", "unfinalized-uid", false),
+		running,
+	}}
+	p.k8sClient = k8sClient
+
+	p.finalizeDeletedPods(context.Background())
+
+	// NOTE the recreated pod has the cached pod's name, but is a different pod
+	want := []string{"force-deleted", "pod"}
+	if !reflect.DeepEqual(k8sClient.removed, want) {
+		t.Errorf("expected deleted pods %v to be finalized, got %v", want, k8sClient.removed)
+	}
+}
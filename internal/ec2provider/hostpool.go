@@ -0,0 +1,447 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package ec2provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"go.opentelemetry.io/otel/attribute"
+	"k8s.io/klog/v2"
+
+	"github.com/aws/aws-virtual-kubelet/internal/awsutils"
+	"github.com/aws/aws-virtual-kubelet/internal/config"
+	"github.com/aws/aws-virtual-kubelet/internal/metrics"
+	"github.com/aws/aws-virtual-kubelet/internal/poderrors"
+	"github.com/aws/aws-virtual-kubelet/internal/tracing"
+)
+
+// Host states (as reported by the host pool's metrics)
+const (
+	hostStateInUse       = "in-use"
+	hostStateIdle        = "idle"
+	hostStateScrubbing   = "scrubbing"
+	hostStateAllocating  = "allocating"
+	hostStateUnavailable = "unavailable"
+)
+
+// hostLaunchGracePeriod is how long a host is considered in use after an instance is launched on it, since
+//
+//	DescribeHosts may not report the instance (or the host may not start scrubbing) right away
+const hostLaunchGracePeriod = 5 * time.Minute
+
+// hostAllocationPollInterval is how often a newly allocated host is checked until it's available
+const hostAllocationPollInterval = 10 * time.Second
+
+// hostPool allocates dedicated hosts for instance types that require one (e.g. EC2 Mac instances), reuses them for
+//
+//	later launches once EC2 has scrubbed them, and releases them once they have been idle for the configured window
+//	(and allocated for the minimum allocation period)
+type hostPool struct {
+	ec2Client awsutils.EC2API
+	nodeName  string
+
+	mu    sync.Mutex
+	hosts map[string]*poolHost
+	// allocating is the number of host allocations in progress (counted towards the maximum number of hosts)
+	allocating int
+}
+
+// poolHost is the host pool's view of a dedicated host
+type poolHost struct {
+	id               string
+	instanceType     string
+	availabilityZone string
+	allocationTime   time.Time
+	state            types.AllocationState
+	instances        []string
+	// reserved is set while an instance is being launched on (or the pool is releasing) the host
+	reserved bool
+	// used is set once the host has run an instance (so a pending host is being scrubbed rather than allocated)
+	used bool
+	// lastLaunch is when an instance was last launched on the host
+	lastLaunch time.Time
+	// idleSince is when the host was first found idle (zero if it isn't idle)
+	idleSince time.Time
+	// scrubbingSince is when the host was first found being scrubbed (zero if it isn't being scrubbed)
+	scrubbingSince time.Time
+}
+
+func newHostPool(ec2Client awsutils.EC2API, nodeName string) *hostPool {
+	return &hostPool{
+		ec2Client: ec2Client,
+		nodeName:  nodeName,
+		hosts:     map[string]*poolHost{},
+	}
+}
+
+// requiresHost returns true if the host pool is enabled and instances of instanceType are launched on its hosts
+func (hp *hostPool) requiresHost(instanceType string) bool {
+	cfg := config.Config().HostPoolConfig
+	if !cfg.Enabled {
+		return false
+	}
+
+	for _, hostInstanceType := range cfg.InstanceTypes {
+		if hostInstanceType == instanceType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// placement returns the RunInstances options that launch an instance of instanceType in subnetID on a host from the
+//
+//	pool (or no options if the instance type doesn't require a host).  The returned function must be called with the
+//	launched instance's ID (or an empty string if the launch failed) once the launch is complete.
+func (hp *hostPool) placement(ctx context.Context, instanceType string, subnetID string) (
+	[]awsutils.RunInstancesOption, func(instanceID string), error) {
+	if hp == nil || !hp.requiresHost(instanceType) {
+		return nil, func(string) {}, nil
+	}
+	if subnetID == "" {
+		// the host must be allocated in the instance's availability zone
+		return nil, nil, poderrors.Newf(poderrors.CategoryInvalidSpec,
+			"a subnet is required to launch instance type %v on a dedicated host", instanceType)
+	}
+
+	hostID, err := hp.acquire(ctx, instanceType, subnetID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return []awsutils.RunInstancesOption{awsutils.WithHostPlacement(hostID)},
+		func(instanceID string) { hp.launched(hostID, instanceID) }, nil
+}
+
+// acquire reserves an idle host for an instance of instanceType in the availability zone of subnetID, allocating a
+//
+//	new host if there's no idle one (and the pool isn't full)
+func (hp *hostPool) acquire(ctx context.Context, instanceType string, subnetID string) (hostID string, err error) {
+	ctx, span := tracing.Start(ctx, "HostPool.Acquire", attribute.String("instanceType", instanceType))
+	defer func() {
+		if hostID != "" {
+			span.SetAttributes(attribute.String("host", hostID))
+		}
+		tracing.End(span, err)
+	}()
+
+	if subnetID == "" {
+		return "", poderrors.Newf(poderrors.CategoryInvalidSpec,
+			"a subnet is required to launch instance type %v on a dedicated host", instanceType)
+	}
+
+	availabilityZone, err := awsutils.SubnetAvailabilityZone(ctx, hp.ec2Client, subnetID)
+	if err != nil {
+		return "", err
+	}
+
+	if err = hp.refresh(ctx); err != nil {
+		return "", err
+	}
+
+	cfg := config.Config().HostPoolConfig
+
+	hp.mu.Lock()
+	now := time.Now()
+	for _, host := range hp.hosts {
+		if host.instanceType == instanceType && host.availabilityZone == availabilityZone &&
+			host.status(now) == hostStateIdle {
+			host.reserved = true
+			host.idleSince = time.Time{}
+			hp.updateMetrics(now)
+			hp.mu.Unlock()
+
+			klog.InfoS("Reusing dedicated host", "host", host.id, "instanceType", instanceType)
+			return host.id, nil
+		}
+	}
+
+	if cfg.MaxHosts > 0 && len(hp.hosts)+hp.allocating >= cfg.MaxHosts {
+		hp.mu.Unlock()
+		return "", poderrors.Newf(poderrors.CategoryCapacity,
+			"no idle dedicated host for instance type %v in %v, and the host pool is full (%d hosts)",
+			instanceType, availabilityZone, cfg.MaxHosts)
+	}
+	hp.allocating++
+	hp.mu.Unlock()
+
+	hostID, err = awsutils.AllocatePoolHost(ctx, hp.ec2Client, availabilityZone, instanceType,
+		config.Config().ClusterName, hp.nodeName)
+
+	hp.mu.Lock()
+	hp.allocating--
+	if err == nil {
+		metrics.HostPoolHostsAllocated.Inc()
+		hp.hosts[hostID] = &poolHost{
+			id:               hostID,
+			instanceType:     instanceType,
+			availabilityZone: availabilityZone,
+			allocationTime:   time.Now(),
+			state:            types.AllocationStatePending,
+			reserved:         true,
+		}
+		hp.updateMetrics(time.Now())
+	}
+	hp.mu.Unlock()
+
+	if err != nil {
+		metrics.HostPoolErrors.Inc()
+		return "", fmt.Errorf("unable to allocate dedicated host: %w", err)
+	}
+
+	if err = hp.awaitAvailable(ctx, hostID); err != nil {
+		// the host stays in the pool, and can be used by a later launch once it's available
+		hp.launched(hostID, "")
+		return "", err
+	}
+
+	return hostID, nil
+}
+
+// awaitAvailable waits until a newly allocated host is available (or the allocation timeout expires)
+func (hp *hostPool) awaitAvailable(ctx context.Context, hostID string) error {
+	timeout := time.Duration(config.Config().HostPoolConfig.AllocationTimeoutSeconds) * time.Second
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(hostAllocationPollInterval)
+	defer ticker.Stop()
+
+	for {
+		resp, err := hp.ec2Client.DescribeHosts(ctx, &ec2.DescribeHostsInput{HostIds: []string{hostID}})
+		if err == nil && len(resp.Hosts) == 1 {
+			hp.mu.Lock()
+			if host, ok := hp.hosts[hostID]; ok {
+				host.state = resp.Hosts[0].State
+			}
+			hp.mu.Unlock()
+
+			if resp.Hosts[0].State == types.AllocationStateAvailable {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("dedicated host %v did not become available within %v: %w", hostID, timeout, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// launched ends a host's reservation once the launch it was reserved for is complete (with the launched instance's
+//
+//	ID, or an empty string if the launch failed)
+func (hp *hostPool) launched(hostID string, instanceID string) {
+	hp.mu.Lock()
+	defer hp.mu.Unlock()
+
+	host, ok := hp.hosts[hostID]
+	if !ok {
+		return
+	}
+
+	host.reserved = false
+	if instanceID != "" {
+		host.instances = []string{instanceID}
+		host.used = true
+		host.lastLaunch = time.Now()
+	}
+	hp.updateMetrics(time.Now())
+}
+
+// run refreshes host states and releases idle hosts every interval until the context is cancelled
+func (hp *hostPool) run(ctx context.Context) {
+	cfg := config.Config().HostPoolConfig
+
+	klog.InfoS("Starting dedicated host pool", "instanceTypes", cfg.InstanceTypes, "maxHosts", cfg.MaxHosts,
+		"interval", cfg.ReconcileIntervalSeconds, "idleRelease", cfg.IdleReleaseMinutes)
+
+	ticker := time.NewTicker(time.Duration(cfg.ReconcileIntervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		hp.reconcile(ctx)
+
+		select {
+		case <-ctx.Done():
+			klog.Info("Dedicated host pool stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reconcile refreshes host states and releases hosts that have been idle past the release window (once they have
+//
+//	been allocated for the minimum allocation period)
+func (hp *hostPool) reconcile(ctx context.Context) {
+	if err := hp.refresh(ctx); err != nil {
+		klog.ErrorS(err, "Unable to refresh dedicated host states")
+		return
+	}
+
+	cfg := config.Config().HostPoolConfig
+	minimumAllocation := time.Duration(cfg.MinimumAllocationHours) * time.Hour
+	idleRelease := time.Duration(cfg.IdleReleaseMinutes) * time.Minute
+
+	hp.mu.Lock()
+	now := time.Now()
+	var releasable []*poolHost
+	for _, host := range hp.hosts {
+		if host.status(now) == hostStateIdle && now.Sub(host.allocationTime) >= minimumAllocation &&
+			!host.idleSince.IsZero() && now.Sub(host.idleSince) >= idleRelease {
+			// reserve the host so it isn't acquired while it's being released
+			host.reserved = true
+			releasable = append(releasable, host)
+		}
+	}
+	hp.mu.Unlock()
+
+	for _, host := range releasable {
+		klog.InfoS("Releasing idle dedicated host", "host", host.id, "allocated", host.allocationTime,
+			"idleSince", host.idleSince)
+
+		err := awsutils.ReleaseHost(ctx, hp.ec2Client, host.id)
+
+		hp.mu.Lock()
+		if err != nil {
+			klog.ErrorS(err, "Unable to release dedicated host", "host", host.id)
+			metrics.HostPoolErrors.Inc()
+			host.reserved = false
+		} else {
+			metrics.HostPoolHostsReleased.Inc()
+			delete(hp.hosts, host.id)
+		}
+		hp.updateMetrics(time.Now())
+		hp.mu.Unlock()
+	}
+}
+
+// refresh updates the pool's hosts from EC2 (including hosts allocated before the provider started)
+func (hp *hostPool) refresh(ctx context.Context) error {
+	hosts, err := awsutils.DescribePoolHosts(ctx, hp.ec2Client, config.Config().ClusterName, hp.nodeName)
+	if err != nil {
+		metrics.HostPoolErrors.Inc()
+		return fmt.Errorf("unable to describe dedicated hosts: %w", err)
+	}
+
+	hp.mu.Lock()
+	defer hp.mu.Unlock()
+
+	now := time.Now()
+	found := map[string]bool{}
+	for _, h := range hosts {
+		hostID := aws.ToString(h.HostId)
+		found[hostID] = true
+
+		host, ok := hp.hosts[hostID]
+		if !ok {
+			host = &poolHost{id: hostID, availabilityZone: aws.ToString(h.AvailabilityZone)}
+			if h.HostProperties != nil {
+				host.instanceType = aws.ToString(h.HostProperties.InstanceType)
+			}
+			hp.hosts[hostID] = host
+		}
+
+		host.state = h.State
+		host.allocationTime = aws.ToTime(h.AllocationTime)
+		host.instances = nil
+		for _, instance := range h.Instances {
+			host.instances = append(host.instances, aws.ToString(instance.InstanceId))
+		}
+		if len(host.instances) > 0 {
+			host.used = true
+		}
+
+		host.trackStatus(now)
+	}
+
+	// forget hosts that were released (keeping reserved hosts, which DescribeHosts may not report yet)
+	for hostID, host := range hp.hosts {
+		if !found[hostID] && !host.reserved {
+			delete(hp.hosts, hostID)
+		}
+	}
+
+	hp.updateMetrics(now)
+
+	return nil
+}
+
+// status returns the host's state (see the host state constants)
+func (h *poolHost) status(now time.Time) string {
+	switch {
+	case h.reserved || len(h.instances) > 0 || now.Sub(h.lastLaunch) < hostLaunchGracePeriod:
+		return hostStateInUse
+	case h.state == types.AllocationStateAvailable:
+		return hostStateIdle
+	case h.state == types.AllocationStatePending && (h.used || now.Sub(h.allocationTime) >
+		time.Duration(config.Config().HostPoolConfig.AllocationTimeoutSeconds)*time.Second):
+		// hosts are pending while EC2 scrubs them after an instance stops (a newly allocated host is pending too,
+		//	but only until the allocation timeout)
+		return hostStateScrubbing
+	case h.state == types.AllocationStatePending:
+		return hostStateAllocating
+	default:
+		return hostStateUnavailable
+	}
+}
+
+// trackStatus records when the host became idle or started being scrubbed
+func (h *poolHost) trackStatus(now time.Time) {
+	status := h.status(now)
+
+	if status == hostStateIdle {
+		if h.idleSince.IsZero() {
+			h.idleSince = now
+		}
+	} else {
+		h.idleSince = time.Time{}
+	}
+
+	if status == hostStateScrubbing {
+		if h.scrubbingSince.IsZero() {
+			klog.InfoS("Dedicated host is being scrubbed", "host", h.id)
+			h.scrubbingSince = now
+		}
+	} else if !h.scrubbingSince.IsZero() {
+		klog.InfoS("Dedicated host scrubbing finished", "host", h.id, "state", h.state,
+			"duration", now.Sub(h.scrubbingSince))
+		h.scrubbingSince = time.Time{}
+	}
+}
+
+// updateMetrics sets the host pool metrics (the caller must hold the pool's lock)
+func (hp *hostPool) updateMetrics(now time.Time) {
+	metrics.HostPoolHosts.Reset()
+
+	inUse := 0
+	for _, host := range hp.hosts {
+		status := host.status(now)
+		if status == hostStateInUse {
+			inUse++
+		}
+		metrics.HostPoolHosts.WithLabelValues(status, host.instanceType).Inc()
+	}
+
+	if len(hp.hosts) > 0 {
+		metrics.HostPoolUtilization.Set(float64(inUse) / float64(len(hp.hosts)))
+	} else {
+		metrics.HostPoolUtilization.Set(0)
+	}
+}
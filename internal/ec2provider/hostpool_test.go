@@ -0,0 +1,523 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package ec2provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"github.com/aws/aws-virtual-kubelet/internal/config"
+	"github.com/aws/aws-virtual-kubelet/internal/poderrors"
+)
+
+// fakeHostsEC2 is an EC2API that manages dedicated hosts in memory.  Subnets are in availability zone "az-" + the
+//
+//	subnet ID, and allocated hosts start in allocatedState (available if unset).
+type fakeHostsEC2 struct {
+	*fakeEC2
+	mu             sync.Mutex
+	hosts          []types.Host
+	allocatedState types.AllocationState
+	allocateErr    error
+	releaseErr     error
+	describeErr    error
+	allocated      []string
+	released       []string
+}
+
+func (f *fakeHostsEC2) DescribeSubnets(ctx context.Context, input *ec2.DescribeSubnetsInput) (
+	*ec2.DescribeSubnetsOutput, error) {
+	var subnets []types.Subnet
+	for _, subnetID := range input.SubnetIds {
+		subnets = append(subnets, types.Subnet{
+			SubnetId:         aws.String(subnetID),
+			AvailabilityZone: aws.String("az-" + subnetID),
+		})
+	}
+	return &ec2.DescribeSubnetsOutput{Subnets: subnets}, nil
+}
+
+func (f *fakeHostsEC2) DescribeHosts(ctx context.Context, input *ec2.DescribeHostsInput) (
+	*ec2.DescribeHostsOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.describeErr != nil {
+		return nil, f.describeErr
+	}
+
+	output := &ec2.DescribeHostsOutput{}
+	for _, host := range f.hosts {
+		if len(input.HostIds) == 0 || aws.ToString(host.HostId) == input.HostIds[0] {
+			output.Hosts = append(output.Hosts, host)
+		}
+	}
+	return output, nil
+}
+
+func (f *fakeHostsEC2) AllocateHosts(ctx context.Context, input *ec2.AllocateHostsInput) (
+	*ec2.AllocateHostsOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.allocateErr != nil {
+		return nil, f.allocateErr
+	}
+
+	state := f.allocatedState
+	if state == "" {
+		state = types.AllocationStateAvailable
+	}
+
+	hostID := fmt.Sprintf("h-%017d", len(f.allocated)+1)
+	f.allocated = append(f.allocated, hostID)
+	f.hosts = append(f.hosts, types.Host{
+		HostId:           aws.String(hostID),
+		AvailabilityZone: input.AvailabilityZone,
+		HostProperties:   &types.HostProperties{InstanceType: input.InstanceType},
+		AllocationTime:   aws.Time(time.Now()),
+		State:            state,
+	})
+	return &ec2.AllocateHostsOutput{HostIds: []string{hostID}}, nil
+}
+
+func (f *fakeHostsEC2) ReleaseHosts(ctx context.Context, input *ec2.ReleaseHostsInput) (
+	*ec2.ReleaseHostsOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.releaseErr != nil {
+		return nil, f.releaseErr
+	}
+
+	f.released = append(f.released, input.HostIds...)
+	var hosts []types.Host
+	for _, host := range f.hosts {
+		if aws.ToString(host.HostId) != input.HostIds[0] {
+			hosts = append(hosts, host)
+		}
+	}
+	f.hosts = hosts
+	return &ec2.ReleaseHostsOutput{Successful: input.HostIds}, nil
+}
+
+// testHost returns a dedicated host allocated age ago (running the given instances)
+func testHost(hostID string, instanceType string, availabilityZone string, state types.AllocationState,
+	age time.Duration, instances ...string) types.Host {
+	host := types.Host{
+		HostId:           aws.String(hostID),
+		AvailabilityZone: aws.String(availabilityZone),
+		HostProperties:   &types.HostProperties{InstanceType: aws.String(instanceType)},
+		AllocationTime:   aws.Time(time.Now().Add(-age)),
+		State:            state,
+	}
+	for _, instanceID := range instances {
+		host.Instances = append(host.Instances, types.HostInstance{InstanceId: aws.String(instanceID)})
+	}
+	return host
+}
+
+// poolHostIDs returns the (sorted) IDs of a host pool's hosts
+func poolHostIDs(hp *hostPool) []string {
+	hp.mu.Lock()
+	defer hp.mu.Unlock()
+
+	var hostIDs []string
+	for hostID := range hp.hosts {
+		hostIDs = append(hostIDs, hostID)
+	}
+	sort.Strings(hostIDs)
+	return hostIDs
+}
+
+func TestHostPoolAcquire(t *testing.T) {
+	tests := []struct {
+		name           string
+		maxHosts       int
+		hosts          []types.Host
+		allocatedState types.AllocationState
+		allocateErr    error
+		describeErr    error
+		subnetID       string
+		wantHost       string
+		wantAllocated  int
+		wantErr        bool
+		wantCategory   poderrors.Category
+	}{
+		{
+			name:          "allocates a host when there's no idle host",
+			subnetID:      "subnet-a",
+			wantHost:      "h-00000000000000001",
+			wantAllocated: 1,
+		},
+		{
+			name:     "reuses an idle host",
+			subnetID: "subnet-a",
+			hosts: []types.Host{
+				testHost("h-idle", "mac1.metal", "az-subnet-a", types.AllocationStateAvailable, time.Hour),
+			},
+			wantHost: "h-idle",
+		},
+		{
+			name:     "doesn't reuse a host of another instance type, in another availability zone, or in use",
+			subnetID: "subnet-a",
+			hosts: []types.Host{
+				testHost("h-mac2", "mac2.metal", "az-subnet-a", types.AllocationStateAvailable, time.Hour),
+				testHost("h-other-az", "mac1.metal", "az-subnet-b", types.AllocationStateAvailable, time.Hour),
+				testHost("h-in-use", "mac1.metal", "az-subnet-a", types.AllocationStateAvailable, time.Hour,
+					"i-0123456789abcdef0"),
+				testHost("h-scrubbing", "mac1.metal", "az-subnet-a", types.AllocationStatePending, time.Hour),
+			},
+			wantHost:      "h-00000000000000001",
+			wantAllocated: 1,
+		},
+		{
+			name:     "full pool",
+			maxHosts: 1,
+			subnetID: "subnet-a",
+			hosts: []types.Host{
+				testHost("h-in-use", "mac1.metal", "az-subnet-a", types.AllocationStateAvailable, time.Hour,
+					"i-0123456789abcdef0"),
+			},
+			wantErr:      true,
+			wantCategory: poderrors.CategoryCapacity,
+		},
+		{
+			name:           "allocated host doesn't become available within the allocation timeout",
+			subnetID:       "subnet-a",
+			allocatedState: types.AllocationStatePending,
+			wantAllocated:  1,
+			wantErr:        true,
+		},
+		{
+			name:        "allocation fails",
+			subnetID:    "subnet-a",
+			allocateErr: errors.New("AllocateHosts failed"),
+			wantErr:     true,
+		},
+		{
+			name:        "hosts can't be described",
+			subnetID:    "subnet-a",
+			describeErr: errors.New("DescribeHosts failed"),
+			wantErr:     true,
+		},
+		{
+			name:         "subnet is required",
+			wantErr:      true,
+			wantCategory: poderrors.CategoryInvalidSpec,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTestConfig(t, config.ProviderConfig{HostPoolConfig: config.HostPoolConfig{
+				Enabled:                  true,
+				MaxHosts:                 tt.maxHosts,
+				AllocationTimeoutSeconds: 1,
+			}})
+
+			ec2Client := &fakeHostsEC2{
+				fakeEC2:        &fakeEC2{},
+				hosts:          tt.hosts,
+				allocatedState: tt.allocatedState,
+				allocateErr:    tt.allocateErr,
+				describeErr:    tt.describeErr,
+			}
+			hp := newHostPool(ec2Client, "node")
+
+			hostID, err := hp.acquire(context.Background(), "mac1.metal", tt.subnetID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("acquire() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && tt.wantCategory != "" && poderrors.Classify(err) != tt.wantCategory {
+				t.Errorf("acquire() error category = %v, want %v", poderrors.Classify(err), tt.wantCategory)
+			}
+			if hostID != tt.wantHost {
+				t.Errorf("acquire() = %v, want %v", hostID, tt.wantHost)
+			}
+			if len(ec2Client.allocated) != tt.wantAllocated {
+				t.Errorf("expected %d host allocation(s), got %v", tt.wantAllocated, ec2Client.allocated)
+			}
+
+			// an acquired host is reserved, and a host that didn't become available stays in the pool (unreserved)
+			for _, allocatedID := range ec2Client.allocated {
+				hp.mu.Lock()
+				host, ok := hp.hosts[allocatedID]
+				if !ok {
+					t.Errorf("expected allocated host %v to be in the pool", allocatedID)
+				} else if host.reserved != (allocatedID == hostID) {
+					t.Errorf("expected allocated host %v reserved = %v, got %v", allocatedID, allocatedID == hostID,
+						host.reserved)
+				}
+				hp.mu.Unlock()
+			}
+		})
+	}
+}
+
+func TestHostPoolLaunched(t *testing.T) {
+	tests := []struct {
+		name       string
+		instanceID string
+		wantStatus string
+		// wantReused is whether the host is acquired again after the launch
+		wantReused bool
+	}{
+		{
+			name:       "launched instance keeps the host in use",
+			instanceID: "i-0123456789abcdef0",
+			wantStatus: hostStateInUse,
+		},
+		{
+			name:       "failed launch makes the host idle again",
+			wantStatus: hostStateIdle,
+			wantReused: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTestConfig(t, config.ProviderConfig{HostPoolConfig: config.HostPoolConfig{Enabled: true}})
+
+			ec2Client := &fakeHostsEC2{fakeEC2: &fakeEC2{}}
+			hp := newHostPool(ec2Client, "node")
+
+			options, launched, err := hp.placement(context.Background(), "mac1.metal", "subnet-a")
+			if err != nil {
+				t.Fatalf("placement: unexpected error %v", err)
+			}
+			if len(options) != 1 {
+				t.Fatalf("expected a host placement option, got %d options", len(options))
+			}
+			hostID := ec2Client.allocated[0]
+
+			launched(tt.instanceID)
+
+			hp.mu.Lock()
+			status := hp.hosts[hostID].status(time.Now())
+			hp.mu.Unlock()
+			if status != tt.wantStatus {
+				t.Errorf("expected host status %v after the launch, got %v", tt.wantStatus, status)
+			}
+
+			reusedID, err := hp.acquire(context.Background(), "mac1.metal", "subnet-a")
+			if err != nil {
+				t.Fatalf("acquire: unexpected error %v", err)
+			}
+			if (reusedID == hostID) != tt.wantReused {
+				t.Errorf("expected host reused = %v, got host %v (launched on %v)", tt.wantReused, reusedID, hostID)
+			}
+		})
+	}
+}
+
+func TestHostPoolPlacementNotRequired(t *testing.T) {
+	initTestConfig(t, config.ProviderConfig{HostPoolConfig: config.HostPoolConfig{Enabled: true}})
+
+	ec2Client := &fakeHostsEC2{fakeEC2: &fakeEC2{}}
+	hp := newHostPool(ec2Client, "node")
+
+	options, launched, err := hp.placement(context.Background(), "t3.large", "subnet-a")
+	if err != nil {
+		t.Fatalf("placement: unexpected error %v", err)
+	}
+	launched("i-0123456789abcdef0")
+
+	if len(options) != 0 || len(ec2Client.allocated) != 0 {
+		t.Errorf("expected no host for an instance type that doesn't require one, got %d options and %v allocated",
+			len(options), ec2Client.allocated)
+	}
+}
+
+func TestHostPoolReconcile(t *testing.T) {
+	tests := []struct {
+		name  string
+		hosts []types.Host
+		// idleSince is when the pool first found each host idle (the pool doesn't know hosts not listed)
+		idleSince map[string]time.Duration
+		// reserved are hosts reserved by the pool (e.g. for a launch)
+		reserved     []string
+		releaseErr   error
+		describeErr  error
+		wantReleased []string
+		wantHosts    []string
+	}{
+		{
+			name: "releases hosts idle past the release window",
+			hosts: []types.Host{
+				testHost("h-idle", "mac1.metal", "az-a", types.AllocationStateAvailable, 48*time.Hour),
+			},
+			idleSince:    map[string]time.Duration{"h-idle": 2 * time.Hour},
+			wantReleased: []string{"h-idle"},
+		},
+		{
+			name: "keeps hosts idle within the release window",
+			hosts: []types.Host{
+				testHost("h-idle", "mac1.metal", "az-a", types.AllocationStateAvailable, 48*time.Hour),
+			},
+			idleSince: map[string]time.Duration{"h-idle": 30 * time.Minute},
+			wantHosts: []string{"h-idle"},
+		},
+		{
+			name: "keeps newly found idle hosts (their idle time starts now)",
+			hosts: []types.Host{
+				testHost("h-idle", "mac1.metal", "az-a", types.AllocationStateAvailable, 48*time.Hour),
+			},
+			wantHosts: []string{"h-idle"},
+		},
+		{
+			name: "keeps idle hosts within the minimum allocation period",
+			hosts: []types.Host{
+				testHost("h-idle", "mac1.metal", "az-a", types.AllocationStateAvailable, 12*time.Hour),
+			},
+			idleSince: map[string]time.Duration{"h-idle": 2 * time.Hour},
+			wantHosts: []string{"h-idle"},
+		},
+		{
+			name: "keeps hosts in use, being scrubbed, or reserved",
+			hosts: []types.Host{
+				testHost("h-in-use", "mac1.metal", "az-a", types.AllocationStateAvailable, 48*time.Hour,
+					"i-0123456789abcdef0"),
+				testHost("h-reserved", "mac1.metal", "az-a", types.AllocationStateAvailable, 48*time.Hour),
+				testHost("h-scrubbing", "mac1.metal", "az-a", types.AllocationStatePending, 48*time.Hour),
+			},
+			idleSince: map[string]time.Duration{
+				"h-in-use":    2 * time.Hour,
+				"h-reserved":  2 * time.Hour,
+				"h-scrubbing": 2 * time.Hour,
+			},
+			reserved:  []string{"h-reserved"},
+			wantHosts: []string{"h-in-use", "h-reserved", "h-scrubbing"},
+		},
+		{
+			name: "keeps hosts that can't be released",
+			hosts: []types.Host{
+				testHost("h-idle", "mac1.metal", "az-a", types.AllocationStateAvailable, 48*time.Hour),
+			},
+			idleSince:  map[string]time.Duration{"h-idle": 2 * time.Hour},
+			releaseErr: errors.New("ReleaseHosts failed"),
+			wantHosts:  []string{"h-idle"},
+		},
+		{
+			name:      "forgets hosts that were released elsewhere (but not reserved hosts)",
+			idleSince: map[string]time.Duration{"h-gone": 2 * time.Hour, "h-reserved": 0},
+			reserved:  []string{"h-reserved"},
+			wantHosts: []string{"h-reserved"},
+		},
+		{
+			name: "releases nothing if hosts can't be described",
+			hosts: []types.Host{
+				testHost("h-idle", "mac1.metal", "az-a", types.AllocationStateAvailable, 48*time.Hour),
+			},
+			idleSince:   map[string]time.Duration{"h-idle": 2 * time.Hour},
+			describeErr: errors.New("DescribeHosts failed"),
+			wantHosts:   []string{"h-idle"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTestConfig(t, config.ProviderConfig{HostPoolConfig: config.HostPoolConfig{Enabled: true}})
+
+			ec2Client := &fakeHostsEC2{
+				fakeEC2:     &fakeEC2{},
+				hosts:       tt.hosts,
+				releaseErr:  tt.releaseErr,
+				describeErr: tt.describeErr,
+			}
+			hp := newHostPool(ec2Client, "node")
+
+			now := time.Now()
+			for hostID, idle := range tt.idleSince {
+				hp.hosts[hostID] = &poolHost{
+					id:               hostID,
+					instanceType:     "mac1.metal",
+					availabilityZone: "az-a",
+					allocationTime:   now.Add(-48 * time.Hour),
+					state:            types.AllocationStateAvailable,
+					idleSince:        now.Add(-idle),
+				}
+			}
+			for _, hostID := range tt.reserved {
+				hp.hosts[hostID].reserved = true
+			}
+
+			hp.reconcile(context.Background())
+
+			if !reflect.DeepEqual(ec2Client.released, tt.wantReleased) {
+				t.Errorf("expected hosts %v to be released, got %v", tt.wantReleased, ec2Client.released)
+			}
+			if hostIDs := poolHostIDs(hp); !reflect.DeepEqual(hostIDs, tt.wantHosts) {
+				t.Errorf("expected pool hosts %v, got %v", tt.wantHosts, hostIDs)
+			}
+
+			// hosts the pool failed to release can be acquired again
+			hp.mu.Lock()
+			for _, host := range hp.hosts {
+				wantReserved := false
+				for _, hostID := range tt.reserved {
+					wantReserved = wantReserved || host.id == hostID
+				}
+				if host.reserved != wantReserved {
+					t.Errorf("expected host %v reserved = %v, got %v", host.id, wantReserved, host.reserved)
+				}
+			}
+			hp.mu.Unlock()
+		})
+	}
+}
+
+func TestHostPoolRefresh(t *testing.T) {
+	initTestConfig(t, config.ProviderConfig{HostPoolConfig: config.HostPoolConfig{Enabled: true}})
+
+	ec2Client := &fakeHostsEC2{
+		fakeEC2: &fakeEC2{},
+		hosts: []types.Host{
+			testHost("h-adopted", "mac2.metal", "az-a", types.AllocationStateAvailable, time.Hour,
+				"i-0123456789abcdef0"),
+			testHost("h-scrubbing", "mac1.metal", "az-a", types.AllocationStatePending, time.Hour),
+		},
+	}
+	hp := newHostPool(ec2Client, "node")
+	hp.hosts["h-scrubbing"] = &poolHost{id: "h-scrubbing", instanceType: "mac1.metal", used: true}
+
+	if err := hp.refresh(context.Background()); err != nil {
+		t.Fatalf("refresh: unexpected error %v", err)
+	}
+
+	// hosts allocated before the provider started are adopted
+	hp.mu.Lock()
+	defer hp.mu.Unlock()
+	adopted, ok := hp.hosts["h-adopted"]
+	if !ok {
+		t.Fatal("expected a host allocated by an earlier provider to be adopted")
+	}
+	if adopted.instanceType != "mac2.metal" || adopted.availabilityZone != "az-a" ||
+		!reflect.DeepEqual(adopted.instances, []string{"i-0123456789abcdef0"}) || !adopted.used {
+		t.Errorf("unexpected adopted host %+v", adopted)
+	}
+
+	now := time.Now()
+	if status := adopted.status(now); status != hostStateInUse {
+		t.Errorf("expected adopted host status %v, got %v", hostStateInUse, status)
+	}
+	scrubbing := hp.hosts["h-scrubbing"]
+	if status := scrubbing.status(now); status != hostStateScrubbing || scrubbing.scrubbingSince.IsZero() {
+		t.Errorf("expected a used, pending host to be scrubbing, got %v (since %v)", status,
+			scrubbing.scrubbingSince)
+	}
+}
@@ -0,0 +1,192 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package ec2provider
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/aws/aws-virtual-kubelet/internal/config"
+	"github.com/aws/aws-virtual-kubelet/internal/metrics"
+	vkvmagent_v0 "github.com/aws/aws-virtual-kubelet/proto/vkvmagent/v0"
+)
+
+// PodConditionDisruptionTarget reports that a pod is about to be disrupted (evicted) because its instance is being
+//
+//	interrupted (the same condition the Kubernetes control plane sets on pods it disrupts)
+const PodConditionDisruptionTarget corev1.PodConditionType = "DisruptionTarget"
+
+// Disruption condition reasons
+const (
+	disruptionReasonSpotInterruption        = "SpotInterruption"
+	disruptionReasonRebalanceRecommendation = "RebalanceRecommendation"
+)
+
+// instanceEventsRetryInterval is how long to wait before reconnecting a pod's instance event stream
+const instanceEventsRetryInterval = 30 * time.Second
+
+// watchInstanceEvents streams instance events (e.g. spot interruption notices) from the pod's VKVMAgent until the
+//
+//	pod's context is cancelled, reconnecting if the stream ends (e.g. while the pod's compute is being replaced).  A
+//	VKVMAgent that doesn't implement instance events isn't watched.
+func (p *Ec2Provider) watchInstanceEvents(metaPod *MetaPod) {
+	ctx := metaPod.ctx
+
+	for {
+		pod := metaPod.snapshot()
+		if pod.Status.PodIP != "" && !podCompleted(pod) {
+			err := p.newPodClient(pod).WatchInstanceEvents(ctx,
+				func(event *vkvmagent_v0.InstanceEvent) { p.handleInstanceEvent(metaPod, event) })
+
+			if ctx.Err() != nil {
+				return
+			}
+			if status.Code(err) == codes.Unimplemented {
+				klog.V(1).InfoS("VKVMAgent doesn't report instance events", "pod", klog.KObj(pod))
+				return
+			}
+			klog.ErrorS(err, "Instance event stream ended, reconnecting", "pod", klog.KObj(pod),
+				"retryInterval", instanceEventsRetryInterval)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(instanceEventsRetryInterval):
+		}
+	}
+}
+
+// handleInstanceEvent records an instance event and evicts the pod if its instance is being interrupted (or, if
+//
+//	configured, has received a rebalance recommendation)
+func (p *Ec2Provider) handleInstanceEvent(metaPod *MetaPod, event *vkvmagent_v0.InstanceEvent) {
+	pod := metaPod.snapshot()
+	instanceID := pod.Annotations["compute.amazonaws.com/instance-id"]
+
+	// NOTE a missing time would otherwise convert to the Unix epoch (i.e. an interruption that's already overdue)
+	var eventTime time.Time
+	if event.Time != nil {
+		eventTime = event.Time.AsTime()
+	}
+
+	klog.InfoS("Received instance event", "pod", klog.KObj(pod), "instance", instanceID, "type", event.Type,
+		"time", eventTime, "action", event.Action)
+	metrics.InstanceEvents.WithLabelValues(event.Type.String()).Inc()
+
+	switch event.Type {
+	case vkvmagent_v0.InstanceEvent_SPOT_INTERRUPTION:
+		message := fmt.Sprintf("Spot instance %v will be interrupted (%v)", instanceID, event.Action)
+		if !eventTime.IsZero() {
+			message = fmt.Sprintf("%v at %v", message, eventTime.Format(time.RFC3339))
+		}
+		p.recordEvent(pod, corev1.EventTypeWarning, eventReasonSpotInterruption, "%v", message)
+		p.evictDisruptedPod(metaPod, disruptionReasonSpotInterruption, message, eventTime)
+	case vkvmagent_v0.InstanceEvent_REBALANCE_RECOMMENDATION:
+		message := fmt.Sprintf("Spot instance %v is at elevated risk of interruption", instanceID)
+		p.recordEvent(pod, corev1.EventTypeWarning, eventReasonRebalanceRecommendation, "%v", message)
+		if config.Config().SpotConfig.EvictOnRebalanceRecommendation {
+			p.evictDisruptedPod(metaPod, disruptionReasonRebalanceRecommendation, message, time.Time{})
+		}
+	default:
+		klog.InfoS("Ignoring unknown instance event", "pod", klog.KObj(pod), "type", event.Type)
+	}
+}
+
+// evictDisruptedPod marks a pod with the DisruptionTarget condition and evicts it (once), shortening its grace period
+//
+//	so its application stops before the interruption (if the interruption time is known).  A spot interruption can't
+//	be postponed, so if the eviction is refused (e.g. by a PodDisruptionBudget) the pod is deleted instead.  If
+//	neither succeeds the condition is cleared, so a later event tries again.
+func (p *Ec2Provider) evictDisruptedPod(metaPod *MetaPod, reason string, message string, interruption time.Time) {
+	var pod *corev1.Pod
+	evicted := false
+	metaPod.updatePod(func(cached *corev1.Pod) {
+		if metaPod.evicted {
+			evicted = true
+			return
+		}
+
+		setPodCondition(cached, PodConditionDisruptionTarget, corev1.ConditionTrue, reason, message)
+		pod = cached.DeepCopy()
+	})
+	if evicted {
+		klog.InfoS("Pod is already being evicted", "pod", klog.KObj(metaPod.snapshot()))
+		return
+	}
+	p.notifyPod(metaPod)
+
+	gracePeriod := terminationGracePeriod(pod)
+	if !interruption.IsZero() {
+		margin := time.Duration(config.Config().SpotConfig.EvictionMarginSeconds) * time.Second
+		remaining := int64((time.Until(interruption) - margin) / time.Second)
+		if remaining < 0 {
+			remaining = 0
+		}
+		if remaining < gracePeriod {
+			gracePeriod = remaining
+		}
+	}
+
+	klog.InfoS("Evicting pod", "pod", klog.KObj(pod), "reason", reason, "gracePeriod", gracePeriod)
+
+	err := p.evictPod(metaPod, gracePeriod)
+	if err != nil && reason == disruptionReasonSpotInterruption && p.k8sClient != nil {
+		klog.ErrorS(err, "Unable to evict pod, deleting it before its instance is interrupted", "pod",
+			klog.KObj(pod))
+		err = p.k8sClient.DeletePod(metaPod.ctx, pod.Namespace, pod.Name)
+	}
+	if err != nil {
+		klog.ErrorS(err, "Unable to evict pod", "pod", klog.KObj(pod))
+		metrics.PodEvictionErrors.Inc()
+		p.recordEvent(pod, corev1.EventTypeWarning, eventReasonEvictionFailed, "Unable to evict pod: %v", err)
+
+		metaPod.updatePod(func(pod *corev1.Pod) {
+			removePodCondition(pod, PodConditionDisruptionTarget)
+		})
+		p.notifyPod(metaPod)
+		return
+	}
+
+	metaPod.mu.Lock()
+	metaPod.evicted = true
+	metaPod.mu.Unlock()
+
+	metrics.PodsEvicted.Inc()
+	p.recordEvent(pod, corev1.EventTypeNormal, eventReasonEvicted, "Evicting pod (grace period %ds): %v",
+		gracePeriod, message)
+}
+
+// evictPod evicts a pod via the k8s eviction API
+func (p *Ec2Provider) evictPod(metaPod *MetaPod, gracePeriod int64) error {
+	if p.k8sClient == nil {
+		return errors.New("no k8s client to evict pod with")
+	}
+
+	pod := metaPod.snapshot()
+	return p.k8sClient.EvictPod(metaPod.ctx, pod.Namespace, pod.Name, gracePeriod)
+}
+
+// removePodCondition removes a condition from a pod's status (if it has it)
+func removePodCondition(pod *corev1.Pod, conditionType corev1.PodConditionType) {
+	conditions := pod.Status.Conditions[:0]
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type != conditionType {
+			conditions = append(conditions, condition)
+		}
+	}
+	pod.Status.Conditions = conditions
+}
@@ -0,0 +1,207 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package ec2provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/aws-virtual-kubelet/internal/config"
+	"github.com/aws/aws-virtual-kubelet/internal/k8sutils"
+	"github.com/aws/aws-virtual-kubelet/internal/vkvmaclient"
+	mock_vkvmaclient "github.com/aws/aws-virtual-kubelet/mocks/vkvmaclient"
+	vkvmagent_v0 "github.com/aws/aws-virtual-kubelet/proto/vkvmagent/v0"
+)
+
+// fakeK8sClient records pod evictions and deletions (other K8SAPI methods aren't implemented)
+type fakeK8sClient struct {
+	k8sutils.K8SAPI
+	evictErr     error
+	deleteErr    error
+	gracePeriods []int64
+	deletes      int
+}
+
+func (f *fakeK8sClient) EvictPod(ctx context.Context, namespace string, podName string,
+	gracePeriodSeconds int64) error {
+	f.gracePeriods = append(f.gracePeriods, gracePeriodSeconds)
+	return f.evictErr
+}
+
+func (f *fakeK8sClient) DeletePod(ctx context.Context, namespace string, podName string) error {
+	f.deletes++
+	return f.deleteErr
+}
+
+func TestWatchInstanceEvents(t *testing.T) {
+	if err := config.InitConfig(&config.DirectLoader{DirectConfig: config.ProviderConfig{
+		ManagementSubnet: "subnet-management",
+	}}); err != nil {
+		t.Fatalf("unable to initialize config: %v", err)
+	}
+
+	spotInterruption := func(in time.Duration) *vkvmagent_v0.InstanceEvent {
+		return &vkvmagent_v0.InstanceEvent{
+			Type:   vkvmagent_v0.InstanceEvent_SPOT_INTERRUPTION,
+			Time:   timestamppb.New(time.Now().Add(in)),
+			Action: "terminate",
+		}
+	}
+
+	tests := []struct {
+		name      string
+		events    []*vkvmagent_v0.InstanceEvent
+		evictErr  error
+		deleteErr error
+		// minGracePeriod and maxGracePeriod bound each eviction's grace period
+		minGracePeriod int64
+		maxGracePeriod int64
+		wantEvictions  int
+		wantDeletes    int
+		wantDisruption bool
+	}{
+		{
+			name:           "spot interruption shortens grace period",
+			events:         []*vkvmagent_v0.InstanceEvent{spotInterruption(20 * time.Second)},
+			minGracePeriod: 9,
+			maxGracePeriod: 10,
+			wantEvictions:  1,
+			wantDisruption: true,
+		},
+		{
+			name:           "spot interruption without time keeps grace period",
+			events:         []*vkvmagent_v0.InstanceEvent{{Type: vkvmagent_v0.InstanceEvent_SPOT_INTERRUPTION}},
+			minGracePeriod: 30,
+			maxGracePeriod: 30,
+			wantEvictions:  1,
+			wantDisruption: true,
+		},
+		{
+			name:           "imminent spot interruption evicts immediately",
+			events:         []*vkvmagent_v0.InstanceEvent{spotInterruption(5 * time.Second)},
+			minGracePeriod: 0,
+			maxGracePeriod: 0,
+			wantEvictions:  1,
+			wantDisruption: true,
+		},
+		{
+			name: "pod is evicted once",
+			events: []*vkvmagent_v0.InstanceEvent{
+				spotInterruption(time.Minute),
+				spotInterruption(time.Minute),
+			},
+			minGracePeriod: 30,
+			maxGracePeriod: 30,
+			wantEvictions:  1,
+			wantDisruption: true,
+		},
+		{
+			name:           "refused eviction deletes pod",
+			events:         []*vkvmagent_v0.InstanceEvent{spotInterruption(time.Minute)},
+			evictErr:       errors.New("Cannot evict pod as it would violate the pod's disruption budget"),
+			minGracePeriod: 30,
+			maxGracePeriod: 30,
+			wantEvictions:  1,
+			wantDeletes:    1,
+			wantDisruption: true,
+		},
+		{
+			name: "failed eviction clears condition and is retried",
+			events: []*vkvmagent_v0.InstanceEvent{
+				spotInterruption(time.Minute),
+				spotInterruption(time.Minute),
+			},
+			evictErr:       errors.New("eviction failed"),
+			deleteErr:      errors.New("delete failed"),
+			minGracePeriod: 30,
+			maxGracePeriod: 30,
+			wantEvictions:  2,
+			wantDeletes:    2,
+			wantDisruption: false,
+		},
+		{
+			name: "rebalance recommendation doesn't evict by default",
+			events: []*vkvmagent_v0.InstanceEvent{{
+				Type: vkvmagent_v0.InstanceEvent_REBALANCE_RECOMMENDATION,
+				Time: timestamppb.Now(),
+			}},
+			wantEvictions:  0,
+			wantDisruption: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gracePeriod := int64(30)
+			metaPod := NewMetaPod(&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "pod",
+					Namespace:   "default",
+					Annotations: map[string]string{"compute.amazonaws.com/instance-id": "i-0123456789abcdef0"},
+				},
+				Spec:   corev1.PodSpec{TerminationGracePeriodSeconds: &gracePeriod},
+				Status: corev1.PodStatus{Phase: corev1.PodRunning, PodIP: "10.0.0.1"},
+			}, nil, nil)
+			metaPod.startContext(context.Background())
+
+			// the agent sends the events, then the stream ends when the pod is deleted
+			client := mock_vkvmaclient.NewMockGrpcClient(ctrl)
+			client.EXPECT().WatchInstanceEvents(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, handler func(*vkvmagent_v0.InstanceEvent)) error {
+					for _, event := range tt.events {
+						handler(event)
+					}
+					metaPod.cancel()
+					return ctx.Err()
+				})
+
+			k8sClient := &fakeK8sClient{evictErr: tt.evictErr, deleteErr: tt.deleteErr}
+			p := &Ec2Provider{
+				podNotifier:  func(*corev1.Pod) {},
+				k8sClient:    k8sClient,
+				newPodClient: func(*corev1.Pod) vkvmaclient.GrpcClient { return client },
+			}
+
+			p.watchInstanceEvents(metaPod)
+
+			if len(k8sClient.gracePeriods) != tt.wantEvictions {
+				t.Errorf("expected %d eviction(s), got %d", tt.wantEvictions, len(k8sClient.gracePeriods))
+			}
+			for _, gracePeriod := range k8sClient.gracePeriods {
+				if gracePeriod < tt.minGracePeriod || gracePeriod > tt.maxGracePeriod {
+					t.Errorf("expected a grace period between %ds and %ds, got %ds", tt.minGracePeriod,
+						tt.maxGracePeriod, gracePeriod)
+				}
+			}
+			if k8sClient.deletes != tt.wantDeletes {
+				t.Errorf("expected %d deletion(s), got %d", tt.wantDeletes, k8sClient.deletes)
+			}
+
+			disruption := false
+			for _, condition := range metaPod.snapshot().Status.Conditions {
+				if condition.Type == PodConditionDisruptionTarget && condition.Status == corev1.ConditionTrue {
+					disruption = true
+				}
+			}
+			if disruption != tt.wantDisruption {
+				t.Errorf("expected DisruptionTarget condition %v, got %v", tt.wantDisruption, disruption)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package ec2provider
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-virtual-kubelet/internal/metrics"
+)
+
+// launchQueue admits instance launches first-come first-served, limiting how many are in flight at once
+type launchQueue struct {
+	mu sync.Mutex
+	// maxInFlight is the maximum number of launches in flight (0 means no limit)
+	maxInFlight int
+	inFlight    int
+	waiting     []*launchTicket
+}
+
+// launchTicket is a launch waiting in the queue
+type launchTicket struct {
+	// admitted is closed when the launch is admitted
+	admitted chan struct{}
+	// position receives the launch's latest (1-based) position in the queue
+	position chan int
+}
+
+func newLaunchQueue(maxInFlight int) *launchQueue {
+	return &launchQueue{maxInFlight: maxInFlight}
+}
+
+// admit waits until a launch can start (or ctx is done), calling onQueued from the caller's goroutine with the
+//
+//	launch's position in the queue whenever it changes.  The returned function must be called once the launch is
+//	complete (successfully or not) to admit the next launch.
+func (lq *launchQueue) admit(ctx context.Context, onQueued func(position int)) (func(), error) {
+	start := time.Now()
+
+	lq.mu.Lock()
+	if lq.maxInFlight <= 0 || (lq.inFlight < lq.maxInFlight && len(lq.waiting) == 0) {
+		lq.inFlight++
+		lq.updateMetrics()
+		lq.mu.Unlock()
+		metrics.LaunchQueueWaitSeconds.Observe(0)
+		return lq.releaseFunc(), nil
+	}
+
+	ticket := &launchTicket{admitted: make(chan struct{}), position: make(chan int, 1)}
+	lq.waiting = append(lq.waiting, ticket)
+	position := len(lq.waiting)
+	lq.updateMetrics()
+	lq.mu.Unlock()
+
+	onQueued(position)
+
+	for {
+		select {
+		case <-ticket.admitted:
+			metrics.LaunchQueueWaitSeconds.Observe(time.Since(start).Seconds())
+			return lq.releaseFunc(), nil
+		case position = <-ticket.position:
+			onQueued(position)
+		case <-ctx.Done():
+			if !lq.remove(ticket) {
+				// the launch was admitted meanwhile, so pass its slot on
+				lq.release()
+			}
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// releaseFunc returns a function that releases a launch's slot (once, however many times it's called)
+func (lq *launchQueue) releaseFunc() func() {
+	var once sync.Once
+	return func() { once.Do(lq.release) }
+}
+
+// release ends a launch, admitting the next waiting launch (if any)
+func (lq *launchQueue) release() {
+	lq.mu.Lock()
+	defer lq.mu.Unlock()
+
+	lq.inFlight--
+	if len(lq.waiting) > 0 && (lq.maxInFlight <= 0 || lq.inFlight < lq.maxInFlight) {
+		next := lq.waiting[0]
+		lq.waiting = lq.waiting[1:]
+		lq.inFlight++
+		close(next.admitted)
+		lq.updatePositions()
+	}
+	lq.updateMetrics()
+}
+
+// remove removes a waiting launch from the queue, returning false if it isn't waiting (i.e. it was admitted)
+func (lq *launchQueue) remove(ticket *launchTicket) bool {
+	lq.mu.Lock()
+	defer lq.mu.Unlock()
+
+	for i := range lq.waiting {
+		if lq.waiting[i] == ticket {
+			lq.waiting = append(lq.waiting[:i], lq.waiting[i+1:]...)
+			lq.updatePositions()
+			lq.updateMetrics()
+			return true
+		}
+	}
+
+	return false
+}
+
+// updatePositions sends each waiting launch its new position, replacing any position it hasn't received yet (caller
+//
+//	must hold the lock)
+func (lq *launchQueue) updatePositions() {
+	for i, ticket := range lq.waiting {
+		select {
+		case <-ticket.position:
+		default:
+		}
+		ticket.position <- i + 1
+	}
+}
+
+// updateMetrics updates the queue depth and in flight launch gauges (caller must hold the lock)
+func (lq *launchQueue) updateMetrics() {
+	metrics.LaunchQueueDepth.Set(float64(len(lq.waiting)))
+	metrics.LaunchesInFlight.Set(float64(lq.inFlight))
+}
@@ -0,0 +1,215 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package ec2provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+const launchQueueTestTimeout = 5 * time.Second
+
+// admitResult is the outcome of a launch's admit call
+type admitResult struct {
+	release func()
+	err     error
+}
+
+// queueLaunch starts admitting a launch in the background, waiting until it's queued.  Positions the launch is queued
+//
+//	at are sent to positions.
+func queueLaunch(t *testing.T, ctx context.Context, lq *launchQueue, positions chan int) chan admitResult {
+	t.Helper()
+
+	queued := make(chan struct{})
+	result := make(chan admitResult, 1)
+	go func() {
+		first := true
+		release, err := lq.admit(ctx, func(position int) {
+			if positions != nil {
+				positions <- position
+			}
+			if first {
+				first = false
+				close(queued)
+			}
+		})
+		result <- admitResult{release: release, err: err}
+	}()
+
+	select {
+	case <-queued:
+	case r := <-result:
+		t.Fatalf("launch was admitted (err %v) instead of queued", r.err)
+	case <-time.After(launchQueueTestTimeout):
+		t.Fatal("timed out waiting for launch to be queued")
+	}
+
+	return result
+}
+
+// awaitAdmit waits for a queued launch's admit call to return
+func awaitAdmit(t *testing.T, result chan admitResult) admitResult {
+	t.Helper()
+
+	select {
+	case r := <-result:
+		return r
+	case <-time.After(launchQueueTestTimeout):
+		t.Fatal("timed out waiting for launch to be admitted")
+	}
+	return admitResult{}
+}
+
+// assertWaiting asserts that a queued launch hasn't been admitted
+func assertWaiting(t *testing.T, result chan admitResult) {
+	t.Helper()
+
+	select {
+	case r := <-result:
+		t.Fatalf("launch was admitted (err %v) while the queue was full", r.err)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestLaunchQueueAdmitsWithoutLimit(t *testing.T) {
+	lq := newLaunchQueue(0)
+
+	for i := 0; i < 3; i++ {
+		release, err := lq.admit(context.Background(), func(int) { t.Error("launch was queued without a limit") })
+		if err != nil {
+			t.Fatalf("admit: unexpected error %v", err)
+		}
+		defer release()
+	}
+
+	if lq.inFlight != 3 {
+		t.Errorf("expected 3 launches in flight, got %d", lq.inFlight)
+	}
+}
+
+func TestLaunchQueueAdmitsFIFO(t *testing.T) {
+	lq := newLaunchQueue(1)
+
+	release, err := lq.admit(context.Background(), func(int) { t.Error("first launch was queued") })
+	if err != nil {
+		t.Fatalf("admit: unexpected error %v", err)
+	}
+
+	second := queueLaunch(t, context.Background(), lq, nil)
+	third := queueLaunch(t, context.Background(), lq, nil)
+	assertWaiting(t, second)
+
+	release()
+	r := awaitAdmit(t, second)
+	if r.err != nil {
+		t.Fatalf("second launch: unexpected error %v", r.err)
+	}
+	assertWaiting(t, third)
+
+	r.release()
+	r = awaitAdmit(t, third)
+	if r.err != nil {
+		t.Fatalf("third launch: unexpected error %v", r.err)
+	}
+	r.release()
+
+	if lq.inFlight != 0 || len(lq.waiting) != 0 {
+		t.Errorf("expected an empty queue, got %d in flight and %d waiting", lq.inFlight, len(lq.waiting))
+	}
+}
+
+func TestLaunchQueueReleasesOnFailure(t *testing.T) {
+	lq := newLaunchQueue(1)
+
+	// a failed launch releases its slot (release may be deferred and called again, which is a no-op)
+	failedLaunch := func() error {
+		release, err := lq.admit(context.Background(), func(int) {})
+		if err != nil {
+			return err
+		}
+		defer release()
+		release()
+		return errors.New("RunInstances failed")
+	}
+
+	if err := failedLaunch(); err == nil {
+		t.Fatal("expected the launch to fail")
+	}
+	if lq.inFlight != 0 {
+		t.Fatalf("expected the failed launch's slot to be released, got %d in flight", lq.inFlight)
+	}
+
+	release, err := lq.admit(context.Background(), func(int) { t.Error("launch was queued after a failed launch") })
+	if err != nil {
+		t.Fatalf("admit: unexpected error %v", err)
+	}
+
+	waiting := queueLaunch(t, context.Background(), lq, nil)
+	release()
+	r := awaitAdmit(t, waiting)
+	if r.err != nil {
+		t.Fatalf("waiting launch: unexpected error %v", r.err)
+	}
+	r.release()
+}
+
+func TestLaunchQueueRemovesCancelledWaiter(t *testing.T) {
+	lq := newLaunchQueue(1)
+
+	release, err := lq.admit(context.Background(), func(int) {})
+	if err != nil {
+		t.Fatalf("admit: unexpected error %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := queueLaunch(t, ctx, lq, nil)
+	positions := make(chan int, 2)
+	waiting := queueLaunch(t, context.Background(), lq, positions)
+	if position := <-positions; position != 2 {
+		t.Fatalf("expected the second waiter at position 2, got %d", position)
+	}
+
+	cancel()
+	r := awaitAdmit(t, cancelled)
+	if !errors.Is(r.err, context.Canceled) {
+		t.Fatalf("expected the cancelled waiter to return context.Canceled, got %v", r.err)
+	}
+
+	// the remaining waiter moves up and is admitted next
+	select {
+	case position := <-positions:
+		if position != 1 {
+			t.Errorf("expected the remaining waiter at position 1, got %d", position)
+		}
+	case <-time.After(launchQueueTestTimeout):
+		t.Fatal("timed out waiting for the remaining waiter's position to be updated")
+	}
+
+	lq.mu.Lock()
+	waitingCount := len(lq.waiting)
+	lq.mu.Unlock()
+	if waitingCount != 1 {
+		t.Fatalf("expected 1 waiting launch after cancellation, got %d", waitingCount)
+	}
+
+	release()
+	r = awaitAdmit(t, waiting)
+	if r.err != nil {
+		t.Fatalf("remaining waiter: unexpected error %v", r.err)
+	}
+	r.release()
+
+	if lq.inFlight != 0 || len(lq.waiting) != 0 {
+		t.Errorf("expected an empty queue, got %d in flight and %d waiting", lq.inFlight, len(lq.waiting))
+	}
+}
@@ -10,6 +10,9 @@ Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL o
 package ec2provider
 
 import (
+	"context"
+	"sync"
+
 	"github.com/aws/aws-virtual-kubelet/internal/health"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -17,9 +20,34 @@ import (
 // 🐛
 
 type MetaPod struct {
-	pod      *corev1.Pod
+	// pod is updated by the pod's background goroutines (provisioning, monitoring, eviction, etc.) while VK reads it, so
+	//  it's guarded by mu (change it via updatePod and read it via snapshot)
+	pod *corev1.Pod
+	mu  sync.RWMutex
+	// evicted records that the pod was evicted (or deleted) because its instance is being interrupted (guarded by mu)
+	evicted bool
+	// monitor is replaced when the pod's compute is replaced (guarded by mu, see getMonitor and setMonitor)
 	monitor  *health.PodMonitor
 	notifier func(*corev1.Pod)
+	// cancelProvisioning cancels the pod's background provisioning (if provisioning was started, guarded by mu)
+	cancelProvisioning context.CancelFunc
+	// provisioned is closed when background provisioning ends, successfully or not (guarded by mu)
+	provisioned chan struct{}
+	// launchAttempts records failed provisioning attempts
+	launchAttempts []launchAttempt
+	// restarts tracks container restarts
+	restarts restartState
+	// ctx is the pod's context (derived from the provider's root context).  Provisioning, monitoring, restarts, and
+	//  the AWS and gRPC calls they make all use it (or a context derived from it), so cancelling it stops them all.
+	ctx    context.Context
+	cancel context.CancelFunc
+	// waitGroup tracks the pod's goroutines (see Ec2Provider.goPod)
+	waitGroup sync.WaitGroup
+	// goMu makes cancelling ctx and adding to waitGroup mutually exclusive, so no goroutine is added once stopContext
+	//	starts waiting
+	goMu sync.Mutex
+	// watchingInstanceEvents ensures only one goroutine watches the pod's instance events
+	watchingInstanceEvents sync.Once
 }
 
 func NewMetaPod(pod *corev1.Pod, monitor *health.PodMonitor, notifier func(*corev1.Pod)) *MetaPod {
@@ -29,3 +57,148 @@ func NewMetaPod(pod *corev1.Pod, monitor *health.PodMonitor, notifier func(*core
 		notifier: notifier,
 	}
 }
+
+// startProvisioning records that background provisioning has started and how to cancel it.  Returns false (recording
+//
+//	nothing) if provisioning is already in progress.
+func (mp *MetaPod) startProvisioning(cancel context.CancelFunc) bool {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+
+	if mp.provisioning() {
+		return false
+	}
+
+	mp.cancelProvisioning = cancel
+	mp.provisioned = make(chan struct{})
+
+	return true
+}
+
+// provisioningDone records that background provisioning has ended
+func (mp *MetaPod) provisioningDone() {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+
+	mp.cancelProvisioning()
+	close(mp.provisioned)
+}
+
+// isProvisioning returns true while background provisioning is in progress
+func (mp *MetaPod) isProvisioning() bool {
+	mp.mu.RLock()
+	defer mp.mu.RUnlock()
+
+	return mp.provisioning()
+}
+
+// provisioning returns true while background provisioning is in progress (caller must hold the lock)
+func (mp *MetaPod) provisioning() bool {
+	if mp.provisioned == nil {
+		return false
+	}
+
+	select {
+	case <-mp.provisioned:
+		return false
+	default:
+		return true
+	}
+}
+
+// getMonitor returns the pod's monitor (nil if it has none)
+func (mp *MetaPod) getMonitor() *health.PodMonitor {
+	mp.mu.RLock()
+	defer mp.mu.RUnlock()
+
+	return mp.monitor
+}
+
+// setMonitor replaces the pod's monitor
+func (mp *MetaPod) setMonitor(monitor *health.PodMonitor) {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+
+	mp.monitor = monitor
+}
+
+// hasPod returns true if pod is the cached pod object itself (which the pod's monitor holds), rather than a snapshot or
+//
+//	a pod that has since been replaced in the cache
+func (mp *MetaPod) hasPod(pod *corev1.Pod) bool {
+	mp.mu.RLock()
+	defer mp.mu.RUnlock()
+
+	return mp.pod == pod
+}
+
+// startContext derives the pod's context from the provider's root context (unless the pod already has one)
+func (mp *MetaPod) startContext(root context.Context) {
+	if mp.ctx == nil {
+		mp.ctx, mp.cancel = context.WithCancel(root)
+	}
+}
+
+// stopContext cancels the pod's context, then waits for the pod's goroutines (provisioning, restarts, etc.) to exit
+//
+//	(or ctx to be done)
+func (mp *MetaPod) stopContext(ctx context.Context) error {
+	if mp.cancel == nil {
+		return nil
+	}
+
+	mp.goMu.Lock()
+	mp.cancel()
+	mp.goMu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		mp.waitGroup.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// trackGoroutine adds a goroutine to the pod's WaitGroup, unless the pod's context was already cancelled (in which case
+//
+//	the goroutine mustn't be started, and false is returned)
+func (mp *MetaPod) trackGoroutine() bool {
+	mp.goMu.Lock()
+	defer mp.goMu.Unlock()
+
+	if mp.ctx.Err() != nil {
+		return false
+	}
+
+	mp.waitGroup.Add(1)
+	return true
+}
+
+// startWatchingInstanceEvents starts watching the pod's instance events (unless they are already being watched)
+func (mp *MetaPod) startWatchingInstanceEvents(p *Ec2Provider) {
+	mp.watchingInstanceEvents.Do(func() {
+		p.goPod(mp, func() { p.watchInstanceEvents(mp) })
+	})
+}
+
+// updatePod applies an update to the pod while holding its lock
+func (mp *MetaPod) updatePod(update func(pod *corev1.Pod)) {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+
+	update(mp.pod)
+}
+
+// snapshot returns a copy of the pod that can be read (or handed to VK) while the pod is being updated
+func (mp *MetaPod) snapshot() *corev1.Pod {
+	mp.mu.RLock()
+	defer mp.mu.RUnlock()
+
+	return mp.pod.DeepCopy()
+}
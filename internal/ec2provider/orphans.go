@@ -0,0 +1,188 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package ec2provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+
+	"github.com/aws/aws-virtual-kubelet/internal/awsutils"
+	"github.com/aws/aws-virtual-kubelet/internal/config"
+	"github.com/aws/aws-virtual-kubelet/internal/k8sutils"
+	"github.com/aws/aws-virtual-kubelet/internal/metrics"
+	"github.com/aws/aws-virtual-kubelet/internal/utils"
+)
+
+// orphanCollector periodically finds pod instances (tagged for this cluster and node) whose pod no longer exists in
+//
+//	the pod cache or k8s, and terminates them once they have been orphaned for the configured grace period
+type orphanCollector struct {
+	provider  *Ec2Provider
+	ec2Client awsutils.EC2API
+	k8sClient k8sutils.K8SAPI
+	// orphanedSince records when each currently orphaned instance was first found
+	orphanedSince map[string]time.Time
+}
+
+func newOrphanCollector(provider *Ec2Provider, ec2Client awsutils.EC2API, k8sClient k8sutils.K8SAPI) *orphanCollector {
+	return &orphanCollector{
+		provider:      provider,
+		ec2Client:     ec2Client,
+		k8sClient:     k8sClient,
+		orphanedSince: map[string]time.Time{},
+	}
+}
+
+// run looks for orphaned instances every interval until the context is cancelled
+func (oc *orphanCollector) run(ctx context.Context) {
+	cfg := config.Config().OrphanCollectorConfig
+
+	klog.InfoS("Starting orphaned instance collector", "interval", cfg.IntervalSeconds,
+		"gracePeriod", cfg.GracePeriodSeconds, "dryRun", cfg.DryRun, "exclusionTag", cfg.ExclusionTagKey)
+
+	ticker := time.NewTicker(time.Duration(cfg.IntervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			klog.Info("Orphaned instance collector stopped")
+			return
+		case <-ticker.C:
+			oc.collect(ctx)
+		}
+	}
+}
+
+// collect finds orphaned instances and terminates those that have been orphaned longer than the grace period
+func (oc *orphanCollector) collect(ctx context.Context) {
+	cfg := config.Config().OrphanCollectorConfig
+	gracePeriod := time.Duration(cfg.GracePeriodSeconds) * time.Second
+
+	instances, err := awsutils.DescribePodInstances(ctx, oc.ec2Client, config.Config().ClusterName,
+		oc.provider.NodeName)
+	if err != nil {
+		metrics.OrphanCollectorErrors.Inc()
+		return
+	}
+
+	now := time.Now()
+	orphaned := map[string]bool{}
+
+	for i := range instances {
+		instance := &instances[i]
+		instanceID := aws.ToString(instance.InstanceId)
+
+		if cfg.ExclusionTagKey != "" && hasTag(instance.Tags, cfg.ExclusionTagKey) {
+			klog.V(1).InfoS("Skipping instance excluded from orphan collection", "instance", instanceID)
+			continue
+		}
+
+		isOrphan, err := oc.isOrphan(ctx, instance)
+		if err != nil {
+			// ownership is unknown, so leave the instance (and its grace period) alone until the next pass
+			klog.ErrorS(err, "Unable to determine if instance is orphaned", "instance", instanceID)
+			metrics.OrphanCollectorErrors.Inc()
+			if _, ok := oc.orphanedSince[instanceID]; ok {
+				orphaned[instanceID] = true
+			}
+			continue
+		}
+		if !isOrphan {
+			continue
+		}
+
+		orphaned[instanceID] = true
+		since, ok := oc.orphanedSince[instanceID]
+		if !ok {
+			klog.InfoS("Found orphaned instance", "instance", instanceID,
+				"pod", klog.KRef(awsutils.GetTagValue(instance.Tags, awsutils.PodNamespaceTagKey),
+					awsutils.GetTagValue(instance.Tags, awsutils.PodNameTagKey)),
+				"uid", awsutils.GetTagValue(instance.Tags, awsutils.PodUIDTagKey))
+			metrics.OrphanedInstancesFound.Inc()
+			oc.orphanedSince[instanceID] = now
+			since = now
+		}
+
+		if now.Sub(since) < gracePeriod {
+			continue
+		}
+
+		if cfg.DryRun {
+			klog.InfoS("Dry run: would terminate orphaned instance", "instance", instanceID,
+				"orphanedSince", since.Format(time.RFC3339))
+			continue
+		}
+
+		klog.InfoS("Terminating orphaned instance", "instance", instanceID, "orphanedSince", since.Format(time.RFC3339))
+		if _, err := awsutils.TerminateEC2(ctx, oc.ec2Client, instanceID); err != nil {
+			klog.ErrorS(err, "Unable to terminate orphaned instance", "instance", instanceID)
+			metrics.OrphanCollectorErrors.Inc()
+			continue
+		}
+		metrics.OrphanedInstancesTerminated.Inc()
+		delete(orphaned, instanceID)
+	}
+
+	// forget instances that are gone or have been adopted
+	for instanceID := range oc.orphanedSince {
+		if !orphaned[instanceID] {
+			delete(oc.orphanedSince, instanceID)
+		}
+	}
+}
+
+// isOrphan returns true if neither the pod cache nor k8s has the pod an instance is tagged for.  An instance tagged for
+//
+//	a cached pod that has since moved to another instance is also an orphan.
+func (oc *orphanCollector) isOrphan(ctx context.Context, instance *types.Instance) (bool, error) {
+	instanceID := aws.ToString(instance.InstanceId)
+	uid := awsutils.GetTagValue(instance.Tags, awsutils.PodUIDTagKey)
+	namespace := awsutils.GetTagValue(instance.Tags, awsutils.PodNamespaceTagKey)
+	name := awsutils.GetTagValue(instance.Tags, awsutils.PodNameTagKey)
+
+	if oc.provider.pods != nil {
+		if metaPod := oc.provider.pods.Get(utils.GetPodCacheKey(namespace, name)); metaPod != nil {
+			if pod := metaPod.snapshot(); string(pod.UID) == uid {
+				podInstanceID := pod.Annotations["compute.amazonaws.com/instance-id"]
+				// the annotation is set after RunInstances returns, so an unset annotation may mean launch is in
+				//	progress
+				return podInstanceID != "" && podInstanceID != instanceID, nil
+			}
+		}
+	}
+
+	// the cache may lag k8s (e.g. during startup), so k8s has the final say
+	pod, err := oc.k8sClient.GetPod(ctx, namespace, name)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}
+
+	return string(pod.UID) != uid, nil
+}
+
+// hasTag returns true if a tag with the given key is present (regardless of value)
+func hasTag(tags []types.Tag, key string) bool {
+	for _, tag := range tags {
+		if aws.ToString(tag.Key) == key {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,118 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package ec2provider
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	"github.com/aws/aws-virtual-kubelet/internal/awsutils"
+	"github.com/aws/aws-virtual-kubelet/internal/config"
+	"github.com/aws/aws-virtual-kubelet/internal/metrics"
+	"github.com/aws/aws-virtual-kubelet/internal/utils"
+)
+
+// ReconcileCacheWithInstances reconciles a pod cache (populated from k8s) with the pod instances found via EC2 tags.
+//
+//   - Pods k8s knows about get their instance ID (and IP) restored if it's missing
+//   - Pods k8s has forgotten (e.g. a bare pod evicted while the provider was down) are restored from instance tags.  VK
+//     then sees them as dangling and calls DeletePod, which terminates their instances.
+//   - Instances belonging to an earlier pod with the same name (or duplicates) are logged and left alone
+func (p *Ec2Provider) ReconcileCacheWithInstances(ctx context.Context, cache *PodCache) error {
+	instances, err := awsutils.DescribePodInstances(ctx, p.computeManager.ec2Client, config.Config().ClusterName,
+		p.NodeName)
+	if err != nil {
+		return err
+	}
+
+	klog.InfoS("Reconciling pod cache with tagged pod instances", "pods", len(cache.GetList()),
+		"instances", len(instances))
+
+	for i := range instances {
+		instance := &instances[i]
+		instanceID := aws.ToString(instance.InstanceId)
+		uid := awsutils.GetTagValue(instance.Tags, awsutils.PodUIDTagKey)
+		namespace := awsutils.GetTagValue(instance.Tags, awsutils.PodNamespaceTagKey)
+		name := awsutils.GetTagValue(instance.Tags, awsutils.PodNameTagKey)
+
+		if uid == "" || namespace == "" || name == "" {
+			klog.InfoS("⚠️  Instance has incomplete pod tags, skipping", "instance", instanceID)
+			continue
+		}
+
+		podKey := utils.GetPodCacheKey(namespace, name)
+		metaPod := cache.Get(podKey)
+
+		if metaPod == nil {
+			klog.InfoS("Restoring pod unknown to k8s from instance tags (it will be deleted)",
+				"pod", klog.KRef(namespace, name), "uid", uid, "instance", instanceID)
+			cache.Set(podKey, NewMetaPod(newRestoredPod(instance, p.NodeName, namespace, name, uid), nil, nil))
+			metrics.PodsRestoredFromEC2.Inc()
+			continue
+		}
+
+		pod := metaPod.snapshot()
+		podInstanceID := pod.Annotations["compute.amazonaws.com/instance-id"]
+
+		switch {
+		case string(pod.UID) != uid:
+			klog.InfoS("⚠️  Instance belongs to an earlier pod with the same name", "pod", klog.KObj(pod),
+				"uid", pod.UID, "instanceUID", uid, "instance", instanceID)
+		case podInstanceID == "":
+			klog.InfoS("Restoring pod instance from instance tags", "pod", klog.KObj(pod), "instance", instanceID)
+			metaPod.updatePod(func(pod *corev1.Pod) {
+				if pod.Annotations == nil {
+					pod.Annotations = map[string]string{}
+				}
+				pod.Annotations["compute.amazonaws.com/instance-id"] = instanceID
+				if pod.Status.PodIP == "" {
+					pod.Status.PodIP = aws.ToString(instance.PrivateIpAddress)
+					pod.Status.HostIP = aws.ToString(instance.PrivateIpAddress)
+				}
+			})
+		case podInstanceID != instanceID:
+			klog.InfoS("⚠️  Pod has more than one tagged instance", "pod", klog.KObj(pod), "podInstance",
+				podInstanceID, "instance", instanceID)
+		}
+	}
+
+	return nil
+}
+
+// newRestoredPod creates a (minimal) pod from the tags and attributes of its instance
+func newRestoredPod(instance *types.Instance, nodeName, namespace, name, uid string) *corev1.Pod {
+	privateIP := aws.ToString(instance.PrivateIpAddress)
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			UID:       k8stypes.UID(uid),
+			Annotations: map[string]string{
+				"compute.amazonaws.com/instance-id":   aws.ToString(instance.InstanceId),
+				"compute.amazonaws.com/instance-type": string(instance.InstanceType),
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: nodeName,
+		},
+		Status: corev1.PodStatus{
+			Phase:  corev1.PodRunning,
+			PodIP:  privateIP,
+			HostIP: privateIP,
+		},
+	}
+}
@@ -48,7 +48,7 @@ func (pc *PodCache) GetList() []*MetaPod {
 	return list
 }
 
-// GetPodList returns a list of Pods
+// GetPodList returns a list of Pods (snapshots of the cached pods)
 func (pc *PodCache) GetPodList() []*corev1.Pod {
 	pc.RLock()
 	defer pc.RUnlock()
@@ -56,7 +56,7 @@ func (pc *PodCache) GetPodList() []*corev1.Pod {
 	var podList []*corev1.Pod
 
 	for _, value := range pc.pods {
-		podList = append(podList, value.pod)
+		podList = append(podList, value.snapshot())
 	}
 
 	return podList
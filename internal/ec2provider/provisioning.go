@@ -0,0 +1,374 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package ec2provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/aws/aws-virtual-kubelet/internal/config"
+	"github.com/aws/aws-virtual-kubelet/internal/metrics"
+	"github.com/aws/aws-virtual-kubelet/internal/poderrors"
+	"github.com/aws/aws-virtual-kubelet/internal/tracing"
+	"github.com/aws/aws-virtual-kubelet/internal/utils"
+	"github.com/aws/aws-virtual-kubelet/internal/vkvmaclient"
+	vkvmagent_v0 "github.com/aws/aws-virtual-kubelet/proto/vkvmagent/v0"
+)
+
+// Custom pod conditions reporting the progress of (background) pod provisioning
+const (
+	PodConditionEC2Launched         corev1.PodConditionType = "EC2Launched"
+	PodConditionAgentConnected      corev1.PodConditionType = "AgentConnected"
+	PodConditionApplicationLaunched corev1.PodConditionType = "ApplicationLaunched"
+)
+
+// Provisioning condition reasons
+const (
+	provisioningReasonWaiting         = "Waiting"
+	provisioningReasonQueued          = "Queued"
+	provisioningReasonInProgress      = "InProgress"
+	provisioningReasonSucceeded       = "Succeeded"
+	provisioningReasonEC2LaunchFailed = "EC2LaunchFailed"
+	provisioningReasonLaunchAppFailed = "LaunchApplicationFailed"
+	provisioningReasonFailed          = "ProvisioningFailed"
+)
+
+// launchAttemptsAnnotation records the history of failed provisioning attempts (as JSON)
+const launchAttemptsAnnotation = "compute.amazonaws.com/launch-attempts"
+
+// launchAttempt records a failed provisioning attempt
+type launchAttempt struct {
+	Attempt int       `json:"attempt"`
+	Time    time.Time `json:"time"`
+	Stage   string    `json:"stage"`
+	Reason  string    `json:"reason"`
+	Message string    `json:"message"`
+}
+
+// provisionPod provisions a pod, retrying failed attempts with exponential backoff until the retry budget (see
+//
+//	config.LaunchRetryConfig) is exhausted, at which point the pod is marked Failed.  Provisioning stops early if
+//	provisionCtx is cancelled (i.e. by DeletePod), in which case cleanup is left to the canceller.
+func (p *Ec2Provider) provisionPod(ctx context.Context, provisionCtx context.Context, metaPod *MetaPod) {
+	defer metaPod.provisioningDone()
+
+	// NOTE the pod's identity (used below for logging, events, and tracing) doesn't change while it's provisioned
+	pod := metaPod.snapshot()
+
+	// NOTE only provisionCtx carries the span (pod monitoring started from ctx isn't part of provisioning)
+	provisionCtx, span := tracing.Start(provisionCtx, "ProvisionPod", tracing.PodAttributes(pod)...)
+	var lastErr error
+	defer func() { tracing.End(span, lastErr) }()
+	retryCfg := config.Config().LaunchRetryConfig
+
+	maxAttempts := retryCfg.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		attemptCtx, attemptSpan := tracing.Start(provisionCtx, "ProvisionAttempt", attribute.Int("attempt", attempt))
+		stage, reason, err := p.provisionAttempt(ctx, attemptCtx, metaPod)
+		if err != nil {
+			attemptSpan.SetAttributes(attribute.String("stage", string(stage)), attribute.String("reason", reason))
+		}
+		tracing.End(attemptSpan, err)
+
+		lastErr = err
+		if err == nil {
+			return
+		}
+
+		if provisionCtx.Err() != nil {
+			klog.InfoS("Pod provisioning cancelled", "pod", klog.KObj(pod), "stage", stage)
+			return
+		}
+
+		p.cleanUpFailedAttempt(metaPod, err)
+		p.recordLaunchAttempt(ctx, metaPod, launchAttempt{
+			Attempt: attempt,
+			Time:    time.Now().UTC(),
+			Stage:   string(stage),
+			Reason:  reason,
+			Message: err.Error(),
+		})
+
+		if attempt >= maxAttempts {
+			p.provisioningFailed(metaPod, stage, reason, err, attempt)
+			return
+		}
+
+		backoff := utils.ExponentialBackoff(attempt, time.Duration(retryCfg.InitialBackoffSeconds)*time.Second,
+			time.Duration(retryCfg.MaxBackoffSeconds)*time.Second, retryCfg.BackoffMultiplier)
+
+		klog.InfoS("Retrying pod provisioning", "pod", klog.KObj(pod), "attempt", attempt,
+			"maxAttempts", maxAttempts, "backoff", backoff)
+		metrics.PodLaunchRetries.Inc()
+		p.recordEvent(pod, corev1.EventTypeWarning, eventReasonProvisioningRetry,
+			"Provisioning attempt %d of %d failed (retrying in %v): %v", attempt, maxAttempts, backoff, err)
+
+		metaPod.updatePod(func(pod *corev1.Pod) {
+			setPodCondition(pod, stage, corev1.ConditionFalse, reason,
+				fmt.Sprintf("Attempt %d of %d failed (retrying in %v): %v", attempt, maxAttempts, backoff, err))
+		})
+		p.notifyPod(metaPod)
+
+		select {
+		case <-provisionCtx.Done():
+			klog.InfoS("Pod provisioning cancelled", "pod", klog.KObj(pod), "stage", stage)
+			return
+		case <-time.After(backoff):
+		}
+
+		metaPod.updatePod(initProvisioningConditions)
+		p.notifyPod(metaPod)
+	}
+}
+
+// provisionAttempt obtains compute for a pod, connects to its VKVMAgent, and launches the application, publishing
+//
+//	progress via pod conditions.  On failure, the failed stage (condition) and a reason are returned with the error
+//	(the reason is that of the error's category if it's classified, see poderrors).  Pod monitoring is started using
+//	ctx (which outlives provisioning).
+func (p *Ec2Provider) provisionAttempt(ctx context.Context, provisionCtx context.Context, metaPod *MetaPod) (
+	corev1.PodConditionType, string, error) {
+	pod := metaPod.snapshot()
+
+	// the finalizer must be in place before an instance exists to guarantee the instance is cleaned up
+	if err := p.addCleanupFinalizer(provisionCtx, metaPod); err != nil {
+		klog.ErrorS(err, "Unable to add cleanup finalizer to pod", "pod", klog.KObj(pod))
+		err = poderrors.Wrap(err)
+		return PodConditionEC2Launched, poderrors.Reason(err, provisioningReasonFailed), err
+	}
+
+	// launch EC2
+	instanceID, privateIP, err := p.computeManager.GetCompute(provisionCtx, p, metaPod)
+	if err != nil {
+		klog.ErrorS(err, "Error getting compute for pod", "pod", klog.KObj(pod))
+		err = poderrors.Wrap(err)
+		return PodConditionEC2Launched, poderrors.Reason(err, provisioningReasonEC2LaunchFailed), err
+	}
+
+	metaPod.updatePod(func(pod *corev1.Pod) {
+		pod.Status.PodIP = privateIP
+		pod.Status.HostIP = privateIP
+
+		setPodCondition(pod, PodConditionEC2Launched, corev1.ConditionTrue, provisioningReasonSucceeded,
+			fmt.Sprintf("Instance %v launched with IP %v", instanceID, privateIP))
+		setPodCondition(pod, PodConditionAgentConnected, corev1.ConditionFalse, provisioningReasonInProgress,
+			"Connecting to VKVMAgent")
+	})
+	p.notifyPod(metaPod)
+
+	// connect to VKVMAgent
+	cfg := config.Config()
+	vkvmaClient := vkvmaclient.NewVkvmaClient(privateIP, cfg.VKVMAgentConnectionConfig.Port)
+
+	appClient, err := vkvmaClient.GetApplicationLifecycleClient(provisionCtx)
+	if err != nil {
+		klog.ErrorS(err, "Error getting ApplicationLifecycleClient", "pod", klog.KObj(pod))
+		metrics.GRPCAppClientErrors.Inc()
+		p.recordEvent(pod, corev1.EventTypeWarning, eventReasonAgentUnreachable,
+			"Unable to connect to VKVMAgent at %v: %v", privateIP, err)
+		err = poderrors.WrapAs(poderrors.CategoryAgentUnreachable, err)
+		return PodConditionAgentConnected, poderrors.CategoryAgentUnreachable.Reason(), err
+	}
+
+	p.recordEvent(pod, corev1.EventTypeNormal, eventReasonAgentConnected, "Connected to VKVMAgent at %v",
+		privateIP)
+
+	metaPod.updatePod(func(pod *corev1.Pod) {
+		setPodCondition(pod, PodConditionAgentConnected, corev1.ConditionTrue, provisioningReasonSucceeded,
+			"Connected to VKVMAgent")
+		setPodCondition(pod, PodConditionApplicationLaunched, corev1.ConditionFalse, provisioningReasonInProgress,
+			"Launching application")
+	})
+	p.notifyPod(metaPod)
+
+	// launch application (with the pod as it is now, i.e. with its instance's IP and annotations)
+	// NOTE LaunchApplicationResponse is currently empty (so we discard it)
+	pod = metaPod.snapshot()
+	_, err = appClient.LaunchApplication(provisionCtx, &vkvmagent_v0.LaunchApplicationRequest{
+		Pod: pod,
+	})
+	if err != nil {
+		klog.ErrorS(err, "Error launching application", "pod", klog.KObj(pod))
+		metrics.LaunchApplicationErrors.Inc()
+		p.recordEvent(pod, corev1.EventTypeWarning, eventReasonApplicationLaunchFailed,
+			"LaunchApplication failed: %v", err)
+		err = poderrors.Wrap(err)
+		return PodConditionApplicationLaunched, poderrors.Reason(err, provisioningReasonLaunchAppFailed), err
+	}
+
+	if utils.PodIsWarmPool(provisionCtx, pod, len(p.warmPool.config)) {
+		// mark the instance as IN_USE
+		err = p.warmPool.updateEC2Tags(provisionCtx, instanceID, "set_in_use", *pod)
+		if err != nil {
+			klog.ErrorS(err, "Can't update EC2 tags for Warm Pool", "instance",
+				instanceID, pod, "pod", klog.KObj(pod))
+			err = poderrors.Wrap(err)
+			return PodConditionApplicationLaunched, poderrors.Reason(err, provisioningReasonFailed), err
+		}
+	}
+
+	// DeletePod may have arrived while the application was launching (it will clean up)
+	if err = provisionCtx.Err(); err != nil {
+		return PodConditionApplicationLaunched, provisioningReasonFailed, err
+	}
+
+	metaPod.updatePod(func(pod *corev1.Pod) {
+		setPodCondition(pod, PodConditionApplicationLaunched, corev1.ConditionTrue, provisioningReasonSucceeded,
+			"Application launched")
+	})
+
+	p.recordEvent(pod, corev1.EventTypeNormal, eventReasonApplicationLaunched, "Launched application on instance %v",
+		instanceID)
+
+	// the pod's instance now counts against node resources
+	p.EniNode.RequestStatusRefresh()
+
+	// start monitoring
+	metaPod.getMonitor().Start(ctx)
+	metaPod.startWatchingInstanceEvents(p)
+
+	// notify k8s with pod status update
+	p.notifyPod(metaPod)
+
+	// increment metric
+	metrics.PodsLaunched.Inc()
+
+	klog.InfoS("Pod provisioned", "pod", klog.KObj(pod), "instance", instanceID)
+
+	return "", "", nil
+}
+
+// cleanUpFailedAttempt deletes any compute obtained by a failed provisioning attempt
+func (p *Ec2Provider) cleanUpFailedAttempt(metaPod *MetaPod, err error) {
+	pod := metaPod.snapshot()
+
+	// use a fresh context for cleanup (the attempt may have failed because the pod's context was cancelled, but its
+	//  compute still needs deleting)
+	cleanupCtx := context.Background()
+
+	if pod.Annotations["compute.amazonaws.com/instance-id"] != "" {
+		if err2 := p.computeManager.DeleteCompute(cleanupCtx, p, pod); err2 != nil {
+			klog.ErrorS(err2, "Error deleting compute while cleaning up failed CreatePod", "original error", err)
+		}
+	}
+
+	metaPod.updatePod(func(pod *corev1.Pod) {
+		delete(pod.Annotations, "compute.amazonaws.com/instance-id")
+		pod.Status.PodIP = ""
+		pod.Status.HostIP = ""
+	})
+}
+
+// recordLaunchAttempt adds a failed attempt to the pod's launch attempts annotation (in the cache and k8s)
+func (p *Ec2Provider) recordLaunchAttempt(ctx context.Context, metaPod *MetaPod, attempt launchAttempt) {
+	pod := metaPod.snapshot()
+
+	metaPod.launchAttempts = append(metaPod.launchAttempts, attempt)
+
+	history, err := json.Marshal(metaPod.launchAttempts)
+	if err != nil {
+		klog.ErrorS(err, "Unable to encode launch attempts", "pod", klog.KObj(pod))
+		return
+	}
+
+	metaPod.updatePod(func(pod *corev1.Pod) {
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		pod.Annotations[launchAttemptsAnnotation] = string(history)
+	})
+
+	// VK only syncs pod status to k8s, so annotations have to be patched directly
+	if p.k8sClient == nil {
+		return
+	}
+
+	err = p.k8sClient.PatchPodAnnotations(ctx, pod.Namespace, pod.Name,
+		map[string]string{launchAttemptsAnnotation: string(history)})
+	if err != nil {
+		klog.ErrorS(err, "Unable to update launch attempts annotation", "pod", klog.KObj(pod))
+	}
+}
+
+// provisioningFailed marks a pod Failed once its retry budget is exhausted.  The pod stays in the cache (so k8s keeps
+//
+//	the Failed status) until it is deleted.
+func (p *Ec2Provider) provisioningFailed(metaPod *MetaPod, conditionType corev1.PodConditionType, reason string,
+	err error, attempts int) {
+	pod := metaPod.snapshot()
+
+	klog.InfoS("Pod provisioning failed, giving up", "pod", klog.KObj(pod), "attempts", attempts,
+		"reason", reason, "error", err)
+	metrics.PodsLaunchFailed.Inc()
+	p.recordEvent(pod, corev1.EventTypeWarning, eventReasonProvisioningFailed,
+		"Provisioning failed after %d attempt(s): %v", attempts, err)
+
+	metaPod.updatePod(func(pod *corev1.Pod) {
+		setPodCondition(pod, conditionType, corev1.ConditionFalse, reason, err.Error())
+
+		pod.Status.Phase = corev1.PodFailed
+		pod.Status.Reason = reason
+		pod.Status.Message = fmt.Sprintf("Pod provisioning failed after %d attempt(s): %v", attempts, err)
+	})
+	p.notifyPod(metaPod)
+}
+
+// initProvisioningConditions sets the provisioning conditions of a newly created pod
+func initProvisioningConditions(pod *corev1.Pod) {
+	setPodCondition(pod, PodConditionEC2Launched, corev1.ConditionFalse, provisioningReasonInProgress,
+		"Launching instance")
+	setPodCondition(pod, PodConditionAgentConnected, corev1.ConditionFalse, provisioningReasonWaiting,
+		"Waiting for instance")
+	setPodCondition(pod, PodConditionApplicationLaunched, corev1.ConditionFalse, provisioningReasonWaiting,
+		"Waiting for VKVMAgent")
+}
+
+// setPodCondition adds or updates a pod condition.  The transition time only changes when the status does.
+func setPodCondition(pod *corev1.Pod, conditionType corev1.PodConditionType, status corev1.ConditionStatus,
+	reason string, message string) {
+	now := metav1.Now()
+
+	for i := range pod.Status.Conditions {
+		c := &pod.Status.Conditions[i]
+		if c.Type != conditionType {
+			continue
+		}
+
+		if c.Status != status {
+			c.LastTransitionTime = now
+		}
+		c.Status = status
+		c.Reason = reason
+		c.Message = message
+		c.LastProbeTime = now
+
+		return
+	}
+
+	pod.Status.Conditions = append(pod.Status.Conditions, corev1.PodCondition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastProbeTime:      now,
+		LastTransitionTime: now,
+	})
+}
@@ -0,0 +1,163 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package ec2provider
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/aws/aws-virtual-kubelet/internal/config"
+)
+
+// newFailingProvisioningProvider returns a provider whose pods can't be provisioned (its warm pool is configured but
+//
+//	empty), and that records events to recorder
+func newFailingProvisioningProvider(t *testing.T, maxAttempts int, recorder *record.FakeRecorder) *Ec2Provider {
+	initTestConfig(t, config.ProviderConfig{LaunchRetryConfig: config.LaunchRetryConfig{MaxAttempts: maxAttempts}})
+	resetWarmPoolState()
+
+	p := newTestProvider(&fakeEC2{})
+	p.warmPool.config = []config.WarmPoolConfig{{}}
+	p.eventRecorder = recorder
+
+	return p
+}
+
+// recordedEvents returns the events recorded so far with the given reason
+func recordedEvents(recorder *record.FakeRecorder, reason string) []string {
+	var events []string
+	for {
+		select {
+		case event := <-recorder.Events:
+			if strings.Contains(event, " "+reason+" ") {
+				events = append(events, event)
+			}
+		default:
+			return events
+		}
+	}
+}
+
+// podLaunchAttempts returns the launch attempts recorded in a pod's annotation
+func podLaunchAttempts(t *testing.T, pod *corev1.Pod) []launchAttempt {
+	t.Helper()
+
+	var attempts []launchAttempt
+	if history := pod.Annotations[launchAttemptsAnnotation]; history != "" {
+		if err := json.Unmarshal([]byte(history), &attempts); err != nil {
+			t.Fatalf("unable to decode launch attempts annotation %q: %v", history, err)
+		}
+	}
+	return attempts
+}
+
+func TestProvisionPodRetryBudget(t *testing.T) {
+	tests := []struct {
+		name         string
+		maxAttempts  int
+		wantAttempts int
+	}{
+		{
+			name:         "pod fails after a single attempt",
+			maxAttempts:  1,
+			wantAttempts: 1,
+		},
+		{
+			name:         "pod fails once its retry budget is exhausted",
+			maxAttempts:  3,
+			wantAttempts: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(100)
+			p := newFailingProvisioningProvider(t, tt.maxAttempts, recorder)
+			// NOTE zero backoff can't be configured (it's replaced by the default)
+			config.Config().LaunchRetryConfig.InitialBackoffSeconds = 0
+
+			metaPod := cacheTestPod(t, p, corev1.PodPending, "")
+			provisionCtx, cancel := context.WithCancel(metaPod.ctx)
+			defer cancel()
+			metaPod.startProvisioning(cancel)
+
+			p.provisionPod(metaPod.ctx, provisionCtx, metaPod)
+
+			if metaPod.isProvisioning() {
+				t.Error("expected provisioning to be done")
+			}
+
+			pod := metaPod.snapshot()
+			if pod.Status.Phase != corev1.PodFailed || pod.Status.Reason != provisioningReasonEC2LaunchFailed {
+				t.Errorf("expected the pod to have failed with reason %v, got %v (%v)",
+					provisioningReasonEC2LaunchFailed, pod.Status.Phase, pod.Status.Reason)
+			}
+			condition := podCondition(pod, PodConditionEC2Launched)
+			if condition == nil || condition.Status != corev1.ConditionFalse ||
+				condition.Reason != provisioningReasonEC2LaunchFailed {
+				t.Errorf("expected a failed EC2Launched condition, got %+v", condition)
+			}
+
+			attempts := podLaunchAttempts(t, pod)
+			if len(attempts) != tt.wantAttempts {
+				t.Fatalf("expected %v launch attempt(s) to be recorded, got %+v", tt.wantAttempts, attempts)
+			}
+			for i, attempt := range attempts {
+				if attempt.Attempt != i+1 || attempt.Stage != string(PodConditionEC2Launched) {
+					t.Errorf("expected attempt %v to fail at %v, got %+v", i+1, PodConditionEC2Launched, attempt)
+				}
+			}
+
+			if retries := recordedEvents(recorder, eventReasonProvisioningRetry); len(retries) != tt.wantAttempts-1 {
+				t.Errorf("expected %v retry event(s), got %v", tt.wantAttempts-1, retries)
+			}
+		})
+	}
+}
+
+func TestProvisionPodCancelledDuringBackoff(t *testing.T) {
+	recorder := record.NewFakeRecorder(100)
+	p := newFailingProvisioningProvider(t, 5, recorder)
+
+	metaPod := cacheTestPod(t, p, corev1.PodPending, "")
+	provisionCtx, cancel := context.WithCancel(metaPod.ctx)
+	defer cancel()
+	metaPod.startProvisioning(cancel)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		p.provisionPod(metaPod.ctx, provisionCtx, metaPod)
+	}()
+
+	// wait for the first attempt to fail (provisioning then waits out the default backoff), then cancel
+	for event := range recorder.Events {
+		if strings.Contains(event, " "+eventReasonProvisioningRetry+" ") {
+			break
+		}
+	}
+	cancel()
+	<-done
+
+	pod := metaPod.snapshot()
+	if pod.Status.Phase == corev1.PodFailed {
+		t.Error("expected a cancelled pod not to be marked Failed")
+	}
+	if attempts := podLaunchAttempts(t, pod); len(attempts) != 1 {
+		t.Errorf("expected 1 launch attempt before provisioning was cancelled, got %+v", attempts)
+	}
+	if failures := recordedEvents(recorder, eventReasonProvisioningFailed); len(failures) != 0 {
+		t.Errorf("expected no provisioning failure event, got %v", failures)
+	}
+}
@@ -0,0 +1,217 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package ec2provider
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"go.opentelemetry.io/otel/attribute"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/aws/aws-virtual-kubelet/internal/awsutils"
+	"github.com/aws/aws-virtual-kubelet/internal/config"
+	"github.com/aws/aws-virtual-kubelet/internal/metrics"
+	"github.com/aws/aws-virtual-kubelet/internal/poderrors"
+	"github.com/aws/aws-virtual-kubelet/internal/tracing"
+)
+
+// Warm pool instance tags used to recycle instances
+const (
+	// warmpoolConfigIndexTagKey is the index of the WarmPoolConfig an instance was launched from
+	warmpoolConfigIndexTagKey = "aws-virtual-kubelet/WarmpoolConfigIndex"
+	// warmpoolReuseCountTagKey is the number of times an instance has been recycled
+	warmpoolReuseCountTagKey = "aws-virtual-kubelet/WarmpoolReuseCount"
+)
+
+// defaultResetTimeout is used when WarmPoolConfig.ResetTimeoutSeconds isn't set
+const defaultResetTimeout = 600 * time.Second
+
+// recycleInstance resets an instance whose pod was deleted (or completed) via its VKVMAgent and returns it to the warm
+//
+//	pool.  Recycling is skipped unless the instance's WarmPoolConfig enables it (or force is set), and the instance
+//	must have been reused fewer than MaxReuseCount times.  Returns false if the instance wasn't recycled, in which case
+//	the caller should terminate it.
+func (wpm *WarmPoolManager) recycleInstance(ctx context.Context, instanceID string, privateIP string, force bool) (
+	recycled bool) {
+	if instanceID == "" || privateIP == "" {
+		return false
+	}
+
+	ctx, span := tracing.Start(ctx, "WarmPool.RecycleInstance", tracing.InstanceAttribute(instanceID))
+	defer func() {
+		span.SetAttributes(attribute.Bool("recycled", recycled))
+		tracing.End(span, nil)
+	}()
+
+	tags, err := wpm.instanceTags(ctx, instanceID)
+	if err != nil {
+		klog.ErrorS(err, "Unable to describe warm pool instance to recycle", "instance", instanceID)
+		return false
+	}
+
+	wpc, ok := wpm.instanceConfig(tags)
+	if !ok {
+		klog.InfoS("Warm pool instance has no matching config and can't be recycled", "instance", instanceID)
+		return false
+	}
+	if !wpc.Recycle && !force {
+		return false
+	}
+
+	// an instance without the tag (or with an invalid one) hasn't been reused yet
+	reuseCount, _ := strconv.Atoi(awsutils.GetTagValue(tags, warmpoolReuseCountTagKey))
+	if wpc.MaxReuseCount > 0 && reuseCount >= wpc.MaxReuseCount {
+		klog.InfoS("Warm pool instance reached its maximum reuse count", "instance", instanceID,
+			"reuseCount", reuseCount, "maxReuseCount", wpc.MaxReuseCount)
+		metrics.WarmEC2ReuseLimitReached.Inc()
+		return false
+	}
+
+	resetTimeout := time.Duration(wpc.ResetTimeoutSeconds) * time.Second
+	if resetTimeout == 0 {
+		resetTimeout = defaultResetTimeout
+	}
+
+	klog.InfoS("Resetting warm pool instance for reuse", "instance", instanceID, "reuseCount", reuseCount,
+		"timeout", resetTimeout)
+
+	resetCtx, cancel := context.WithTimeout(ctx, resetTimeout)
+	defer cancel()
+
+	if err = wpm.newAgentClient(privateIP).ResetInstance(resetCtx); err != nil {
+		klog.ErrorS(err, "Unable to reset warm pool instance (it will be terminated)", "instance", instanceID)
+		metrics.WarmEC2ResetErrors.Inc()
+		return false
+	}
+
+	if err = wpm.returnToPool(ctx, instanceID, privateIP, reuseCount+1); err != nil {
+		klog.ErrorS(err, "Unable to return warm pool instance to the pool (it will be terminated)",
+			"instance", instanceID)
+		return false
+	}
+
+	klog.InfoS("Recycled warm pool instance", "instance", instanceID, "reuseCount", reuseCount+1)
+	metrics.WarmEC2Recycled.Inc()
+
+	return true
+}
+
+// returnToPool removes the pod tags from a reset instance and moves it back through the PENDING_WARMPOOL_PROVISIONING
+//
+//	and Ready states (so an instance whose Ready transition is interrupted is still picked up by the next refresh)
+func (wpm *WarmPoolManager) returnToPool(ctx context.Context, instanceID string, privateIP string, reuseCount int) error {
+	var podTags []types.Tag
+	for _, tag := range awsutils.PodTags(&corev1.Pod{}) {
+		podTags = append(podTags, types.Tag{Key: tag.Key})
+	}
+	for _, key := range []string{
+		"aws-virtual-kubelet/WarmpoolPodName",
+		"aws-virtual-kubelet/WarmpoolPodNamespace",
+		"aws-virtual-kubelet/WarmpoolPodUID",
+	} {
+		podTags = append(podTags, types.Tag{Key: aws.String(key)})
+	}
+
+	_, err := wpm.ec2Client.DeleteTags(ctx, &ec2.DeleteTagsInput{
+		Resources: []string{instanceID},
+		Tags:      podTags,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = wpm.ec2Client.CreateTags(ctx, &ec2.CreateTagsInput{
+		Resources: []string{instanceID},
+		Tags: []types.Tag{
+			{Key: aws.String(warmpoolReuseCountTagKey), Value: aws.String(strconv.Itoa(reuseCount))},
+		},
+	})
+	if err != nil {
+		metrics.EC2TagCreationErrors.Inc()
+		return err
+	}
+
+	ec2Info := Ec2Info{InstanceID: instanceID, RetryCount: 0, PrivateIP: privateIP}
+
+	if err = wpm.updateEC2Tags(ctx, instanceID, initialSetup, corev1.Pod{}); err != nil {
+		return err
+	}
+	VKState.Lock()
+	delete(VKState.AllocatedEC2, instanceID)
+	VKState.ProvisioningEC2[instanceID] = ec2Info
+	VKState.Unlock()
+
+	if err = wpm.updateEC2Tags(ctx, instanceID, setReady, corev1.Pod{}); err != nil {
+		// the instance is reset, so leave it provisioning (the next refresh moves it to Ready)
+		return nil
+	}
+	VKState.Lock()
+	delete(VKState.ProvisioningEC2, instanceID)
+	VKState.ReadyEC2[instanceID] = ec2Info
+	VKState.Unlock()
+
+	return nil
+}
+
+// instanceTags returns the tags of an instance
+func (wpm *WarmPoolManager) instanceTags(ctx context.Context, instanceID string) ([]types.Tag, error) {
+	resp, err := wpm.ec2Client.DescribeInstances(ctx, &ec2.DescribeInstancesInput{
+		InstanceIds: []string{instanceID},
+	})
+	if err != nil {
+		metrics.DescribeEC2Errors.Inc()
+		return nil, err
+	}
+
+	for _, reservation := range resp.Reservations {
+		for _, instance := range reservation.Instances {
+			return instance.Tags, nil
+		}
+	}
+
+	return nil, poderrors.Newf(poderrors.CategoryNotFound, "instance %v not found", instanceID)
+}
+
+// instanceConfig returns the WarmPoolConfig an instance was launched from.  Instances launched before the config index
+//
+//	was tagged are assumed to belong to the first config.
+func (wpm *WarmPoolManager) instanceConfig(tags []types.Tag) (config.WarmPoolConfig, bool) {
+	index := 0
+	if value := awsutils.GetTagValue(tags, warmpoolConfigIndexTagKey); value != "" {
+		var err error
+		if index, err = strconv.Atoi(value); err != nil {
+			return config.WarmPoolConfig{}, false
+		}
+	}
+
+	if index < 0 || index >= len(wpm.config) {
+		return config.WarmPoolConfig{}, false
+	}
+
+	return wpm.config[index], true
+}
+
+// configIndex returns the index of a WarmPoolConfig in the warm pool manager's configs
+func (wpm *WarmPoolManager) configIndex(wpc config.WarmPoolConfig) int {
+	for i := range wpm.config {
+		if reflect.DeepEqual(wpm.config[i], wpc) {
+			return i
+		}
+	}
+
+	return 0
+}
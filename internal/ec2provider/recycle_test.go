@@ -0,0 +1,193 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package ec2provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/golang/mock/gomock"
+
+	"github.com/aws/aws-virtual-kubelet/internal/config"
+	"github.com/aws/aws-virtual-kubelet/internal/vkvmaclient"
+	mock_vkvmaclient "github.com/aws/aws-virtual-kubelet/mocks/vkvmaclient"
+)
+
+// resetWarmPoolState clears the warm pool's instance states
+func resetWarmPoolState() {
+	VKState.Lock()
+	defer VKState.Unlock()
+
+	VKState.ReadyEC2 = map[string]Ec2Info{}
+	VKState.ProvisioningEC2 = map[string]Ec2Info{}
+	VKState.UnhealthyEC2 = map[string]Ec2Info{}
+	VKState.AllocatedEC2 = map[string]Ec2Info{}
+}
+
+// warmPoolInstance returns a warm pool instance launched from the WarmPoolConfig at configIndex and reused reuseCount
+//
+//	times (tags that are empty are left unset)
+func warmPoolInstance(instanceID string, configIndex string, reuseCount string) types.Instance {
+	instance := types.Instance{InstanceId: aws.String(instanceID)}
+	if configIndex != "" {
+		instance.Tags = append(instance.Tags,
+			types.Tag{Key: aws.String(warmpoolConfigIndexTagKey), Value: aws.String(configIndex)})
+	}
+	if reuseCount != "" {
+		instance.Tags = append(instance.Tags,
+			types.Tag{Key: aws.String(warmpoolReuseCountTagKey), Value: aws.String(reuseCount)})
+	}
+	return instance
+}
+
+// newTestWarmPool returns a provider whose warm pool has the given configs, and resets its instances with client
+func newTestWarmPool(ec2Client *fakeUpdateEC2, configs []config.WarmPoolConfig,
+	client vkvmaclient.GrpcClient) *Ec2Provider {
+	p := newTestProvider(ec2Client)
+	p.warmPool.config = configs
+	p.warmPool.newAgentClient = func(string) vkvmaclient.GrpcClient { return client }
+
+	return p
+}
+
+func TestRecycleInstance(t *testing.T) {
+	tests := []struct {
+		name        string
+		configs     []config.WarmPoolConfig
+		instance    types.Instance
+		noPrivateIP bool
+		force       bool
+		resetErr    error
+		tagErr      error
+		// wantResets is the number of times the instance is reset
+		wantResets     int
+		wantRecycled   bool
+		wantReuseCount string
+	}{
+		{
+			name:           "recycles an instance whose pool recycles instances",
+			configs:        []config.WarmPoolConfig{{Recycle: true}},
+			instance:       warmPoolInstance("i-0123456789abcdef0", "0", ""),
+			wantResets:     1,
+			wantRecycled:   true,
+			wantReuseCount: "1",
+		},
+		{
+			name:           "uses the config the instance was launched from",
+			configs:        []config.WarmPoolConfig{{}, {Recycle: true, MaxReuseCount: 3}},
+			instance:       warmPoolInstance("i-0123456789abcdef0", "1", "2"),
+			wantResets:     1,
+			wantRecycled:   true,
+			wantReuseCount: "3",
+		},
+		{
+			name:     "doesn't recycle an instance whose pool doesn't recycle instances",
+			configs:  []config.WarmPoolConfig{{}},
+			instance: warmPoolInstance("i-0123456789abcdef0", "0", ""),
+		},
+		{
+			name:           "recycles an instance whose pool doesn't recycle instances if forced",
+			configs:        []config.WarmPoolConfig{{}},
+			instance:       warmPoolInstance("i-0123456789abcdef0", "0", "1"),
+			force:          true,
+			wantResets:     1,
+			wantRecycled:   true,
+			wantReuseCount: "2",
+		},
+		{
+			name:     "doesn't recycle an instance that reached its maximum reuse count",
+			configs:  []config.WarmPoolConfig{{Recycle: true, MaxReuseCount: 2}},
+			instance: warmPoolInstance("i-0123456789abcdef0", "0", "2"),
+			force:    true,
+		},
+		{
+			name:     "doesn't recycle an instance without a matching config",
+			configs:  []config.WarmPoolConfig{{Recycle: true}},
+			instance: warmPoolInstance("i-0123456789abcdef0", "1", ""),
+		},
+		{
+			name:     "doesn't recycle an instance that can't be found",
+			configs:  []config.WarmPoolConfig{{Recycle: true}},
+			instance: warmPoolInstance("i-0fedcba9876543210", "0", ""),
+		},
+		{
+			name:        "doesn't recycle an instance without a private IP",
+			configs:     []config.WarmPoolConfig{{Recycle: true}},
+			instance:    warmPoolInstance("i-0123456789abcdef0", "0", ""),
+			noPrivateIP: true,
+		},
+		{
+			name:       "doesn't recycle an instance that can't be reset",
+			configs:    []config.WarmPoolConfig{{Recycle: true}},
+			instance:   warmPoolInstance("i-0123456789abcdef0", "0", ""),
+			resetErr:   errors.New("ResetInstance failed"),
+			wantResets: 1,
+		},
+		{
+			name:       "doesn't recycle an instance whose pod tags can't be removed",
+			configs:    []config.WarmPoolConfig{{Recycle: true}},
+			instance:   warmPoolInstance("i-0123456789abcdef0", "0", ""),
+			tagErr:     errors.New("DeleteTags failed"),
+			wantResets: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTestConfig(t, config.ProviderConfig{})
+			resetWarmPoolState()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			client := mock_vkvmaclient.NewMockGrpcClient(ctrl)
+			client.EXPECT().ResetInstance(gomock.Any()).Return(tt.resetErr).Times(tt.wantResets)
+
+			ec2Client := &fakeUpdateEC2{
+				fakeEC2:     &fakeEC2{instances: []types.Instance{tt.instance}},
+				err:         tt.tagErr,
+				createdTags: map[string]string{},
+			}
+			p := newTestWarmPool(ec2Client, tt.configs, client)
+
+			privateIP := "10.0.0.1"
+			if tt.noPrivateIP {
+				privateIP = ""
+			}
+
+			recycled := p.warmPool.recycleInstance(context.Background(), "i-0123456789abcdef0", privateIP, tt.force)
+			if recycled != tt.wantRecycled {
+				t.Fatalf("recycleInstance() = %v, want %v", recycled, tt.wantRecycled)
+			}
+			if !recycled {
+				return
+			}
+
+			if reuseCount := ec2Client.createdTags[warmpoolReuseCountTagKey]; reuseCount != tt.wantReuseCount {
+				t.Errorf("expected reuse count tag %v, got %v", tt.wantReuseCount, reuseCount)
+			}
+			if status := ec2Client.createdTags["aws-virtual-kubelet/WarmpoolStatus"]; status != operationReady {
+				t.Errorf("expected the recycled instance to be tagged Ready, got %v", status)
+			}
+			if len(ec2Client.deletedTags) == 0 {
+				t.Error("expected the recycled instance's pod tags to be removed")
+			}
+
+			VKState.Lock()
+			ready, ok := VKState.ReadyEC2["i-0123456789abcdef0"]
+			VKState.Unlock()
+			if !ok || ready.PrivateIP != privateIP {
+				t.Errorf("expected the recycled instance to be ready in the warm pool, got %+v", ready)
+			}
+		})
+	}
+}
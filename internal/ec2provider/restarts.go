@@ -0,0 +1,473 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package ec2provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/aws/aws-virtual-kubelet/internal/health"
+	"github.com/aws/aws-virtual-kubelet/internal/metrics"
+	"github.com/aws/aws-virtual-kubelet/internal/utils"
+	"github.com/aws/aws-virtual-kubelet/internal/vkvmaclient"
+	vkvmagent_v0 "github.com/aws/aws-virtual-kubelet/proto/vkvmagent/v0"
+)
+
+// Container restart back-off (these match the kubelet's)
+const (
+	restartBackoffInitial    = 10 * time.Second
+	restartBackoffMax        = 300 * time.Second
+	restartBackoffMultiplier = 2
+	// restartBackoffReset is how long a container must run before its back-off is reset
+	restartBackoffReset = 10 * time.Minute
+)
+
+// Container waiting reasons
+const (
+	containerReasonCrashLoopBackOff  = "CrashLoopBackOff"
+	containerReasonContainerCreating = "ContainerCreating"
+)
+
+// restartState tracks the restarts of a pod's containers.  The VKVMAgent reports container states, but restart counts
+//
+//	(and pending restarts) are only known to the provider.
+type restartState struct {
+	sync.Mutex
+	// restartCounts is the number of times each container has been restarted
+	restartCounts map[string]int32
+	// lastTerminations is the most recent terminated state of each restarted container
+	lastTerminations map[string]corev1.ContainerStateTerminated
+	// backoffAttempt is the number of consecutive restarts (used to calculate the back-off)
+	backoffAttempt int
+	// pending lists the containers waiting (in back-off) to be restarted
+	pending []string
+	// pendingMessage describes the pending restart
+	pendingMessage string
+	// completed is set once all containers have terminated and none will be restarted
+	completed bool
+}
+
+// newCheckHandler creates a check handler for a pod's monitors.  Each pod gets its own handler so the context passed to
+//
+//	the handler's functions is that of the pod's monitor (which is cancelled when the monitor is stopped).
+func (p *Ec2Provider) newCheckHandler() *health.CheckHandler {
+	handler := health.NewCheckHandler()
+	handler.SetPodStatusFunc(p.handleAppStatus)
+	handler.SetUnhealthyFunc(p.handleUnhealthy)
+
+	return handler
+}
+
+// handleAppStatus processes a pod status reported by a pod's VKVMAgent, restarting terminated containers according to
+//
+//	the pod's restart policy (with back-off).  Restart counts and provider conditions are carried over to the reported
+//	status before k8s is notified.
+func (p *Ec2Provider) handleAppStatus(ctx context.Context, pod *corev1.Pod, podStatus *corev1.PodStatus) {
+	metaPod := p.pods.Get(utils.GetPodCacheKey(pod.Namespace, pod.Name))
+	if metaPod == nil || !metaPod.hasPod(pod) {
+		klog.InfoS("Ignoring status for pod that is no longer cached", "pod", klog.KObj(pod))
+		return
+	}
+
+	rs := &metaPod.restarts
+	rs.Lock()
+	defer rs.Unlock()
+
+	if rs.completed {
+		// the pod's final status has already been reported
+		return
+	}
+
+	// the pod is read from a snapshot since it's updated concurrently (e.g. by UpdatePod or eviction)
+	pod = metaPod.snapshot()
+	keepProviderConditions(podStatus, pod.Status.Conditions)
+
+	for i := range podStatus.ContainerStatuses {
+		cs := &podStatus.ContainerStatuses[i]
+		cs.RestartCount = rs.restartCounts[cs.Name]
+		if lastTermination, ok := rs.lastTerminations[cs.Name]; ok && cs.LastTerminationState.Terminated == nil {
+			cs.LastTerminationState = corev1.ContainerState{Terminated: lastTermination.DeepCopy()}
+		}
+	}
+
+	if len(rs.pending) == 0 {
+		toRestart := containersToRestart(pod.Spec.RestartPolicy, podStatus)
+		if len(toRestart) > 0 {
+			backoff := rs.startRestart(pod, podStatus, toRestart)
+			p.recordEvent(pod, corev1.EventTypeWarning, eventReasonBackOff,
+				"Back-off %v restarting failed container(s) %v", backoff, strings.Join(toRestart, ", "))
+			p.goPod(metaPod, func() { p.restartApplication(metaPod.ctx, metaPod, backoff) })
+		} else if allContainersTerminated(pod, podStatus) {
+			podStatus.Phase = terminatedPodPhase(podStatus)
+			rs.completed = true
+		}
+	}
+
+	if len(rs.pending) > 0 {
+		// containers are reported as waiting (rather than terminated) until they are restarted
+		rs.setPendingStates(podStatus)
+		podStatus.Phase = corev1.PodRunning
+	}
+
+	metaPod.updatePod(func(pod *corev1.Pod) {
+		pod.Status = *podStatus
+	})
+	p.notifyPod(metaPod)
+
+	if rs.completed {
+		// the monitor can't be stopped from its own handler (Stop waits for the handler to exit)
+		p.goPod(metaPod, func() { p.completePod(metaPod) })
+	}
+}
+
+// startRestart records the terminated containers to restart and returns the back-off before restarting them
+func (rs *restartState) startRestart(pod *corev1.Pod, podStatus *corev1.PodStatus, containers []string) time.Duration {
+	if rs.restartCounts == nil {
+		rs.restartCounts = map[string]int32{}
+		rs.lastTerminations = map[string]corev1.ContainerStateTerminated{}
+	}
+
+	for _, cs := range podStatus.ContainerStatuses {
+		for _, name := range containers {
+			if cs.Name != name {
+				continue
+			}
+			terminated := *cs.State.Terminated
+			rs.lastTerminations[name] = terminated
+
+			// a container that ran long enough before terminating starts over with the initial back-off
+			if !terminated.StartedAt.IsZero() &&
+				terminated.FinishedAt.Sub(terminated.StartedAt.Time) >= restartBackoffReset {
+				rs.backoffAttempt = 0
+			}
+		}
+	}
+
+	rs.backoffAttempt++
+	backoff := utils.ExponentialBackoff(rs.backoffAttempt, restartBackoffInitial, restartBackoffMax,
+		restartBackoffMultiplier)
+
+	rs.pending = containers
+	rs.pendingMessage = fmt.Sprintf("back-off %v restarting failed container(s) %v in pod %v_%v", backoff,
+		strings.Join(containers, ", "), pod.Name, pod.Namespace)
+
+	klog.InfoS("Restarting terminated containers after back-off", "pod", klog.KObj(pod), "containers", containers,
+		"restartPolicy", pod.Spec.RestartPolicy, "backoff", backoff)
+
+	return backoff
+}
+
+// setPendingStates reports the containers pending restart as waiting in CrashLoopBackOff
+func (rs *restartState) setPendingStates(podStatus *corev1.PodStatus) {
+	for i := range podStatus.ContainerStatuses {
+		cs := &podStatus.ContainerStatuses[i]
+		for _, name := range rs.pending {
+			if cs.Name != name {
+				continue
+			}
+			if lastTermination, ok := rs.lastTerminations[name]; ok {
+				cs.LastTerminationState = corev1.ContainerState{Terminated: lastTermination.DeepCopy()}
+			}
+			cs.Ready = false
+			cs.State = corev1.ContainerState{
+				Waiting: &corev1.ContainerStateWaiting{
+					Reason:  containerReasonCrashLoopBackOff,
+					Message: rs.pendingMessage,
+				},
+			}
+		}
+	}
+}
+
+// restartApplication waits for the back-off, then relaunches the application on the pod's instance.  Failed relaunches
+//
+//	are retried (with increasing back-off) until they succeed or ctx (the pod monitor's context) is cancelled.
+func (p *Ec2Provider) restartApplication(ctx context.Context, metaPod *MetaPod, backoff time.Duration) {
+	pod := metaPod.snapshot()
+	rs := &metaPod.restarts
+
+	for {
+		select {
+		case <-ctx.Done():
+			klog.InfoS("Container restart cancelled", "pod", klog.KObj(pod))
+			return
+		case <-time.After(backoff):
+		}
+
+		err := p.relaunchApplication(ctx, metaPod.snapshot())
+
+		rs.Lock()
+		if err == nil {
+			metaPod.updatePod(rs.restarted)
+			rs.Unlock()
+			p.notifyPod(metaPod)
+			return
+		}
+
+		klog.ErrorS(err, "Error restarting containers", "pod", klog.KObj(pod), "containers", rs.pending)
+		metrics.LaunchApplicationErrors.Inc()
+
+		rs.backoffAttempt++
+		backoff = utils.ExponentialBackoff(rs.backoffAttempt, restartBackoffInitial, restartBackoffMax,
+			restartBackoffMultiplier)
+		rs.pendingMessage = fmt.Sprintf("back-off %v restarting failed container(s) %v in pod %v_%v", backoff,
+			strings.Join(rs.pending, ", "), pod.Name, pod.Namespace)
+		metaPod.updatePod(func(pod *corev1.Pod) {
+			rs.setPendingStates(&pod.Status)
+		})
+		rs.Unlock()
+
+		p.notifyPod(metaPod)
+	}
+}
+
+// relaunchApplication asks a pod's VKVMAgent to launch its application again (on the same instance)
+func (p *Ec2Provider) relaunchApplication(ctx context.Context, pod *corev1.Pod) error {
+	vkvmaClient := vkvmaclient.NewVkvmaPodClient(pod)
+
+	appClient, err := vkvmaClient.GetApplicationLifecycleClient(ctx)
+	if err != nil {
+		metrics.GRPCAppClientErrors.Inc()
+		return err
+	}
+
+	// NOTE LaunchApplicationResponse is currently empty (so we discard it)
+	_, err = appClient.LaunchApplication(ctx, &vkvmagent_v0.LaunchApplicationRequest{
+		Pod: pod,
+	})
+
+	return err
+}
+
+// restarted records that the pending containers were restarted and reports them as running
+func (rs *restartState) restarted(pod *corev1.Pod) {
+	now := metav1.Now()
+
+	for i := range pod.Status.ContainerStatuses {
+		cs := &pod.Status.ContainerStatuses[i]
+		for _, name := range rs.pending {
+			if cs.Name != name {
+				continue
+			}
+			rs.restartCounts[name]++
+			cs.RestartCount = rs.restartCounts[name]
+			cs.State = corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: now}}
+			metrics.ContainerRestarts.Inc()
+		}
+	}
+
+	klog.InfoS("Restarted containers", "pod", klog.KObj(pod), "containers", rs.pending)
+
+	rs.pending = nil
+	rs.pendingMessage = ""
+}
+
+// handleUnhealthy replaces the compute of a pod whose VKVMAgent is unhealthy (an unhealthy application is handled via
+//
+//	its reported container states instead)
+func (p *Ec2Provider) handleUnhealthy(ctx context.Context, pod *corev1.Pod, subject health.Subject) {
+	metaPod := p.pods.Get(utils.GetPodCacheKey(pod.Namespace, pod.Name))
+	if metaPod == nil || !metaPod.hasPod(pod) {
+		return
+	}
+
+	// a completed pod's instance may already be gone (and its containers won't run again anyway)
+	pod = metaPod.snapshot()
+	if podCompleted(pod) {
+		return
+	}
+
+	p.recordEvent(pod, corev1.EventTypeWarning, eventReasonUnhealthy, "Health check of %v is unhealthy", subject)
+
+	if subject != health.SubjectVkvma {
+		return
+	}
+
+	p.replaceCompute(metaPod)
+}
+
+// replaceCompute terminates a pod's instance and provisions the pod again (on a new instance).  Each container's
+//
+//	restart count is incremented since the replacement starts every container again.
+func (p *Ec2Provider) replaceCompute(metaPod *MetaPod) {
+	pod := metaPod.snapshot()
+
+	// the replacement is cancelled (like any provisioning) if the pod is deleted, and a pod being (re)provisioned has
+	//	no healthy compute to replace yet
+	provisionCtx, cancel := context.WithCancel(metaPod.ctx)
+	if !metaPod.startProvisioning(cancel) {
+		cancel()
+		return
+	}
+
+	klog.InfoS("Replacing compute for pod with unhealthy VKVMAgent", "pod", klog.KObj(pod),
+		"instance", pod.Annotations["compute.amazonaws.com/instance-id"])
+	metrics.ComputeReplacements.Inc()
+	p.recordEvent(pod, corev1.EventTypeWarning, eventReasonReplacingInstance,
+		"VKVMAgent is unhealthy, replacing instance %v", pod.Annotations["compute.amazonaws.com/instance-id"])
+
+	// the monitor can't be stopped from its own handler (Stop waits for the handler to exit), so replace asynchronously
+	p.goPod(metaPod, func() {
+		if err := p.stopPodMonitor(provisionCtx, metaPod); err != nil {
+			klog.ErrorS(err, "Could not stop pod monitoring", "pod", klog.KObj(pod))
+		}
+
+		if err := p.computeManager.DeleteCompute(metaPod.ctx, p, pod); err != nil {
+			klog.ErrorS(err, "Error deleting compute while replacing it", "pod", klog.KObj(pod))
+			if metaPod.ctx.Err() != nil {
+				// the pod is being deleted, so leave the instance annotated for DeletePod to terminate
+				metaPod.provisioningDone()
+				return
+			}
+		}
+
+		metaPod.restarts.Lock()
+		metaPod.updatePod(func(pod *corev1.Pod) {
+			delete(pod.Annotations, "compute.amazonaws.com/instance-id")
+
+			metaPod.restarts.replaced(pod)
+
+			pod.Status.Phase = corev1.PodPending
+			pod.Status.PodIP = ""
+			pod.Status.HostIP = ""
+			initProvisioningConditions(pod)
+		})
+		metaPod.restarts.Unlock()
+		p.notifyPod(metaPod)
+
+		// NOTE the monitor holds the cached pod itself (handleAppStatus and handleUnhealthy match it via hasPod)
+		monitor, err := health.NewPodMonitor(metaPod.pod, p.newCheckHandler())
+		if err != nil {
+			klog.ErrorS(err, "Can't create pod monitor", "pod", klog.KObj(pod))
+		} else {
+			metaPod.setMonitor(monitor)
+		}
+
+		p.provisionPod(metaPod.ctx, provisionCtx, metaPod)
+	})
+}
+
+// replaced records that every container was restarted (on a new instance) and reports them as waiting
+func (rs *restartState) replaced(pod *corev1.Pod) {
+	if rs.restartCounts == nil {
+		rs.restartCounts = map[string]int32{}
+		rs.lastTerminations = map[string]corev1.ContainerStateTerminated{}
+	}
+
+	unknown := unreportedContainerState(false, 0)
+	unknown.FinishedAt = metav1.Now()
+
+	setTerminatedContainerStatuses(pod, nil, unknown)
+
+	for i := range pod.Status.ContainerStatuses {
+		cs := &pod.Status.ContainerStatuses[i]
+		rs.restartCounts[cs.Name]++
+		rs.lastTerminations[cs.Name] = *cs.State.Terminated
+		cs.RestartCount = rs.restartCounts[cs.Name]
+		cs.LastTerminationState = cs.State
+		cs.State = corev1.ContainerState{
+			Waiting: &corev1.ContainerStateWaiting{
+				Reason:  containerReasonContainerCreating,
+				Message: "Replacing the pod's instance",
+			},
+		}
+		metrics.ContainerRestarts.Inc()
+	}
+
+	rs.pending = nil
+	rs.pendingMessage = ""
+}
+
+// containersToRestart returns the (sorted) names of terminated containers the restart policy says to restart
+func containersToRestart(restartPolicy corev1.RestartPolicy, podStatus *corev1.PodStatus) []string {
+	var containers []string
+	for _, cs := range podStatus.ContainerStatuses {
+		if cs.State.Terminated == nil {
+			continue
+		}
+
+		switch restartPolicy {
+		case corev1.RestartPolicyNever:
+			continue
+		case corev1.RestartPolicyOnFailure:
+			if cs.State.Terminated.ExitCode == 0 {
+				continue
+			}
+		}
+
+		containers = append(containers, cs.Name)
+	}
+
+	sort.Strings(containers)
+
+	return containers
+}
+
+// allContainersTerminated returns true if every container in the pod spec has a terminated status
+func allContainersTerminated(pod *corev1.Pod, podStatus *corev1.PodStatus) bool {
+	terminated := map[string]bool{}
+	for _, cs := range podStatus.ContainerStatuses {
+		if cs.State.Terminated != nil {
+			terminated[cs.Name] = true
+		}
+	}
+
+	for _, container := range pod.Spec.Containers {
+		if !terminated[container.Name] {
+			return false
+		}
+	}
+
+	return len(pod.Spec.Containers) > 0
+}
+
+// terminatedPodPhase returns Succeeded if every (terminated) container exited successfully and Failed otherwise
+func terminatedPodPhase(podStatus *corev1.PodStatus) corev1.PodPhase {
+	for _, cs := range podStatus.ContainerStatuses {
+		if cs.State.Terminated != nil && cs.State.Terminated.ExitCode != 0 {
+			return corev1.PodFailed
+		}
+	}
+
+	return corev1.PodSucceeded
+}
+
+// keepProviderConditions adds the provider's own pod conditions (which the VKVMAgent doesn't know about) to a reported
+//
+//	status
+func keepProviderConditions(podStatus *corev1.PodStatus, conditions []corev1.PodCondition) {
+	for _, condition := range conditions {
+		switch condition.Type {
+		case PodConditionEC2Launched, PodConditionAgentConnected, PodConditionApplicationLaunched,
+			PodConditionInstanceUpdated, PodConditionDisruptionTarget:
+		default:
+			continue
+		}
+
+		found := false
+		for _, reported := range podStatus.Conditions {
+			if reported.Type == condition.Type {
+				found = true
+				break
+			}
+		}
+		if !found {
+			podStatus.Conditions = append(podStatus.Conditions, condition)
+		}
+	}
+}
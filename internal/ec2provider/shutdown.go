@@ -0,0 +1,100 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package ec2provider
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// tracingFlushTimeout bounds flushing traces when Shutdown's own context is already done
+const tracingFlushTimeout = 5 * time.Second
+
+// goProvider runs f in a goroutine tracked by the provider's WaitGroup (so Shutdown waits for it).  f should return
+//
+//	once the provider's root context is cancelled.
+func (p *Ec2Provider) goProvider(f func()) {
+	p.waitGroup.Add(1)
+	go func() {
+		defer p.waitGroup.Done()
+		f()
+	}()
+}
+
+// goPod runs f in a goroutine tracked by both the pod's and the provider's WaitGroups.  f should return once the pod's
+//
+//	context is cancelled.  Nothing is started for a pod whose context was already cancelled (i.e. it is being deleted).
+func (p *Ec2Provider) goPod(metaPod *MetaPod, f func()) {
+	if !metaPod.trackGoroutine() {
+		klog.InfoS("Not starting work for pod that is being deleted", "pod", klog.KObj(metaPod.snapshot()))
+		return
+	}
+
+	p.goProvider(func() {
+		defer metaPod.waitGroup.Done()
+		f()
+	})
+}
+
+// Shutdown cancels the provider's root context (and so every pod's context), stops pod monitoring, then waits for the
+//
+//	provider's goroutines to exit (or ctx to be done) and flushes traces.  Pods' instances are left running so they
+//	can be adopted when the provider restarts.
+func (p *Ec2Provider) Shutdown(ctx context.Context) error {
+	klog.Info("Shutting down provider")
+
+	// export any buffered spans (even if goroutines didn't exit in time, since those spans are the most useful)
+	defer p.flushTraces(ctx)
+
+	p.cancel()
+
+	if p.pods != nil {
+		for _, metaPod := range p.pods.GetList() {
+			if monitor := metaPod.getMonitor(); monitor != nil {
+				monitor.Stop()
+			}
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		p.waitGroup.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		klog.ErrorS(ctx.Err(), "Timed out waiting for provider goroutines to exit")
+		return ctx.Err()
+	}
+
+	klog.Info("Provider shut down")
+	return nil
+}
+
+// flushTraces flushes and stops trace exporting, allowing tracingFlushTimeout for it if ctx is already done
+func (p *Ec2Provider) flushTraces(ctx context.Context) {
+	if p.shutdownTracing == nil {
+		return
+	}
+
+	if ctx.Err() != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.Background(), tracingFlushTimeout)
+		defer cancel()
+	}
+
+	if err := p.shutdownTracing(ctx); err != nil {
+		klog.ErrorS(err, "Unable to shut down tracing")
+	}
+}
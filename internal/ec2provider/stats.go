@@ -0,0 +1,276 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package ec2provider
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/virtual-kubelet/virtual-kubelet/node/api/statsv1alpha1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/aws/aws-virtual-kubelet/internal/config"
+	"github.com/aws/aws-virtual-kubelet/internal/metrics"
+	"github.com/aws/aws-virtual-kubelet/internal/vkvmaclient"
+	vkvmagent_v0 "github.com/aws/aws-virtual-kubelet/proto/vkvmagent/v0"
+)
+
+// GetStatsSummary collects stats from the VKVMAgent of every pod with compute assigned and assembles them into a
+//
+//	summary.  Since each pod runs on its own instance, node-level stats are the totals of all pod (instance) stats.
+func (p *Ec2Provider) GetStatsSummary(ctx context.Context) (*statsv1alpha1.Summary, error) {
+	cfg := config.Config().StatsConfig
+
+	var pods []*corev1.Pod
+	for _, pod := range p.pods.GetPodList() {
+		// pods without compute have no agent to ask (yet)
+		if pod.Status.PodIP != "" {
+			pods = append(pods, pod)
+		}
+	}
+
+	// each goroutine writes only to its own index, so no locking is needed for results
+	results := make([]*statsv1alpha1.PodStats, len(pods))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, cfg.MaxConcurrentRequests)
+
+	for i, pod := range pods {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return nil, ctx.Err()
+		}
+
+		wg.Add(1)
+		go func(i int, pod *corev1.Pod) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			podCtx, cancel := context.WithTimeout(ctx, time.Duration(cfg.TimeoutSeconds)*time.Second)
+			defer cancel()
+
+			results[i] = getPodStats(podCtx, pod)
+		}(i, pod)
+	}
+
+	wg.Wait()
+
+	summary := &statsv1alpha1.Summary{
+		Node: statsv1alpha1.NodeStats{
+			NodeName:  p.NodeName,
+			StartTime: metav1.NewTime(p.startTime),
+		},
+		Pods: []statsv1alpha1.PodStats{},
+	}
+
+	for _, podStats := range results {
+		if podStats == nil {
+			continue
+		}
+		summary.Pods = append(summary.Pods, *podStats)
+	}
+
+	addNodeTotals(&summary.Node, summary.Pods)
+
+	return summary, nil
+}
+
+// getPodStats requests stats from a pod's VKVMAgent (returns nil if they couldn't be retrieved)
+func getPodStats(ctx context.Context, pod *corev1.Pod) *statsv1alpha1.PodStats {
+	vkvmaClient := vkvmaclient.NewVkvmaPodClient(pod)
+
+	resp, err := vkvmaClient.GetStats(ctx)
+	if err != nil {
+		klog.ErrorS(err, "Error getting stats for pod", "pod", klog.KObj(pod))
+		metrics.GetStatsErrors.Inc()
+		return nil
+	}
+
+	return newPodStats(pod, resp)
+}
+
+// newPodStats converts VKVMAgent stats into pod stats
+func newPodStats(pod *corev1.Pod, resp *vkvmagent_v0.GetStatsResponse) *statsv1alpha1.PodStats {
+	instance := resp.GetInstance()
+
+	podStats := &statsv1alpha1.PodStats{
+		PodRef: statsv1alpha1.PodReference{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+			UID:       string(pod.UID),
+		},
+		StartTime:        newTime(instance.GetStartTime()),
+		Containers:       []statsv1alpha1.ContainerStats{},
+		CPU:              newCPUStats(instance.GetCpu()),
+		Memory:           newMemoryStats(instance.GetMemory()),
+		Network:          newNetworkStats(instance.GetNetwork()),
+		EphemeralStorage: newFsStats(instance.GetFs()),
+	}
+
+	// prefer the pod's start time (as kubelet does) when it is known
+	if pod.Status.StartTime != nil {
+		podStats.StartTime = *pod.Status.StartTime
+	}
+
+	for _, c := range resp.GetContainers() {
+		podStats.Containers = append(podStats.Containers, statsv1alpha1.ContainerStats{
+			Name:      c.GetName(),
+			StartTime: newTime(c.GetStartTime()),
+			CPU:       newCPUStats(c.GetCpu()),
+			Memory:    newMemoryStats(c.GetMemory()),
+			Rootfs:    newFsStats(c.GetRootfs()),
+			Logs:      newFsStats(c.GetLogs()),
+		})
+	}
+
+	return podStats
+}
+
+// addNodeTotals sets the node's CPU, memory, network, and filesystem stats to the totals of the passed-in pod stats
+func addNodeTotals(node *statsv1alpha1.NodeStats, pods []statsv1alpha1.PodStats) {
+	now := metav1.Now()
+
+	cpu := &statsv1alpha1.CPUStats{Time: now, UsageNanoCores: new(uint64), UsageCoreNanoSeconds: new(uint64)}
+	memory := &statsv1alpha1.MemoryStats{Time: now, AvailableBytes: new(uint64), UsageBytes: new(uint64),
+		WorkingSetBytes: new(uint64), RSSBytes: new(uint64), PageFaults: new(uint64), MajorPageFaults: new(uint64)}
+	network := &statsv1alpha1.NetworkStats{Time: now, InterfaceStats: statsv1alpha1.InterfaceStats{
+		RxBytes: new(uint64), RxErrors: new(uint64), TxBytes: new(uint64), TxErrors: new(uint64)}}
+	fs := &statsv1alpha1.FsStats{Time: now, AvailableBytes: new(uint64), CapacityBytes: new(uint64),
+		UsedBytes: new(uint64), InodesFree: new(uint64), Inodes: new(uint64), InodesUsed: new(uint64)}
+
+	for _, pod := range pods {
+		if pod.CPU != nil {
+			addTo(cpu.UsageNanoCores, pod.CPU.UsageNanoCores)
+			addTo(cpu.UsageCoreNanoSeconds, pod.CPU.UsageCoreNanoSeconds)
+		}
+		if pod.Memory != nil {
+			addTo(memory.AvailableBytes, pod.Memory.AvailableBytes)
+			addTo(memory.UsageBytes, pod.Memory.UsageBytes)
+			addTo(memory.WorkingSetBytes, pod.Memory.WorkingSetBytes)
+			addTo(memory.RSSBytes, pod.Memory.RSSBytes)
+			addTo(memory.PageFaults, pod.Memory.PageFaults)
+			addTo(memory.MajorPageFaults, pod.Memory.MajorPageFaults)
+		}
+		if pod.Network != nil {
+			addTo(network.RxBytes, pod.Network.RxBytes)
+			addTo(network.RxErrors, pod.Network.RxErrors)
+			addTo(network.TxBytes, pod.Network.TxBytes)
+			addTo(network.TxErrors, pod.Network.TxErrors)
+		}
+		if pod.EphemeralStorage != nil {
+			addTo(fs.AvailableBytes, pod.EphemeralStorage.AvailableBytes)
+			addTo(fs.CapacityBytes, pod.EphemeralStorage.CapacityBytes)
+			addTo(fs.UsedBytes, pod.EphemeralStorage.UsedBytes)
+			addTo(fs.InodesFree, pod.EphemeralStorage.InodesFree)
+			addTo(fs.Inodes, pod.EphemeralStorage.Inodes)
+			addTo(fs.InodesUsed, pod.EphemeralStorage.InodesUsed)
+		}
+	}
+
+	node.CPU = cpu
+	node.Memory = memory
+	node.Network = network
+	node.Fs = fs
+}
+
+// addTo adds value (if set) to total
+func addTo(total *uint64, value *uint64) {
+	if value != nil {
+		*total += *value
+	}
+}
+
+func newTime(ts *timestamppb.Timestamp) metav1.Time {
+	if ts == nil {
+		return metav1.Time{}
+	}
+	return metav1.NewTime(ts.AsTime())
+}
+
+func newCPUStats(s *vkvmagent_v0.CpuStats) *statsv1alpha1.CPUStats {
+	if s == nil {
+		return nil
+	}
+	return &statsv1alpha1.CPUStats{
+		Time:                 newTime(s.GetTime()),
+		UsageNanoCores:       uint64Ptr(s.GetUsageNanoCores()),
+		UsageCoreNanoSeconds: uint64Ptr(s.GetUsageCoreNanoSeconds()),
+	}
+}
+
+func newMemoryStats(s *vkvmagent_v0.MemoryStats) *statsv1alpha1.MemoryStats {
+	if s == nil {
+		return nil
+	}
+	return &statsv1alpha1.MemoryStats{
+		Time:            newTime(s.GetTime()),
+		AvailableBytes:  uint64Ptr(s.GetAvailableBytes()),
+		UsageBytes:      uint64Ptr(s.GetUsageBytes()),
+		WorkingSetBytes: uint64Ptr(s.GetWorkingSetBytes()),
+		RSSBytes:        uint64Ptr(s.GetRssBytes()),
+		PageFaults:      uint64Ptr(s.GetPageFaults()),
+		MajorPageFaults: uint64Ptr(s.GetMajorPageFaults()),
+	}
+}
+
+func newFsStats(s *vkvmagent_v0.FsStats) *statsv1alpha1.FsStats {
+	if s == nil {
+		return nil
+	}
+	return &statsv1alpha1.FsStats{
+		Time:           newTime(s.GetTime()),
+		AvailableBytes: uint64Ptr(s.GetAvailableBytes()),
+		CapacityBytes:  uint64Ptr(s.GetCapacityBytes()),
+		UsedBytes:      uint64Ptr(s.GetUsedBytes()),
+		InodesFree:     uint64Ptr(s.GetInodesFree()),
+		Inodes:         uint64Ptr(s.GetInodes()),
+		InodesUsed:     uint64Ptr(s.GetInodesUsed()),
+	}
+}
+
+// newNetworkStats converts network stats, setting the (inline) default interface stats to the totals of all interfaces
+func newNetworkStats(s *vkvmagent_v0.NetworkStats) *statsv1alpha1.NetworkStats {
+	if s == nil {
+		return nil
+	}
+
+	network := &statsv1alpha1.NetworkStats{
+		Time: newTime(s.GetTime()),
+		InterfaceStats: statsv1alpha1.InterfaceStats{
+			RxBytes: new(uint64), RxErrors: new(uint64), TxBytes: new(uint64), TxErrors: new(uint64),
+		},
+	}
+
+	for _, iface := range s.GetInterfaces() {
+		network.Interfaces = append(network.Interfaces, statsv1alpha1.InterfaceStats{
+			Name:     iface.GetName(),
+			RxBytes:  uint64Ptr(iface.GetRxBytes()),
+			RxErrors: uint64Ptr(iface.GetRxErrors()),
+			TxBytes:  uint64Ptr(iface.GetTxBytes()),
+			TxErrors: uint64Ptr(iface.GetTxErrors()),
+		})
+		*network.RxBytes += iface.GetRxBytes()
+		*network.RxErrors += iface.GetRxErrors()
+		*network.TxBytes += iface.GetTxBytes()
+		*network.TxErrors += iface.GetTxErrors()
+	}
+
+	return network
+}
+
+func uint64Ptr(v uint64) *uint64 {
+	return &v
+}
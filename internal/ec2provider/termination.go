@@ -0,0 +1,190 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package ec2provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/aws/aws-virtual-kubelet/internal/metrics"
+	vkvmagent_v0 "github.com/aws/aws-virtual-kubelet/proto/vkvmagent/v0"
+)
+
+// Reasons for container termination states the VKVMAgent didn't report
+const (
+	containerReasonStatusUnknown       = "ContainerStatusUnknown"
+	containerReasonGracePeriodExceeded = "GracePeriodExceeded"
+)
+
+// exitCodeKilled is the exit code reported for containers that were killed (128 + SIGKILL)
+const exitCodeKilled = 137
+
+// terminationResponseMargin is added to the grace period when waiting for the VKVMAgent to report termination (to
+//
+//	allow for the request round trip)
+const terminationResponseMargin = 5 * time.Second
+
+// terminationGracePeriod returns how long (in seconds) a pod's application may take to stop.  The deletion grace period
+//
+//	(e.g. from `kubectl delete --grace-period`) takes precedence over the pod's terminationGracePeriodSeconds.
+func terminationGracePeriod(pod *corev1.Pod) int64 {
+	if pod.DeletionGracePeriodSeconds != nil {
+		return *pod.DeletionGracePeriodSeconds
+	}
+	if pod.Spec.TerminationGracePeriodSeconds != nil {
+		return *pod.Spec.TerminationGracePeriodSeconds
+	}
+
+	return corev1.DefaultTerminationGracePeriodSeconds
+}
+
+// preStopHandlers returns the preStop handlers of a pod's containers, keyed by container name
+func preStopHandlers(pod *corev1.Pod) map[string]*corev1.Handler {
+	handlers := map[string]*corev1.Handler{}
+	for i := range pod.Spec.Containers {
+		container := &pod.Spec.Containers[i]
+		if container.Lifecycle != nil && container.Lifecycle.PreStop != nil {
+			handlers[container.Name] = container.Lifecycle.PreStop
+		}
+	}
+
+	return handlers
+}
+
+// terminateApp asks the VKVMAgent to stop a pod's application gracefully and waits (up to the grace period) for it to
+//
+//	report the containers' final statuses
+func (p *Ec2Provider) terminateApp(
+	ctx context.Context, metaPod *MetaPod, gracePeriodSeconds int64) ([]corev1.ContainerStatus, error) {
+	pod := metaPod.snapshot()
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(gracePeriodSeconds)*time.Second+terminationResponseMargin)
+	defer cancel()
+
+	appClient, err := p.newPodClient(pod).GetApplicationLifecycleClient(ctx)
+	if err != nil {
+		klog.ErrorS(err, "Could not get ApplicationLifecycleClient", "pod", klog.KObj(pod))
+		metrics.GRPCAppClientErrors.Inc()
+		return nil, err
+	}
+
+	klog.InfoS("Terminating application", "pod", klog.KObj(pod), "gracePeriodSeconds", gracePeriodSeconds)
+
+	termAppResp, err := appClient.TerminateApplication(ctx, &vkvmagent_v0.TerminateApplicationRequest{
+		GracePeriodSeconds: gracePeriodSeconds,
+		PreStop:            preStopHandlers(pod),
+	})
+	if err != nil {
+		klog.ErrorS(err, "Could not Terminate Application", "pod", klog.KObj(pod))
+		return nil, err
+	}
+
+	var containerStatuses []corev1.ContainerStatus
+	for _, containerStatus := range termAppResp.GetContainerStatuses() {
+		if containerStatus != nil {
+			containerStatuses = append(containerStatuses, *containerStatus)
+		}
+	}
+
+	klog.InfoS("Application terminated", "pod", klog.KObj(pod), "containerStatuses", len(containerStatuses))
+
+	return containerStatuses, nil
+}
+
+// isDeadlineExceeded returns true if an error is due to a (gRPC or context) deadline expiring
+func isDeadlineExceeded(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded) || status.Code(err) == codes.DeadlineExceeded
+}
+
+// unreportedContainerState returns the terminated state used for containers whose final status wasn't reported
+func unreportedContainerState(gracePeriodExceeded bool, gracePeriodSeconds int64) corev1.ContainerStateTerminated {
+	if gracePeriodExceeded {
+		return corev1.ContainerStateTerminated{
+			ExitCode: exitCodeKilled,
+			Reason:   containerReasonGracePeriodExceeded,
+			Message: fmt.Sprintf("Container did not stop within the termination grace period (%ds) and was killed",
+				gracePeriodSeconds),
+		}
+	}
+
+	return corev1.ContainerStateTerminated{
+		ExitCode: exitCodeKilled,
+		Reason:   containerReasonStatusUnknown,
+		Message:  "The container could not be located when the pod was terminated",
+	}
+}
+
+// setTerminatedContainerStatuses sets a terminated status for each of a pod's containers, using the reported status
+//
+//	where there is one and the unreported state otherwise.  The pod phase is Succeeded if all containers exited
+//	successfully and Failed otherwise.
+func setTerminatedContainerStatuses(pod *corev1.Pod, reported []corev1.ContainerStatus,
+	unreported corev1.ContainerStateTerminated) {
+	now := metav1.Now()
+
+	reportedByName := map[string]corev1.ContainerStatus{}
+	for _, containerStatus := range reported {
+		reportedByName[containerStatus.Name] = containerStatus
+	}
+
+	existingByName := map[string]corev1.ContainerStatus{}
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		existingByName[containerStatus.Name] = containerStatus
+	}
+
+	succeeded := true
+	containerStatuses := make([]corev1.ContainerStatus, 0, len(pod.Spec.Containers))
+
+	for _, container := range pod.Spec.Containers {
+		containerStatus, ok := existingByName[container.Name]
+		if !ok {
+			containerStatus = corev1.ContainerStatus{
+				Name:  container.Name,
+				Image: container.Image,
+			}
+		}
+
+		terminated := unreported
+		if reportedStatus, ok := reportedByName[container.Name]; ok && reportedStatus.State.Terminated != nil {
+			terminated = *reportedStatus.State.Terminated
+		}
+		if terminated.StartedAt.IsZero() && containerStatus.State.Running != nil {
+			terminated.StartedAt = containerStatus.State.Running.StartedAt
+		}
+		if terminated.FinishedAt.IsZero() {
+			terminated.FinishedAt = now
+		}
+
+		if terminated.ExitCode != 0 {
+			succeeded = false
+		}
+
+		containerStatus.Ready = false
+		containerStatus.Started = nil
+		containerStatus.State = corev1.ContainerState{Terminated: &terminated}
+		containerStatuses = append(containerStatuses, containerStatus)
+	}
+
+	pod.Status.ContainerStatuses = containerStatuses
+
+	if succeeded {
+		pod.Status.Phase = corev1.PodSucceeded
+	} else {
+		pod.Status.Phase = corev1.PodFailed
+	}
+}
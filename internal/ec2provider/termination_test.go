@@ -0,0 +1,270 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package ec2provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/aws-virtual-kubelet/internal/config"
+	"github.com/aws/aws-virtual-kubelet/internal/vkvmaclient"
+	mock_vkvmagent_v0 "github.com/aws/aws-virtual-kubelet/mocks/generated/vkvmagent/v0"
+	mock_vkvmaclient "github.com/aws/aws-virtual-kubelet/mocks/vkvmaclient"
+	vkvmagent_v0 "github.com/aws/aws-virtual-kubelet/proto/vkvmagent/v0"
+)
+
+// terminatedStatus returns the status of a container that terminated with exitCode
+func terminatedStatus(name string, exitCode int32) corev1.ContainerStatus {
+	return corev1.ContainerStatus{
+		Name: name,
+		State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+			ExitCode: exitCode,
+			Reason:   "Completed",
+		}},
+	}
+}
+
+// useTerminatingAgent sets the provider's pod client to one whose VKVMAgent responds to TerminateApplication with
+//
+//	the given container statuses (or err, if set).  The returned request records what TerminateApplication was sent.
+func useTerminatingAgent(ctrl *gomock.Controller, p *Ec2Provider, statuses []*corev1.ContainerStatus,
+	err error) *vkvmagent_v0.TerminateApplicationRequest {
+	request := &vkvmagent_v0.TerminateApplicationRequest{}
+
+	appClient := mock_vkvmagent_v0.NewMockApplicationLifecycleClient(ctrl)
+	appClient.EXPECT().TerminateApplication(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, in *vkvmagent_v0.TerminateApplicationRequest, opts ...grpc.CallOption) (
+			*vkvmagent_v0.TerminateApplicationResponse, error) {
+			request.GracePeriodSeconds, request.PreStop = in.GracePeriodSeconds, in.PreStop
+			if err != nil {
+				return nil, err
+			}
+			return &vkvmagent_v0.TerminateApplicationResponse{ContainerStatuses: statuses}, nil
+		})
+
+	client := mock_vkvmaclient.NewMockGrpcClient(ctrl)
+	client.EXPECT().GetApplicationLifecycleClient(gomock.Any()).Return(appClient, nil)
+	p.newPodClient = func(*corev1.Pod) vkvmaclient.GrpcClient { return client }
+
+	return request
+}
+
+func TestTerminationGracePeriod(t *testing.T) {
+	deletionGracePeriod, specGracePeriod := int64(5), int64(60)
+
+	tests := []struct {
+		name string
+		pod  *corev1.Pod
+		want int64
+	}{
+		{
+			name: "deletion grace period takes precedence",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{DeletionGracePeriodSeconds: &deletionGracePeriod},
+				Spec:       corev1.PodSpec{TerminationGracePeriodSeconds: &specGracePeriod},
+			},
+			want: deletionGracePeriod,
+		},
+		{
+			name: "pod's grace period is used without a deletion grace period",
+			pod:  &corev1.Pod{Spec: corev1.PodSpec{TerminationGracePeriodSeconds: &specGracePeriod}},
+			want: specGracePeriod,
+		},
+		{
+			name: "default grace period is used otherwise",
+			pod:  &corev1.Pod{},
+			want: corev1.DefaultTerminationGracePeriodSeconds,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := terminationGracePeriod(tt.pod); got != tt.want {
+				t.Errorf("terminationGracePeriod() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTerminateApp(t *testing.T) {
+	initTestConfig(t, config.ProviderConfig{})
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p := newTestProvider(&fakeEC2{})
+	metaPod := cacheTestPod(t, p, corev1.PodRunning, "i-0123456789abcdef0")
+	metaPod.updatePod(func(pod *corev1.Pod) {
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{
+			Name: "sidecar",
+			Lifecycle: &corev1.Lifecycle{
+				PreStop: &corev1.Handler{Exec: &corev1.ExecAction{Command: []string{"drain"}}},
+			},
+		})
+	})
+
+	app := terminatedStatus("app", 0)
+	request := useTerminatingAgent(ctrl, p, []*corev1.ContainerStatus{&app, nil}, nil)
+
+	statuses, err := p.terminateApp(context.Background(), metaPod, 10)
+	if err != nil {
+		t.Fatalf("terminateApp: unexpected error %v", err)
+	}
+
+	if request.GracePeriodSeconds != 10 {
+		t.Errorf("expected a grace period of 10s to be requested, got %vs", request.GracePeriodSeconds)
+	}
+	if len(request.PreStop) != 1 || request.PreStop["sidecar"] == nil {
+		t.Errorf("expected the sidecar's preStop handler to be sent, got %v", request.PreStop)
+	}
+	if len(statuses) != 1 || statuses[0].Name != "app" {
+		t.Errorf("expected the reported container status (without nil statuses), got %+v", statuses)
+	}
+}
+
+func TestIsDeadlineExceeded(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "context deadline", err: fmt.Errorf("terminating: %w", context.DeadlineExceeded), want: true},
+		{name: "gRPC deadline", err: status.Error(codes.DeadlineExceeded, "deadline exceeded"), want: true},
+		{name: "gRPC unavailable", err: status.Error(codes.Unavailable, "connection refused")},
+		{name: "other error", err: errors.New("failed")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isDeadlineExceeded(tt.err); got != tt.want {
+				t.Errorf("isDeadlineExceeded() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetTerminatedContainerStatuses(t *testing.T) {
+	startedAt := metav1.NewTime(time.Now().Add(-time.Minute))
+
+	tests := []struct {
+		name       string
+		reported   []corev1.ContainerStatus
+		unreported corev1.ContainerStateTerminated
+		wantPhase  corev1.PodPhase
+		// wantReasons are the terminated reasons of the app and sidecar containers
+		wantReasons []string
+	}{
+		{
+			name:        "pod whose containers all exited successfully succeeded",
+			reported:    []corev1.ContainerStatus{terminatedStatus("app", 0), terminatedStatus("sidecar", 0)},
+			unreported:  unreportedContainerState(false, 0),
+			wantPhase:   corev1.PodSucceeded,
+			wantReasons: []string{"Completed", "Completed"},
+		},
+		{
+			name:        "pod with a failed container failed",
+			reported:    []corev1.ContainerStatus{terminatedStatus("app", 0), terminatedStatus("sidecar", 1)},
+			unreported:  unreportedContainerState(false, 0),
+			wantPhase:   corev1.PodFailed,
+			wantReasons: []string{"Completed", "Completed"},
+		},
+		{
+			name:        "pod with an unreported container failed",
+			reported:    []corev1.ContainerStatus{terminatedStatus("app", 0)},
+			unreported:  unreportedContainerState(false, 0),
+			wantPhase:   corev1.PodFailed,
+			wantReasons: []string{"Completed", containerReasonStatusUnknown},
+		},
+		{
+			name:        "pod that exceeded its grace period failed",
+			unreported:  unreportedContainerState(true, 30),
+			wantPhase:   corev1.PodFailed,
+			wantReasons: []string{containerReasonGracePeriodExceeded, containerReasonGracePeriodExceeded},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}, {Name: "sidecar"}}},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodRunning,
+					ContainerStatuses: []corev1.ContainerStatus{{
+						Name:  "app",
+						Ready: true,
+						State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: startedAt}},
+					}},
+				},
+			}
+
+			setTerminatedContainerStatuses(pod, tt.reported, tt.unreported)
+
+			if pod.Status.Phase != tt.wantPhase {
+				t.Errorf("expected phase %v, got %v", tt.wantPhase, pod.Status.Phase)
+			}
+			if len(pod.Status.ContainerStatuses) != 2 {
+				t.Fatalf("expected a status per container, got %+v", pod.Status.ContainerStatuses)
+			}
+			for i, containerStatus := range pod.Status.ContainerStatuses {
+				terminated := containerStatus.State.Terminated
+				if terminated == nil || terminated.Reason != tt.wantReasons[i] {
+					t.Errorf("expected container %v to be terminated with reason %v, got %+v", containerStatus.Name,
+						tt.wantReasons[i], containerStatus.State)
+					continue
+				}
+				if containerStatus.Ready || terminated.FinishedAt.IsZero() {
+					t.Errorf("expected container %v to be finished and not ready, got %+v", containerStatus.Name,
+						containerStatus)
+				}
+			}
+
+			// the running container's start time is kept
+			if started := pod.Status.ContainerStatuses[0].State.Terminated.StartedAt; !started.Equal(&startedAt) {
+				t.Errorf("expected the app container's start time %v to be kept, got %v", startedAt, started)
+			}
+		})
+	}
+}
+
+func TestDeletePodGracePeriodExceeded(t *testing.T) {
+	initTestConfig(t, config.ProviderConfig{})
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p := newTestProvider(&fakeEC2{})
+	var notified []*corev1.Pod
+	p.podNotifier = func(pod *corev1.Pod) { notified = append(notified, pod) }
+	metaPod := cacheTestPod(t, p, corev1.PodRunning, "")
+	useTerminatingAgent(ctrl, p, nil, status.Error(codes.DeadlineExceeded, "deadline exceeded"))
+
+	if err := p.DeletePod(context.Background(), metaPod.snapshot()); err != nil {
+		t.Fatalf("DeletePod: unexpected error %v", err)
+	}
+
+	if len(notified) == 0 {
+		t.Fatal("expected the deleted pod to be notified")
+	}
+	pod := notified[len(notified)-1]
+	if pod.Status.Phase != corev1.PodFailed || len(pod.Status.ContainerStatuses) != 1 {
+		t.Fatalf("expected the deleted pod to have failed with a status per container, got %+v", pod.Status)
+	}
+	if terminated := pod.Status.ContainerStatuses[0].State.Terminated; terminated == nil ||
+		terminated.Reason != containerReasonGracePeriodExceeded || terminated.ExitCode != exitCodeKilled {
+		t.Errorf("expected the container to have been killed after the grace period, got %+v", terminated)
+	}
+}
@@ -0,0 +1,261 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package ec2provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/aws/aws-virtual-kubelet/internal/awsutils"
+	"github.com/aws/aws-virtual-kubelet/internal/metrics"
+)
+
+// PodConditionInstanceUpdated reports whether the latest changes to a pod's compute annotations were applied to its
+//
+//	instance
+const PodConditionInstanceUpdated corev1.PodConditionType = "InstanceUpdated"
+
+// Instance update condition reasons
+const (
+	instanceUpdateReasonApplied             = "UpdateApplied"
+	instanceUpdateReasonFailed              = "UpdateFailed"
+	instanceUpdateReasonReplacementRequired = "ReplacementRequired"
+)
+
+// computeAnnotationPrefix is the prefix of annotations that configure a pod's instance
+const computeAnnotationPrefix = "compute.amazonaws.com/"
+
+// Compute annotations that can be applied to a running instance
+const (
+	securityGroupsAnnotation  = "compute.amazonaws.com/security-groups"
+	instanceProfileAnnotation = "compute.amazonaws.com/instance-profile"
+	tagsAnnotation            = "compute.amazonaws.com/tags"
+)
+
+// providerAnnotations are set by the provider rather than the pod's author.  They aren't compared when a pod is
+//
+//	updated, and the cached pod's values are kept (k8s's copy of the pod may not have them).
+var providerAnnotations = []string{
+	"compute.amazonaws.com/instance-id",
+	launchAttemptsAnnotation,
+	awsutils.ResolvedSpecAnnotation,
+}
+
+// updatePod applies changes to a cached pod's compute annotations to its instance, then merges the updated pod's
+//
+//	metadata and spec into the cached pod.  The cached pod object is updated in place since its pod monitor holds a
+//	reference to it (and its status is owned by the provider).  Changes that failed to apply keep the cached pod's
+//	previous values, so they're retried when the pod is next updated.
+func (p *Ec2Provider) updatePod(ctx context.Context, metaPod *MetaPod, updatedPod *corev1.Pod) {
+	pod := metaPod.snapshot()
+
+	var failed []string
+	changed := changedComputeAnnotations(pod.Annotations, updatedPod.Annotations)
+	if len(changed) > 0 {
+		failed = p.applyInstanceUpdates(ctx, metaPod, pod, updatedPod, changed)
+	}
+
+	metaPod.updatePod(func(pod *corev1.Pod) {
+		providerValues := map[string]string{}
+		for _, key := range providerAnnotations {
+			if value, ok := pod.Annotations[key]; ok {
+				providerValues[key] = value
+			}
+		}
+
+		retainedValues := map[string]string{}
+		var unset []string
+		for _, key := range failed {
+			if value, ok := pod.Annotations[key]; ok {
+				retainedValues[key] = value
+			} else {
+				unset = append(unset, key)
+			}
+		}
+
+		pod.ObjectMeta = *updatedPod.ObjectMeta.DeepCopy()
+		pod.Spec = *updatedPod.Spec.DeepCopy()
+
+		if len(providerValues)+len(retainedValues) > 0 && pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		for key, value := range providerValues {
+			pod.Annotations[key] = value
+		}
+		for key, value := range retainedValues {
+			pod.Annotations[key] = value
+		}
+		for _, key := range unset {
+			delete(pod.Annotations, key)
+		}
+	})
+}
+
+// applyInstanceUpdates applies changed compute annotations to a pod's instance and reports the outcome via the
+//
+//	InstanceUpdated condition.  Changes that can't be applied to a running instance are reported, not applied.
+//	Returns the annotations whose changes failed to apply.
+//
+// Settings are compared as resolved from the pod's compute profile and annotations (see awsutils.ResolveComputeSpec),
+//
+//	so removing an annotation reverts the instance to its profile's setting.  The instance's current settings are those
+//	it was launched with (recorded in its resolved spec annotation), plus the changes applied since.
+func (p *Ec2Provider) applyInstanceUpdates(ctx context.Context, metaPod *MetaPod, pod *corev1.Pod,
+	updatedPod *corev1.Pod, changed []string) []string {
+	instanceID := pod.Annotations["compute.amazonaws.com/instance-id"]
+	if instanceID == "" {
+		// the pod has no instance (yet), so there's nothing to update
+		return nil
+	}
+
+	ec2Client := p.computeManager.ec2Client
+
+	var applied, failed, unsupported []string
+	var errs []string
+
+	currentSpec := instanceSpec(pod)
+	updatedSpec, resolveErr := awsutils.ResolveComputeSpec(updatedPod)
+
+	for _, key := range changed {
+		var err error
+		switch {
+		case key != securityGroupsAnnotation && key != instanceProfileAnnotation && key != tagsAnnotation:
+			unsupported = append(unsupported, key)
+			continue
+		case resolveErr != nil:
+			// e.g. an invalid tags annotation (which may be fixed by a later update)
+			err = resolveErr
+		case key == securityGroupsAnnotation:
+			if len(updatedSpec.SecurityGroups) == 0 {
+				// an instance must have at least one security group
+				unsupported = append(unsupported, key)
+				continue
+			}
+			err = awsutils.UpdateInstanceSecurityGroups(ctx, ec2Client, instanceID, updatedSpec.SecurityGroups)
+		case key == instanceProfileAnnotation:
+			if updatedSpec.IamInstanceProfile == currentSpec.IamInstanceProfile {
+				// e.g. the annotation was removed, but it matched the profile's setting
+				break
+			}
+			if updatedSpec.IamInstanceProfile == "" || currentSpec.IamInstanceProfile == "" {
+				// associating or disassociating a profile isn't supported (only replacing one)
+				unsupported = append(unsupported, key)
+				continue
+			}
+			err = awsutils.UpdateInstanceProfile(ctx, ec2Client, instanceID, updatedSpec.IamInstanceProfile)
+		case key == tagsAnnotation:
+			err = awsutils.UpdateInstanceTags(ctx, ec2Client, instanceID, currentSpec.Tags, updatedSpec.Tags)
+		}
+
+		if err != nil {
+			klog.ErrorS(err, "Unable to apply annotation change to instance", "pod", klog.KObj(pod),
+				"instance", instanceID, "annotation", key)
+			metrics.InstanceUpdateErrors.Inc()
+			failed = append(failed, key)
+			errs = append(errs, fmt.Sprintf("%v: %v", key, err))
+			continue
+		}
+
+		applied = append(applied, key)
+	}
+
+	klog.InfoS("Applied pod annotation changes to instance", "pod", klog.KObj(pod), "instance", instanceID,
+		"applied", applied, "failed", failed, "unsupported", unsupported)
+
+	// record the instance's new settings, so later updates are compared with them
+	if len(applied) > 0 {
+		for _, key := range applied {
+			switch key {
+			case securityGroupsAnnotation:
+				currentSpec.SecurityGroups = updatedSpec.SecurityGroups
+			case instanceProfileAnnotation:
+				currentSpec.IamInstanceProfile = updatedSpec.IamInstanceProfile
+			case tagsAnnotation:
+				currentSpec.Tags = updatedSpec.Tags
+			}
+		}
+		p.recordComputeSpec(ctx, metaPod, currentSpec)
+	}
+
+	metaPod.updatePod(func(pod *corev1.Pod) {
+		switch {
+		case len(failed) > 0:
+			setPodCondition(pod, PodConditionInstanceUpdated, corev1.ConditionFalse, instanceUpdateReasonFailed,
+				fmt.Sprintf("Unable to apply changes to instance %v: %v", instanceID, strings.Join(errs, "; ")))
+		case len(unsupported) > 0:
+			setPodCondition(pod, PodConditionInstanceUpdated, corev1.ConditionFalse,
+				instanceUpdateReasonReplacementRequired,
+				fmt.Sprintf("Changes to %v can't be applied to running instance %v (the pod must be recreated)",
+					strings.Join(unsupported, ", "), instanceID))
+		default:
+			setPodCondition(pod, PodConditionInstanceUpdated, corev1.ConditionTrue, instanceUpdateReasonApplied,
+				fmt.Sprintf("Changes to %v applied to instance %v", strings.Join(applied, ", "), instanceID))
+		}
+	})
+
+	p.notifyPod(metaPod)
+
+	return failed
+}
+
+// instanceSpec returns the settings of a pod's instance: its resolved spec annotation if it has one, and otherwise the
+//
+//	spec resolved from the pod as it was before the update (e.g. for an instance launched before specs were recorded)
+func instanceSpec(pod *corev1.Pod) *awsutils.ComputeSpec {
+	if spec := awsutils.PodResolvedSpec(pod); spec != nil {
+		return spec
+	}
+
+	spec, err := awsutils.ResolveComputeSpec(pod)
+	if err != nil {
+		// an invalid annotation wasn't applied at launch, so it's treated as unset
+		return &awsutils.ComputeSpec{}
+	}
+	return spec
+}
+
+// changedComputeAnnotations returns the (sorted) compute annotations that were added, changed, or removed, excluding
+//
+//	provider annotations
+func changedComputeAnnotations(oldAnnotations map[string]string, newAnnotations map[string]string) []string {
+	keys := map[string]bool{}
+	for key := range oldAnnotations {
+		keys[key] = true
+	}
+	for key := range newAnnotations {
+		keys[key] = true
+	}
+
+	for _, key := range providerAnnotations {
+		delete(keys, key)
+	}
+
+	var changed []string
+	for key := range keys {
+		if !strings.HasPrefix(key, computeAnnotationPrefix) {
+			continue
+		}
+
+		oldValue, oldOk := oldAnnotations[key]
+		newValue, newOk := newAnnotations[key]
+		if oldOk != newOk || oldValue != newValue {
+			changed = append(changed, key)
+		}
+	}
+
+	sort.Strings(changed)
+
+	return changed
+}
@@ -0,0 +1,251 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package ec2provider
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws/aws-virtual-kubelet/internal/awsutils"
+	"github.com/aws/aws-virtual-kubelet/internal/config"
+)
+
+// fakeUpdateEC2 records instance tag and profile updates (failing them with err, if set)
+type fakeUpdateEC2 struct {
+	*fakeEC2
+	err         error
+	createdTags map[string]string
+	deletedTags []string
+	profiles    []string
+}
+
+func (f *fakeUpdateEC2) CreateTags(ctx context.Context, input *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	for _, tag := range input.Tags {
+		f.createdTags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	return &ec2.CreateTagsOutput{}, nil
+}
+
+func (f *fakeUpdateEC2) DeleteTags(ctx context.Context, input *ec2.DeleteTagsInput) (*ec2.DeleteTagsOutput, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	for _, tag := range input.Tags {
+		f.deletedTags = append(f.deletedTags, aws.ToString(tag.Key))
+	}
+	return &ec2.DeleteTagsOutput{}, nil
+}
+
+func (f *fakeUpdateEC2) DescribeIamInstanceProfileAssociations(ctx context.Context,
+	input *ec2.DescribeIamInstanceProfileAssociationsInput) (*ec2.DescribeIamInstanceProfileAssociationsOutput, error) {
+	return &ec2.DescribeIamInstanceProfileAssociationsOutput{
+		IamInstanceProfileAssociations: []types.IamInstanceProfileAssociation{{AssociationId: aws.String("iip-assoc")}},
+	}, nil
+}
+
+func (f *fakeUpdateEC2) ReplaceIamInstanceProfileAssociation(ctx context.Context,
+	input *ec2.ReplaceIamInstanceProfileAssociationInput) (*ec2.ReplaceIamInstanceProfileAssociationOutput, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.profiles = append(f.profiles, aws.ToString(input.IamInstanceProfile.Name))
+	return &ec2.ReplaceIamInstanceProfileAssociationOutput{}, nil
+}
+
+// podCondition returns a pod's condition of the given type (or nil if it has none)
+func podCondition(pod *corev1.Pod, conditionType corev1.PodConditionType) *corev1.PodCondition {
+	for i := range pod.Status.Conditions {
+		if pod.Status.Conditions[i].Type == conditionType {
+			return &pod.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+func TestUpdatePod(t *testing.T) {
+	profiles := map[string]config.ComputeProfile{
+		"general": {IamInstanceProfile: "general-profile", Tags: map[string]string{"team": "platform"}},
+	}
+
+	tests := []struct {
+		name string
+		// launched is the spec the pod's instance was launched with (recorded in its resolved spec annotation)
+		launched       *awsutils.ComputeSpec
+		annotations    map[string]string
+		newAnnotations map[string]string
+		updateErr      error
+		wantTags       map[string]string
+		wantDeleted    []string
+		wantProfiles   []string
+		wantReason     string
+		// wantAnnotations are the cached pod's annotations after the update
+		wantAnnotations map[string]string
+	}{
+		{
+			name:            "tags change is applied",
+			launched:        &awsutils.ComputeSpec{Profile: "general", Tags: map[string]string{"team": "platform"}},
+			newAnnotations:  map[string]string{tagsAnnotation: `{"app": "web"}`},
+			wantTags:        map[string]string{"app": "web"},
+			wantReason:      instanceUpdateReasonApplied,
+			wantAnnotations: map[string]string{tagsAnnotation: `{"app": "web"}`},
+		},
+		{
+			name:           "removed tag reverts to the compute profile's",
+			launched:       &awsutils.ComputeSpec{Profile: "general", Tags: map[string]string{"team": "web", "app": "web"}},
+			annotations:    map[string]string{tagsAnnotation: `{"team": "web", "app": "web"}`},
+			newAnnotations: map[string]string{},
+			wantTags:       map[string]string{"team": "platform"},
+			wantDeleted:    []string{"app"},
+			wantReason:     instanceUpdateReasonApplied,
+		},
+		{
+			name:           "removed instance profile reverts to the compute profile's",
+			launched:       &awsutils.ComputeSpec{Profile: "general", IamInstanceProfile: "custom-profile"},
+			annotations:    map[string]string{instanceProfileAnnotation: "custom-profile"},
+			newAnnotations: map[string]string{},
+			wantProfiles:   []string{"general-profile"},
+			wantReason:     instanceUpdateReasonApplied,
+		},
+		{
+			name:           "instance profile matching the launched profile isn't replaced",
+			launched:       &awsutils.ComputeSpec{Profile: "general", IamInstanceProfile: "general-profile"},
+			newAnnotations: map[string]string{instanceProfileAnnotation: "general-profile"},
+			wantReason:     instanceUpdateReasonApplied,
+		},
+		{
+			name:           "failed change keeps the previous annotation",
+			launched:       &awsutils.ComputeSpec{Profile: "general", IamInstanceProfile: "custom-profile"},
+			annotations:    map[string]string{instanceProfileAnnotation: "custom-profile"},
+			newAnnotations: map[string]string{instanceProfileAnnotation: "other-profile", "team": "web"},
+			updateErr:      errors.New("ReplaceIamInstanceProfileAssociation failed"),
+			wantReason:     instanceUpdateReasonFailed,
+			wantAnnotations: map[string]string{
+				instanceProfileAnnotation: "custom-profile",
+				"team":                    "web",
+			},
+		},
+		{
+			name:            "failed change to an unset annotation keeps it unset",
+			launched:        &awsutils.ComputeSpec{Profile: "general", Tags: map[string]string{"team": "platform"}},
+			newAnnotations:  map[string]string{tagsAnnotation: `{"app": "web"}`},
+			updateErr:       errors.New("CreateTags failed"),
+			wantReason:      instanceUpdateReasonFailed,
+			wantAnnotations: map[string]string{},
+		},
+		{
+			name:            "invalid tags annotation isn't applied",
+			launched:        &awsutils.ComputeSpec{Profile: "general", Tags: map[string]string{"team": "platform"}},
+			newAnnotations:  map[string]string{tagsAnnotation: "app=web"},
+			wantReason:      instanceUpdateReasonFailed,
+			wantAnnotations: map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTestConfig(t, config.ProviderConfig{ComputeProfiles: profiles, DefaultComputeProfile: "general"})
+
+			ec2Client := &fakeUpdateEC2{fakeEC2: &fakeEC2{}, err: tt.updateErr, createdTags: map[string]string{}}
+			p := newTestProvider(ec2Client)
+			metaPod := cacheTestPod(t, p, corev1.PodRunning, "i-0123456789abcdef0")
+			metaPod.updatePod(func(pod *corev1.Pod) {
+				pod.Annotations[awsutils.ResolvedSpecAnnotation] = tt.launched.String()
+				for key, value := range tt.annotations {
+					pod.Annotations[key] = value
+				}
+			})
+
+			updatedPod := metaPod.snapshot()
+			updatedPod.Annotations = map[string]string{}
+			for key, value := range tt.newAnnotations {
+				updatedPod.Annotations[key] = value
+			}
+
+			p.updatePod(context.Background(), metaPod, updatedPod)
+
+			wantTags := tt.wantTags
+			if wantTags == nil {
+				wantTags = map[string]string{}
+			}
+			if !reflect.DeepEqual(ec2Client.createdTags, wantTags) {
+				t.Errorf("expected tags %v to be created or updated, got %v", wantTags, ec2Client.createdTags)
+			}
+			if !reflect.DeepEqual(ec2Client.deletedTags, tt.wantDeleted) {
+				t.Errorf("expected tags %v to be deleted, got %v", tt.wantDeleted, ec2Client.deletedTags)
+			}
+			if !reflect.DeepEqual(ec2Client.profiles, tt.wantProfiles) {
+				t.Errorf("expected instance profile(s) %v, got %v", tt.wantProfiles, ec2Client.profiles)
+			}
+
+			pod := metaPod.snapshot()
+			condition := podCondition(pod, PodConditionInstanceUpdated)
+			if condition == nil || condition.Reason != tt.wantReason {
+				t.Errorf("expected InstanceUpdated condition with reason %v, got %+v", tt.wantReason, condition)
+			}
+
+			// provider annotations are kept
+			if pod.Annotations["compute.amazonaws.com/instance-id"] != "i-0123456789abcdef0" {
+				t.Errorf("expected the instance-id annotation to be kept, got %v", pod.Annotations)
+			}
+			if tt.wantAnnotations != nil {
+				for _, key := range providerAnnotations {
+					delete(pod.Annotations, key)
+				}
+				if !reflect.DeepEqual(pod.Annotations, tt.wantAnnotations) {
+					t.Errorf("expected annotations %v, got %v", tt.wantAnnotations, pod.Annotations)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdatePodRetriesFailedChanges(t *testing.T) {
+	initTestConfig(t, config.ProviderConfig{})
+
+	ec2Client := &fakeUpdateEC2{
+		fakeEC2:     &fakeEC2{},
+		err:         errors.New("CreateTags failed"),
+		createdTags: map[string]string{},
+	}
+	p := newTestProvider(ec2Client)
+	metaPod := cacheTestPod(t, p, corev1.PodRunning, "i-0123456789abcdef0")
+
+	updatedPod := metaPod.snapshot()
+	updatedPod.Annotations[tagsAnnotation] = `{"app": "web"}`
+
+	p.updatePod(context.Background(), metaPod, updatedPod)
+
+	// the same update is retried (and now succeeds)
+	ec2Client.err = nil
+	p.updatePod(context.Background(), metaPod, updatedPod)
+
+	if !reflect.DeepEqual(ec2Client.createdTags, map[string]string{"app": "web"}) {
+		t.Errorf("expected the tags change to be retried, got tags %v", ec2Client.createdTags)
+	}
+
+	pod := metaPod.snapshot()
+	if condition := podCondition(pod, PodConditionInstanceUpdated); condition == nil ||
+		condition.Reason != instanceUpdateReasonApplied {
+		t.Errorf("expected InstanceUpdated condition with reason %v, got %+v", instanceUpdateReasonApplied, condition)
+	}
+	spec := awsutils.PodResolvedSpec(pod)
+	if spec == nil || !reflect.DeepEqual(spec.Tags, map[string]string{"app": "web"}) {
+		t.Errorf("expected the applied tags to be recorded in the resolved spec, got %+v", spec)
+	}
+}
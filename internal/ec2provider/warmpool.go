@@ -12,6 +12,7 @@ package ec2provider
 import (
 	"context"
 	"math/rand"
+	"strconv"
 	"sync"
 	"time"
 
@@ -20,9 +21,12 @@ import (
 	"github.com/aws/aws-virtual-kubelet/internal/config"
 
 	"github.com/aws/aws-virtual-kubelet/internal/awsutils"
+	"github.com/aws/aws-virtual-kubelet/internal/tracing"
+	"github.com/aws/aws-virtual-kubelet/internal/vkvmaclient"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"go.opentelemetry.io/otel/attribute"
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
@@ -67,74 +71,82 @@ var (
 type WarmPoolManager struct {
 	config    []config.WarmPoolConfig
 	provider  *Ec2Provider
-	ec2Client *awsutils.Client
+	ec2Client awsutils.EC2API
+	s3Client  awsutils.S3API
+	// newAgentClient creates a VKVMAgent client for a warm pool instance (e.g. to reset it for reuse)
+	newAgentClient func(privateIP string) vkvmaclient.GrpcClient
 }
 
-func NewWarmPool(ctx context.Context, provider *Ec2Provider) (*WarmPoolManager, error) {
+func NewWarmPool(provider *Ec2Provider, ec2Client awsutils.EC2API, s3Client awsutils.S3API) *WarmPoolManager {
 	cfg := config.Config()
 
 	klog.Infof("Creating Warm Pool Manager with config '%+v'", cfg.WarmPoolConfig)
 
-	ec2Client, err := awsutils.NewEc2Client()
-	if err != nil {
-		klog.Errorf("Can't create Ec2 client in Warm Pool init: %v", err)
-		return nil, err
-	}
-
 	return &WarmPoolManager{
 		config:    cfg.WarmPoolConfig,
 		provider:  provider,
 		ec2Client: ec2Client,
-	}, nil
+		s3Client:  s3Client,
+		newAgentClient: func(privateIP string) vkvmaclient.GrpcClient {
+			return vkvmaclient.NewVkvmaClient(privateIP, config.Config().VKVMAgentConnectionConfig.Port)
+		},
+	}
 }
 
-func (wpm *WarmPoolManager) fillAndMaintain() {
+// fillAndMaintain creates the initial warm pool, then keeps it at depth (and refreshes its state) until ctx is cancelled
+func (wpm *WarmPoolManager) fillAndMaintain(ctx context.Context) {
 	//Generate Initial WarmPool
 	if len(wpm.config) > 0 {
 		klog.Info("Initializing Warmpool EC2")
-		wpm.InitialWarmPoolCreation()
+		wpm.InitialWarmPoolCreation(ctx)
 
 		klog.Info("Starting WarmPool Status Check Ticker")
 
-		go func() {
+		wpm.provider.goProvider(func() {
 			ticker := time.NewTicker(60 * time.Second)
+			defer ticker.Stop()
 			for {
 				select {
+				case <-ctx.Done():
+					return
 				case <-ticker.C:
 					for _, wpConfig := range wpm.config {
 						klog.Infof("Checking Warm Pool depth for config [?]")
-						wpm.CheckWarmPoolDepth(context.TODO(), wpConfig)
+						wpm.CheckWarmPoolDepth(ctx, wpConfig)
 					}
 				}
 			}
-		}()
+		})
 
-		go func() {
+		wpm.provider.goProvider(func() {
 			refreshStateTicker := time.NewTicker(60 * time.Minute)
+			defer refreshStateTicker.Stop()
 			for {
 				select {
+				case <-ctx.Done():
+					return
 				case <-refreshStateTicker.C:
 					for range wpm.config {
 						klog.Infof("Refreshing Warm Pool status from EC2 tags for config [?]")
-						wpm.RefreshWarmPoolFromEC2(context.TODO())
+						wpm.RefreshWarmPoolFromEC2(ctx)
 					}
 				}
 			}
-		}()
+		})
 	}
 }
 
 // InitialWarmPoolCreation generates the start-time WarmPool EC2 for Virtual Kubelet
-func (wpm *WarmPoolManager) InitialWarmPoolCreation() {
+func (wpm *WarmPoolManager) InitialWarmPoolCreation(ctx context.Context) {
 	klog.Info("Generating initial Warmpool Instances")
-	wpm.checkEC2TagsForState(context.TODO(), &ec2.DescribeInstancesInput{})
+	wpm.checkEC2TagsForState(ctx, &ec2.DescribeInstancesInput{})
 	for _, config := range wpm.config {
 		// 	//Check for existing EC2 to import
 		existingEC2 := len(VKState.ProvisioningEC2) + len(VKState.ReadyEC2)
 		// 	//Submit WarmEC2 function call, loop for DesiredCount
 		klog.Infof("Discovered %v existing EC2 for use, creating %v additional", existingEC2, config.DesiredCount-existingEC2)
 		for j := existingEC2; j < config.DesiredCount; j++ {
-			err := wpm.createWarmEC2(context.TODO(), config)
+			err := wpm.createWarmEC2(ctx, config)
 			if err != nil {
 				panic("This createWarmEC2 error wasn't originally handled...determine what to do here")
 			}
@@ -173,6 +185,7 @@ func (wpm *WarmPoolManager) populateEC2Tags(reason string, pod corev1.Pod) (tagS
 			Key:   aws.String("aws-virtual-kubelet/WarmpoolPodUID"),
 			Value: aws.String(string(pod.UID)),
 		})
+		tags = append(tags, awsutils.PodTags(&pod)...)
 		tagsInput[0].Tags = tags
 	} else if reason == setInUse {
 		value = operationPodInUse
@@ -197,6 +210,11 @@ func (wpm *WarmPoolManager) populateEC2Tags(reason string, pod corev1.Pod) (tagS
 func (wpm *WarmPoolManager) createWarmEC2(ctx context.Context, wpCfg config.WarmPoolConfig) error {
 	klog.Info("Creating Warmpool EC2 Instance")
 	tags := wpm.populateEC2Tags(initialSetup, corev1.Pod{})
+	// record which config the instance belongs to (recycling settings are looked up from it)
+	tags[0].Tags = append(tags[0].Tags, types.Tag{
+		Key:   aws.String(warmpoolConfigIndexTagKey),
+		Value: aws.String(strconv.Itoa(wpm.configIndex(wpCfg))),
+	})
 	instance, privateIP, instanceProfile, securityGroups, err := wpm.CreateWarmEC2(ctx, wpCfg, tags)
 
 	if err != nil {
@@ -208,12 +226,17 @@ func (wpm *WarmPoolManager) createWarmEC2(ctx context.Context, wpCfg config.Warm
 	return err
 }
 
-func (wpm *WarmPoolManager) updateEC2Tags(ctx context.Context, instanceID string, reason string, pod corev1.Pod) error {
+func (wpm *WarmPoolManager) updateEC2Tags(ctx context.Context, instanceID string, reason string, pod corev1.Pod) (
+	err error) {
+	ctx, span := tracing.Start(ctx, "WarmPool.UpdateTags", tracing.InstanceAttribute(instanceID),
+		attribute.String("reason", reason))
+	defer func() { tracing.End(span, err) }()
+
 	tags := wpm.populateEC2Tags(reason, pod)
 	input := ec2.CreateTagsInput{}
 	input.Resources = append(input.Resources, instanceID)
 	input.Tags = tags[0].Tags
-	_, err := wpm.ec2Client.CreateTags(ctx, &input)
+	_, err = wpm.ec2Client.CreateTags(ctx, &input)
 	if err != nil {
 		metrics.EC2TagCreationErrors.Inc()
 		klog.ErrorS(err, "error creating ec2 tag", "instanceID", instanceID, "reason", reason, "pod", klog.KObj(&pod))
@@ -223,10 +246,31 @@ func (wpm *WarmPoolManager) updateEC2Tags(ctx context.Context, instanceID string
 
 // CreateWarmEC2 Calls the EC2 RunInstancesAPI with values consistent for a WarmPool using wp and tags as input.
 func (wpm *WarmPoolManager) CreateWarmEC2(ctx context.Context, wpConfig config.WarmPoolConfig, tags []types.TagSpecification) (instanceID string, privateIP string, IAMProfileName string, SecurityGroups []string, err error) {
+	ctx, span := tracing.Start(ctx, "WarmPool.CreateInstance", attribute.String("instanceType", wpConfig.InstanceType))
+	defer func() {
+		if instanceID != "" {
+			span.SetAttributes(tracing.InstanceAttribute(instanceID))
+		}
+		tracing.End(span, err)
+	}()
+
 	cfg := config.Config()
 
+	// warm pool launches share the launch queue with pod launches (so filling the pool doesn't cause EC2 API
+	//	throttling either)
+	queueCtx, queueSpan := tracing.Start(ctx, "LaunchQueue.Admit")
+	release, err := wpm.provider.launchQueue.admit(queueCtx, func(position int) {
+		klog.V(1).InfoS("Warm pool launch is queued", "position", position)
+	})
+	tracing.End(queueSpan, err)
+	if err != nil {
+		return "", "", "", []string{""}, err
+	}
+	defer release()
+
 	finalUserData, err := awsutils.GenerateVKVMUserData(
 		ctx,
+		wpm.s3Client,
 		cfg.BootstrapAgent.S3Bucket,
 		cfg.BootstrapAgent.S3Key,
 		cfg.VMConfig.InitData,
@@ -234,18 +278,50 @@ func (wpm *WarmPoolManager) CreateWarmEC2(ctx context.Context, wpConfig config.W
 	)
 	if err != nil {
 		klog.Errorf("error while creating userdata : %v", err)
+		metrics.WarmEC2LaunchErrors.Inc()
+		return "", "", "", []string{""}, err
 	}
 
-	// select a random subnet from the configured list
+	// select a random subnet from the configured list (a launch template may provide the subnet instead)
 	s := len(wpConfig.Subnets)
-	if s == 0 {
+	var subnet string
+	if s > 0 {
+		r := rand.Intn(s) //nolint:gosec
+		subnet = wpConfig.Subnets[r]
+
+		klog.Infof("Randomly choosing subnet %v from %v subnets configured for Warm Pool", subnet, s)
+	} else if wpConfig.LaunchTemplate == "" {
 		klog.Error("1 or more Subnets must be configured for Warm Pool...skipping configuration")
 		return "", "", "", []string{""}, err
 	}
-	r := rand.Intn(s) //nolint:gosec
-	subnet := wpConfig.Subnets[r]
 
-	klog.Infof("Randomly choosing subnet %v from %v subnets configured for Warm Pool", subnet, s)
+	// the instance type and subnet that decide the dedicated host placement may come from the launch template
+	instanceType, hostSubnet, err := awsutils.ResolveTemplatePlacement(ctx, wpm.ec2Client, wpConfig.LaunchTemplate,
+		wpConfig.InstanceType, subnet)
+	if err != nil {
+		klog.Errorf("error while resolving launch template %v: %v", wpConfig.LaunchTemplate, err)
+		metrics.WarmEC2LaunchErrors.Inc()
+		return "", "", "", []string{""}, err
+	}
+
+	placement, launched, err := wpm.provider.hostPool.placement(ctx, instanceType, hostSubnet)
+	if err != nil {
+		klog.Errorf("error while getting a dedicated host for an EC2 instance: %v", err)
+		metrics.WarmEC2LaunchErrors.Inc()
+		return "", "", "", []string{""}, err
+	}
+
+	launchTemplateOpts, err := awsutils.LaunchTemplateOptions(wpConfig.LaunchTemplate)
+	if err != nil {
+		launched("")
+		return "", "", "", []string{""}, err
+	}
+	capacityTypeOpts, err := awsutils.CapacityTypeOptions(wpConfig.CapacityType)
+	if err != nil {
+		launched("")
+		return "", "", "", []string{""}, err
+	}
+	launchOpts := append(append(launchTemplateOpts, capacityTypeOpts...), placement...)
 
 	resp, err := awsutils.EC2RunInstancesUtil(
 		ctx,
@@ -258,8 +334,10 @@ func (wpm *WarmPoolManager) CreateWarmEC2(ctx context.Context, wpConfig config.W
 		tags,
 		finalUserData,
 		wpm.ec2Client,
+		launchOpts...,
 	)
 	if err != nil {
+		launched("")
 		klog.Errorf("error while generating an EC2 instance: %v", err)
 		metrics.WarmEC2LaunchErrors.Inc()
 		return "", "", "", []string{""}, err
@@ -267,6 +345,7 @@ func (wpm *WarmPoolManager) CreateWarmEC2(ctx context.Context, wpConfig config.W
 	klog.Infof("Created EC2 Instance ID in WarmPool: %v", *resp.Instances[0].InstanceId)
 	metrics.WarmEC2Launched.Inc()
 	instance := resp.Instances[0]
+	launched(aws.ToString(instance.InstanceId))
 
 	// collect list of security group ids
 	var sgs []string
@@ -377,8 +456,8 @@ func (wpm *WarmPoolManager) checkEC2TagsForState(ctx context.Context, input *ec2
 						} else if *instance.Tags[i].Value == operationUnhealthy {
 							VKState.UnhealthyEC2[*instance.InstanceId] = Ec2Info{InstanceID: *instance.InstanceId, RetryCount: 0, PrivateIP: *instance.PrivateIpAddress}
 						} else if *instance.Tags[i].Value == operationPendingPod || *instance.Tags[i].Value == operationPodInUse {
-							// Added to the Allocated state map. This state map isn't used anywhere. This can be used in the future should an instance assigned to a pod be refurbished.
-							// Right now, a pod deletion implies instance termination.
+							// Added to the Allocated state map. Instances are removed from it when they are recycled (see WarmPoolConfig.Recycle),
+							// otherwise a pod deletion implies instance termination.
 							VKState.AllocatedEC2[*instance.InstanceId] = Ec2Info{InstanceID: *instance.InstanceId, RetryCount: 0, PrivateIP: *instance.PrivateIpAddress}
 						}
 					}
@@ -403,8 +482,17 @@ func (wpm *WarmPoolManager) RefreshWarmPoolFromEC2(ctx context.Context) {
 func (wpm *WarmPoolManager) GetWarmPoolInstanceIfExist(ctx context.Context) (instanceID string, privateIP string, ok bool) {
 	klog.Infof("Checking for available Warm Pool instance")
 
+	ctx, span := tracing.Start(ctx, "WarmPool.GetInstance")
+	defer func() {
+		span.SetAttributes(attribute.Bool("found", ok))
+		if ok {
+			span.SetAttributes(tracing.InstanceAttribute(instanceID))
+		}
+		tracing.End(span, nil)
+	}()
+
 	// Refresh the states first before popping an instance from the Ready state map.
-	wpm.RefreshWarmPoolFromEC2(context.TODO())
+	wpm.RefreshWarmPoolFromEC2(ctx)
 
 	VKState.Lock()
 	defer VKState.Unlock()
@@ -413,14 +501,17 @@ func (wpm *WarmPoolManager) GetWarmPoolInstanceIfExist(ctx context.Context) (ins
 	}
 	var ec2 Ec2Info
 	ec2, VKState.ReadyEC2 = pop(VKState.ReadyEC2)
+	VKState.AllocatedEC2[ec2.InstanceID] = ec2
 	return ec2.InstanceID, ec2.PrivateIP, true
 }
 
 // TerminateInstance provides a way to terminate an EC2 instance.
 // To be explicitly used for Warmpool Management and prefer DeletePod once a Pod is set.
 func (wpm *WarmPoolManager) TerminateInstance(ctx context.Context, instanceID string) (resp string, err error) {
+	ctx, span := tracing.Start(ctx, "WarmPool.TerminateInstance", tracing.InstanceAttribute(instanceID))
+	defer func() { tracing.End(span, err) }()
 
-	resp, err = awsutils.TerminateEC2(ctx, instanceID)
+	resp, err = awsutils.TerminateEC2(ctx, wpm.ec2Client, instanceID)
 	return resp, err
 }
 
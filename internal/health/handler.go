@@ -23,11 +23,21 @@ type Handler interface {
 	receive(ctx context.Context, in chan interface{})
 }
 
+// PodStatusFunc processes a PodStatus received from a pod's VKVMAgent (the IP fields have already been corrected)
+type PodStatusFunc func(ctx context.Context, pod *corev1.Pod, podStatus *corev1.PodStatus)
+
+// UnhealthyFunc is called for each check result received while a monitor is unhealthy
+type UnhealthyFunc func(ctx context.Context, pod *corev1.Pod, subject Subject)
+
 type CheckHandler struct {
 	// in receives a checkResult to process
 	in chan *checkResult
 	// IsReceiving is true if handler receiver is currently running
 	IsReceiving bool
+	// podStatusFunc (optional) processes PodStatus check data instead of the default (replace the pod status and notify)
+	podStatusFunc PodStatusFunc
+	// unhealthyFunc (optional) is called for check results received while a monitor is unhealthy
+	unhealthyFunc UnhealthyFunc
 }
 
 // NewCheckHandler creates a new check handler instance
@@ -40,6 +50,16 @@ func NewCheckHandler() *CheckHandler {
 	return ch
 }
 
+// SetPodStatusFunc sets the function that processes PodStatus check data
+func (ch *CheckHandler) SetPodStatusFunc(f PodStatusFunc) {
+	ch.podStatusFunc = f
+}
+
+// SetUnhealthyFunc sets the function called for check results received while a monitor is unhealthy
+func (ch *CheckHandler) SetUnhealthyFunc(f UnhealthyFunc) {
+	ch.unhealthyFunc = f
+}
+
 // receive starts a goroutine to receive and process check results
 func (ch *CheckHandler) receive(ctx context.Context, wg *sync.WaitGroup) {
 	ch.IsReceiving = true
@@ -95,6 +115,10 @@ func (ch *CheckHandler) handleCheckResult(ctx context.Context, result *checkResu
 		default:
 			klog.InfoS("Unknown health check subject...ignoring", "monitor", monitor, "pod", klog.KObj(pod))
 		}
+
+		if ch.unhealthyFunc != nil {
+			ch.unhealthyFunc(ctx, pod, monitor.Subject)
+		}
 	}
 
 	if result.Data != nil {
@@ -109,6 +133,11 @@ func (ch *CheckHandler) handleCheckResult(ctx context.Context, result *checkResu
 			podStatus.PodIPs = pod.Status.PodIPs
 			podStatus.HostIP = pod.Status.HostIP
 
+			if ch.podStatusFunc != nil {
+				ch.podStatusFunc(ctx, pod, podStatus)
+				return
+			}
+
 			// update pod with combined status
 			pod.Status = *podStatus
 
@@ -373,3 +373,74 @@ func Test_checkHandlerWithUnknownData(t *testing.T) {
 	assert.Equal(t, "10.0.0.0", fakePod.Status.PodIP)
 	assert.Equal(t, []v1.PodIP{{IP: "0.0.0.0"}}, fakePod.Status.PodIPs)
 }
+
+func Test_checkHandlerWithPodStatusFunc(t *testing.T) {
+	// create minimum config
+	_ = config.InitConfig(&config.DirectLoader{DirectConfig: config.ProviderConfig{
+		HealthConfig: config.HealthConfig{
+			HealthCheckIntervalSeconds: 1,
+			UnhealthyThresholdCount:    1,
+		},
+	}})
+
+	var received *v1.PodStatus
+
+	handler := NewCheckHandler()
+	handler.SetPodStatusFunc(func(ctx context.Context, pod *v1.Pod, podStatus *v1.PodStatus) {
+		received = podStatus
+	})
+
+	// create fake pod with data
+	fakePod := &v1.Pod{
+		Status: v1.PodStatus{
+			Phase:  v1.PodUnknown,
+			HostIP: "127.0.0.1",
+			PodIP:  "10.0.0.0",
+		},
+	}
+
+	// create monitor for fake pod
+	m := NewMonitor(fakePod, SubjectApp, "TestMonitor", nil)
+
+	reportedPodStatus := &v1.PodStatus{
+		Phase:  v1.PodRunning,
+		HostIP: "replace-me",
+		PodIP:  "replace-me",
+	}
+
+	// generate a successful check result with status data
+	handler.handleCheckResult(context.TODO(), NewCheckResult(m, false, "Successful check result", reportedPodStatus))
+
+	// verify the status was passed to the function (with IPs corrected) instead of replacing the pod's status
+	assert.Same(t, reportedPodStatus, received)
+	assert.Equal(t, "10.0.0.0", received.PodIP)
+	assert.Equal(t, "127.0.0.1", received.HostIP)
+	assert.Equal(t, v1.PodUnknown, fakePod.Status.Phase)
+}
+
+func Test_checkHandlerWithUnhealthyFunc(t *testing.T) {
+	// create minimum config
+	_ = config.InitConfig(&config.DirectLoader{DirectConfig: config.ProviderConfig{
+		HealthConfig: config.HealthConfig{
+			HealthCheckIntervalSeconds: 1,
+			UnhealthyThresholdCount:    2,
+		},
+	}})
+
+	var subjects []Subject
+
+	handler := NewCheckHandler()
+	handler.SetUnhealthyFunc(func(ctx context.Context, pod *v1.Pod, subject Subject) {
+		subjects = append(subjects, subject)
+	})
+
+	fakePod := &v1.Pod{}
+	m := NewMonitor(fakePod, SubjectVkvma, "TestMonitor", nil)
+
+	// the first failure doesn't reach the unhealthy threshold
+	handler.handleCheckResult(context.TODO(), NewCheckResult(m, true, "Failed check result", nil))
+	assert.Empty(t, subjects)
+
+	handler.handleCheckResult(context.TODO(), NewCheckResult(m, true, "Failed check result", nil))
+	assert.Equal(t, []Subject{SubjectVkvma}, subjects)
+}
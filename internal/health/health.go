@@ -136,6 +136,12 @@ func (pm *PodMonitor) Start(ctx context.Context) {
 
 // Stop deactivates monitoring
 func (pm *PodMonitor) Stop() {
+	// a monitor that was never started (e.g. the pod was deleted while provisioning) has nothing to stop
+	if pm.cancel == nil {
+		klog.InfoS("Pod monitor was never started", "pod", klog.KObj(pm.pod))
+		return
+	}
+
 	klog.InfoS("Stopping pod monitor", "pod", klog.KObj(pm.pod))
 
 	// cancel the monitor(s)
@@ -148,3 +154,14 @@ func (pm *PodMonitor) Stop() {
 
 	klog.InfoS("All monitors cancelled", "pod", klog.KObj(pm.pod))
 }
+
+// AgentUnhealthy returns true if any of the pod's VKVMAgent monitors have reached the unhealthy threshold
+func (pm *PodMonitor) AgentUnhealthy() bool {
+	for _, m := range pm.Monitors {
+		if m.Subject == SubjectVkvma && m.getState() == MonitoringStateUnhealthy {
+			return true
+		}
+	}
+
+	return false
+}
@@ -49,3 +49,48 @@ func Test_setPodHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestPodMonitor_AgentUnhealthy(t *testing.T) {
+	tests := []struct {
+		name     string
+		monitors []*Monitor
+		want     bool
+	}{
+		{
+			name:     "No monitors",
+			monitors: nil,
+			want:     false,
+		},
+		{
+			name: "Healthy agent monitor",
+			monitors: []*Monitor{
+				{Subject: SubjectVkvma, State: MonitoringStateHealthy},
+				{Subject: SubjectApp, State: MonitoringStateUnhealthy},
+			},
+			want: false,
+		},
+		{
+			name: "Unhealthy agent monitor",
+			monitors: []*Monitor{
+				{Subject: SubjectVkvma, State: MonitoringStateUnhealthy},
+				{Subject: SubjectApp, State: MonitoringStateHealthy},
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pm := &PodMonitor{Monitors: tt.monitors}
+			if got := pm.AgentUnhealthy(); got != tt.want {
+				t.Errorf("AgentUnhealthy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPodMonitor_StopNotStarted(t *testing.T) {
+	pm := &PodMonitor{pod: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "default"}}}
+
+	// stopping a monitor that was never started must not panic (or block)
+	pm.Stop()
+}
@@ -2,15 +2,22 @@ package k8sutils
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"os"
 	"path/filepath"
 
 	v1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
 	typev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
 	"k8s.io/klog"
 )
 
@@ -65,3 +72,88 @@ func (client *k8sClient) GetPod(ctx context.Context, namespace string, podName s
 func (client *k8sClient) DeletePod(ctx context.Context, namespace string, podName string) error {
 	return client.Svc.Pods(namespace).Delete(ctx, podName, metav1.DeleteOptions{})
 }
+
+// EvictPod evicts a pod via the eviction API (which respects PodDisruptionBudgets), with the given grace period
+func (client *k8sClient) EvictPod(ctx context.Context, namespace string, podName string, gracePeriodSeconds int64) error {
+	return client.Svc.Pods(namespace).Evict(ctx, &policyv1beta1.Eviction{
+		ObjectMeta:    metav1.ObjectMeta{Namespace: namespace, Name: podName},
+		DeleteOptions: &metav1.DeleteOptions{GracePeriodSeconds: &gracePeriodSeconds},
+	})
+}
+
+// PatchPodAnnotations adds or updates annotations on a pod (other annotations are left unchanged)
+func (client *k8sClient) PatchPodAnnotations(
+	ctx context.Context, namespace string, podName string, annotations map[string]string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": annotations,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Svc.Pods(namespace).Patch(ctx, podName, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// AddPodFinalizer adds a finalizer to a pod (if it doesn't already have it), retrying if the pod is updated meanwhile
+func (client *k8sClient) AddPodFinalizer(ctx context.Context, namespace string, podName string, finalizer string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		pod, err := client.Svc.Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		for _, f := range pod.Finalizers {
+			if f == finalizer {
+				return nil
+			}
+		}
+
+		pod.Finalizers = append(pod.Finalizers, finalizer)
+		_, err = client.Svc.Pods(namespace).Update(ctx, pod, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// RemovePodFinalizer removes a finalizer from a pod (if it has it), retrying if the pod is updated meanwhile.  A pod
+//
+//	that no longer exists has nothing to remove.
+func (client *k8sClient) RemovePodFinalizer(
+	ctx context.Context, namespace string, podName string, finalizer string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		pod, err := client.Svc.Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+
+		var finalizers []string
+		for _, f := range pod.Finalizers {
+			if f != finalizer {
+				finalizers = append(finalizers, f)
+			}
+		}
+		if len(finalizers) == len(pod.Finalizers) {
+			return nil
+		}
+
+		pod.Finalizers = finalizers
+		_, err = client.Svc.Pods(namespace).Update(ctx, pod, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// NewEventRecorder creates a recorder that emits events from the given source to the k8s cluster.  Similar events are
+//
+//	aggregated and rate limited according to options (zero values use client-go's defaults).
+func (client *k8sClient) NewEventRecorder(
+	source v1.EventSource, options record.CorrelatorOptions) record.EventRecorder {
+	broadcaster := record.NewBroadcasterWithCorrelatorOptions(options)
+	broadcaster.StartRecordingToSink(&typev1.EventSinkImpl{Interface: client.Svc.Events("")})
+
+	return broadcaster.NewRecorder(scheme.Scheme, source)
+}
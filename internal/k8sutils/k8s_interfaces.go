@@ -4,6 +4,7 @@ import (
 	"context"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
 )
 
 // K8SAPI defines the interface for K8S API actions for either Mock or official AWS Client usage.
@@ -14,4 +15,14 @@ type K8SAPI interface {
 	GetPod(ctx context.Context, namespace string, podName string) (*v1.Pod, error)
 	// DeletePod deletes a pod from k8s cluster
 	DeletePod(ctx context.Context, namespace string, podName string) error
+	// EvictPod evicts a pod via the eviction API with the given grace period
+	EvictPod(ctx context.Context, namespace string, podName string, gracePeriodSeconds int64) error
+	// PatchPodAnnotations adds or updates annotations on a pod
+	PatchPodAnnotations(ctx context.Context, namespace string, podName string, annotations map[string]string) error
+	// AddPodFinalizer adds a finalizer to a pod (if it doesn't already have it)
+	AddPodFinalizer(ctx context.Context, namespace string, podName string, finalizer string) error
+	// RemovePodFinalizer removes a finalizer from a pod (if it has it)
+	RemovePodFinalizer(ctx context.Context, namespace string, podName string, finalizer string) error
+	// NewEventRecorder creates a recorder that emits events to the k8s cluster
+	NewEventRecorder(source v1.EventSource, options record.CorrelatorOptions) record.EventRecorder
 }
@@ -150,6 +150,260 @@ var (
 	})
 )
 
+var (
+	GetContainerLogsErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "vkec2_get_container_logs_grpc_errors_total",
+		Help: "The total number of grpc errors during get container logs",
+	})
+)
+
+var (
+	ExecErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "vkec2_exec_grpc_errors_total",
+		Help: "The total number of grpc errors during exec in container",
+	})
+)
+
+var (
+	GetStatsErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "vkec2_get_stats_grpc_errors_total",
+		Help: "The total number of grpc errors during get stats",
+	})
+)
+
+var (
+	NodeStatusCheckErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "vkec2_node_status_check_errors_total",
+		Help: "The total number of errors checking node (ENI) status",
+	})
+)
+
+var (
+	PodsRestoredFromEC2 = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "vkec2_pods_restored_from_ec2_total",
+		Help: "The total number of pods unknown to k8s restored to the pod cache from EC2 instance tags",
+	})
+)
+
+var (
+	OrphanedInstancesFound = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "vkec2_orphaned_instances_found_total",
+		Help: "The total number of orphaned pod instances found by the orphan collector",
+	})
+)
+
+var (
+	OrphanedInstancesTerminated = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "vkec2_orphaned_instances_terminated_total",
+		Help: "The total number of orphaned pod instances terminated by the orphan collector",
+	})
+)
+
+var (
+	OrphanCollectorErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "vkec2_orphan_collector_errors_total",
+		Help: "The total number of errors finding or terminating orphaned pod instances",
+	})
+)
+
+var (
+	PodLaunchRetries = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "vkec2_pod_launch_retries_total",
+		Help: "The total number of failed pod provisioning attempts that were retried",
+	})
+)
+
+var (
+	PodsLaunchFailed = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "vkec2_pods_launch_failed_total",
+		Help: "The total number of pods marked Failed after exhausting their provisioning retry budget",
+	})
+)
+
+var (
+	InstanceUpdateErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "vkec2_instance_update_errors_total",
+		Help: "The total number of errors applying pod annotation changes to pod instances",
+	})
+)
+
+var (
+	TerminationGracePeriodExceeded = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "vkec2_termination_grace_period_exceeded_total",
+		Help: "The total number of pods whose application didn't stop within the termination grace period",
+	})
+)
+
+var (
+	ContainerRestarts = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "vkec2_container_restarts_total",
+		Help: "The total number of container restarts (including restarts due to compute replacement)",
+	})
+)
+
+var (
+	ComputeReplacements = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "vkec2_compute_replacements_total",
+		Help: "The total number of pod instances replaced due to an unhealthy VKVMAgent",
+	})
+)
+
+var (
+	PodsCompleted = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "vkec2_pods_completed_total",
+		Help: "The total number of pods whose containers all terminated (and won't be restarted)",
+	})
+)
+
+var (
+	CompletedInstanceErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "vkec2_completed_instance_errors_total",
+		Help: "The total number of errors terminating or releasing the instances of completed pods",
+	})
+)
+
+var (
+	WarmEC2Recycled = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "vkec2_warm_ec2_recycled_total",
+		Help: "The total number of warm pool instances reset and returned to the pool after their pod was deleted",
+	})
+)
+
+var (
+	WarmEC2ResetErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "vkec2_warm_ec2_reset_errors_total",
+		Help: "The total number of warm pool instances terminated because they could not be reset for reuse",
+	})
+)
+
+var (
+	WarmEC2ReuseLimitReached = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "vkec2_warm_ec2_reuse_limit_reached_total",
+		Help: "The total number of warm pool instances terminated because they reached the maximum reuse count",
+	})
+)
+
+var (
+	ProviderErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "vkec2_provider_errors_total",
+		Help: "The total number of provider errors by category (e.g. capacity, throttling, or agent-unreachable)",
+	}, []string{"category"})
+)
+
+var (
+	DeletedPodsFinalized = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "vkec2_deleted_pods_finalized_total",
+		Help: "The total number of deleted pods whose instances were cleaned up by the finalizer reconciler",
+	})
+)
+
+var (
+	FinalizerErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "vkec2_finalizer_errors_total",
+		Help: "The total number of errors adding or removing the cleanup finalizer (or cleaning up before removing it)",
+	})
+)
+
+var (
+	LaunchQueueDepth = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "vkec2_launch_queue_depth",
+		Help: "The number of pods waiting in the launch queue for their instance to be launched",
+	})
+)
+
+var (
+	LaunchesInFlight = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "vkec2_launches_in_flight",
+		Help: "The number of pod instances being launched (admitted from the launch queue)",
+	})
+)
+
+var (
+	LaunchQueueWaitSeconds = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "vkec2_launch_queue_wait_seconds",
+		Help:    "How long pods waited in the launch queue before their instance launch was admitted",
+		Buckets: prometheus.ExponentialBuckets(0.1, 2, 14),
+	})
+)
+
+var (
+	AWSAPIRequestSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "vkec2_aws_api_request_seconds",
+		Help:    "How long AWS API operations took (including retries and client-side rate limiting)",
+		Buckets: prometheus.ExponentialBuckets(0.05, 2, 12),
+	}, []string{"service", "operation"})
+)
+
+var (
+	AWSAPIThrottles = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "vkec2_aws_api_throttles_total",
+		Help: "The total number of AWS API attempts rejected because of API rate limits",
+	}, []string{"service", "operation"})
+)
+
+var (
+	HostPoolHosts = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "vkec2_host_pool_hosts",
+		Help: "The number of dedicated hosts in the host pool by state (in-use, idle, scrubbing, allocating or unavailable)",
+	}, []string{"state", "instance_type"})
+)
+
+var (
+	HostPoolUtilization = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "vkec2_host_pool_utilization",
+		Help: "The fraction of the host pool's dedicated hosts that are running (or launching) a pod's instance",
+	})
+)
+
+var (
+	HostPoolHostsAllocated = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "vkec2_host_pool_hosts_allocated_total",
+		Help: "The total number of dedicated hosts allocated by the host pool",
+	})
+)
+
+var (
+	HostPoolHostsReleased = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "vkec2_host_pool_hosts_released_total",
+		Help: "The total number of idle dedicated hosts released by the host pool",
+	})
+)
+
+var (
+	HostPoolErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "vkec2_host_pool_errors_total",
+		Help: "The total number of errors describing, allocating or releasing the host pool's dedicated hosts",
+	})
+)
+
+var (
+	InstanceEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "vkec2_instance_events_total",
+		Help: "The total number of instance events (e.g. spot interruption notices) reported by VKVMAgents",
+	}, []string{"type"})
+)
+
+var (
+	PodsEvicted = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "vkec2_pods_evicted_total",
+		Help: "The total number of pods evicted because their instance is being interrupted",
+	})
+)
+
+var (
+	PodEvictionErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "vkec2_pod_eviction_errors_total",
+		Help: "The total number of errors evicting pods whose instance is being interrupted",
+	})
+)
+
+var (
+	ServiceQuotaErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "vkec2_service_quota_errors_total",
+		Help: "The total number of errors getting EC2 service quotas for node capacity",
+	})
+)
+
 var (
 	NodeNameErrors = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "vkec2_get_nodename_errors_total",
@@ -368,12 +622,48 @@ func init() {
 	metrics.Registry.MustRegister(DescribeEC2Errors)
 	metrics.Registry.MustRegister(LaunchApplicationErrors)
 	metrics.Registry.MustRegister(TerminateApplicationErrors)
+	metrics.Registry.MustRegister(GetContainerLogsErrors)
+	metrics.Registry.MustRegister(ExecErrors)
+	metrics.Registry.MustRegister(GetStatsErrors)
 	metrics.Registry.MustRegister(CheckApplicationHealthErrors)
 	metrics.Registry.MustRegister(WatchApplicationHealthErrors)
 	metrics.Registry.MustRegister(WatchApplicationHealthStreamErrors)
 	metrics.Registry.MustRegister(EC2Terminated)
 	metrics.Registry.MustRegister(EC2Launched)
 	metrics.Registry.MustRegister(NodeNameErrors)
+	metrics.Registry.MustRegister(NodeStatusCheckErrors)
+	metrics.Registry.MustRegister(ServiceQuotaErrors)
+	metrics.Registry.MustRegister(PodsRestoredFromEC2)
+	metrics.Registry.MustRegister(OrphanedInstancesFound)
+	metrics.Registry.MustRegister(OrphanedInstancesTerminated)
+	metrics.Registry.MustRegister(OrphanCollectorErrors)
+	metrics.Registry.MustRegister(PodLaunchRetries)
+	metrics.Registry.MustRegister(PodsLaunchFailed)
+	metrics.Registry.MustRegister(InstanceUpdateErrors)
+	metrics.Registry.MustRegister(TerminationGracePeriodExceeded)
+	metrics.Registry.MustRegister(ContainerRestarts)
+	metrics.Registry.MustRegister(ComputeReplacements)
+	metrics.Registry.MustRegister(PodsCompleted)
+	metrics.Registry.MustRegister(CompletedInstanceErrors)
+	metrics.Registry.MustRegister(WarmEC2Recycled)
+	metrics.Registry.MustRegister(WarmEC2ResetErrors)
+	metrics.Registry.MustRegister(WarmEC2ReuseLimitReached)
+	metrics.Registry.MustRegister(ProviderErrors)
+	metrics.Registry.MustRegister(DeletedPodsFinalized)
+	metrics.Registry.MustRegister(FinalizerErrors)
+	metrics.Registry.MustRegister(LaunchQueueDepth)
+	metrics.Registry.MustRegister(LaunchesInFlight)
+	metrics.Registry.MustRegister(LaunchQueueWaitSeconds)
+	metrics.Registry.MustRegister(AWSAPIRequestSeconds)
+	metrics.Registry.MustRegister(AWSAPIThrottles)
+	metrics.Registry.MustRegister(HostPoolHosts)
+	metrics.Registry.MustRegister(HostPoolUtilization)
+	metrics.Registry.MustRegister(HostPoolHostsAllocated)
+	metrics.Registry.MustRegister(HostPoolHostsReleased)
+	metrics.Registry.MustRegister(HostPoolErrors)
+	metrics.Registry.MustRegister(InstanceEvents)
+	metrics.Registry.MustRegister(PodsEvicted)
+	metrics.Registry.MustRegister(PodEvictionErrors)
 	metrics.Registry.MustRegister(HealthCheckGRPCError)
 	metrics.Registry.MustRegister(HealthCheckStateReset)
 	metrics.Registry.MustRegister(MissingHealthCheckResponse)
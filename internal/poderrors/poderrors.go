@@ -0,0 +1,274 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+// Package poderrors classifies the errors encountered while managing pods (from AWS APIs and VKVMAgents) into
+//
+//	categories that map consistently to Virtual Kubelet errdefs, pod status reasons, and metrics.
+package poderrors
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/smithy-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/aws/aws-virtual-kubelet/internal/metrics"
+)
+
+// Category is the kind of failure behind an error
+type Category string
+
+// Error categories
+const (
+	// CategoryUnknown is any error that isn't classified below
+	CategoryUnknown Category = "unknown"
+	// CategoryCapacity is a lack of capacity (in the AZ or account) for an instance
+	CategoryCapacity Category = "capacity"
+	// CategoryThrottling is a request rejected because of API rate limits
+	CategoryThrottling Category = "throttling"
+	// CategoryAuth is a missing, invalid, or expired credential (or a missing permission)
+	CategoryAuth Category = "auth"
+	// CategoryNotFound is a resource (e.g. instance, pod, or container) that doesn't exist
+	CategoryNotFound Category = "not-found"
+	// CategoryAgentUnreachable is a VKVMAgent that can't be connected to (or stopped responding)
+	CategoryAgentUnreachable Category = "agent-unreachable"
+	// CategoryInvalidSpec is a request (e.g. a pod's spec or annotations) that can never succeed as specified
+	CategoryInvalidSpec Category = "invalid-spec"
+)
+
+// reasons are the pod status (and condition) reasons of each category
+var reasons = map[Category]string{
+	CategoryCapacity:         "InsufficientCapacity",
+	CategoryThrottling:       "Throttled",
+	CategoryAuth:             "Unauthorized",
+	CategoryNotFound:         "NotFound",
+	CategoryAgentUnreachable: "AgentUnreachable",
+	CategoryInvalidSpec:      "InvalidSpec",
+}
+
+// Reason returns the pod status reason of a category (or an empty string for CategoryUnknown)
+func (c Category) Reason() string {
+	return reasons[c]
+}
+
+// PodError is an error with the category of failure that caused it.  It implements the errdefs NotFound and
+//
+//	InvalidInput interfaces, so Virtual Kubelet reports those categories appropriately (e.g. as a 404).
+type PodError struct {
+	category Category
+	err      error
+}
+
+// Error returns the underlying error's message
+func (e *PodError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *PodError) Unwrap() error {
+	return e.err
+}
+
+// Cause returns the underlying error (used by errdefs to traverse wrapped errors)
+func (e *PodError) Cause() error {
+	return e.err
+}
+
+// Category returns the category of the error
+func (e *PodError) Category() Category {
+	return e.category
+}
+
+// NotFound implements errdefs.ErrNotFound
+func (e *PodError) NotFound() bool {
+	return e.category == CategoryNotFound
+}
+
+// InvalidInput implements errdefs.ErrInvalidInput
+func (e *PodError) InvalidInput() bool {
+	return e.category == CategoryInvalidSpec
+}
+
+// Wrap classifies an error (see Classify) and wraps it as a PodError, counting it in the provider errors metric.
+//
+//	Errors that are already PodErrors are returned as-is (and aren't counted again).
+func Wrap(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return WrapAs(Classify(err), err)
+}
+
+// WrapAs wraps an error as a PodError of a given category (e.g. when the category is known from where the error
+//
+//	occurred), counting it in the provider errors metric.  Errors that are already PodErrors are returned as-is.
+func WrapAs(category Category, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var podErr *PodError
+	if errors.As(err, &podErr) {
+		return err
+	}
+
+	metrics.ProviderErrors.WithLabelValues(string(category)).Inc()
+
+	return &PodError{category: category, err: err}
+}
+
+// Newf creates a PodError of a given category from a format and args, counting it in the provider errors metric
+func Newf(category Category, format string, args ...interface{}) error {
+	return WrapAs(category, fmt.Errorf(format, args...))
+}
+
+// Is returns true if an error belongs to a category
+func Is(err error, category Category) bool {
+	return err != nil && Classify(err) == category
+}
+
+// Reason returns the pod status reason for an error, or defaultReason if the error isn't classified
+func Reason(err error, defaultReason string) string {
+	if reason := Classify(err).Reason(); reason != "" {
+		return reason
+	}
+
+	return defaultReason
+}
+
+// Classify returns the category of an error.  PodErrors have their own category, AWS API errors are classified by
+//
+//	their (smithy) error code, and VKVMAgent errors by their gRPC status code.
+func Classify(err error) Category {
+	if err == nil {
+		return CategoryUnknown
+	}
+
+	var podErr *PodError
+	if errors.As(err, &podErr) {
+		return podErr.category
+	}
+
+	// credentials couldn't be retrieved at all (e.g. no credentials configured or the provider failed)
+	var signingErr *v4.SigningError
+	if errors.As(err, &signingErr) {
+		return CategoryAuth
+	}
+
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return classifyAPIErrorCode(apiErr.ErrorCode())
+	}
+
+	// NOTE status.FromError doesn't unwrap errors (in this version of gRPC), so the interface is matched directly
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &grpcErr) {
+		return classifyGRPCCode(grpcErr.GRPCStatus().Code())
+	}
+
+	return CategoryUnknown
+}
+
+// authErrorCodes are AWS API error codes caused by missing, invalid, or expired credentials (or missing permissions)
+var authErrorCodes = map[string]bool{
+	"AuthFailure":                 true,
+	"UnauthorizedOperation":       true,
+	"InvalidClientTokenId":        true,
+	"ExpiredToken":                true,
+	"ExpiredTokenException":       true,
+	"RequestExpired":              true,
+	"SignatureDoesNotMatch":       true,
+	"UnrecognizedClientException": true,
+	"AccessDenied":                true,
+	"AccessDeniedException":       true,
+}
+
+// capacityErrorCodes are EC2 API error codes caused by a lack of capacity (in the AZ or account) for an instance
+var capacityErrorCodes = map[string]bool{
+	"InsufficientInstanceCapacity": true,
+	"InsufficientHostCapacity":     true,
+	"HostLimitExceeded":            true,
+	"InsufficientCapacity":         true,
+	"InstanceLimitExceeded":        true,
+	"VcpuLimitExceeded":            true,
+	"MaxSpotInstanceCountExceeded": true,
+	"SpotMaxPriceTooLow":           true,
+}
+
+// throttlingErrorCodes are AWS API error codes caused by exceeding API rate limits
+var throttlingErrorCodes = map[string]bool{
+	"Throttling":                             true,
+	"ThrottlingException":                    true,
+	"ThrottledException":                     true,
+	"RequestThrottled":                       true,
+	"RequestThrottledException":              true,
+	"RequestLimitExceeded":                   true,
+	"TooManyRequestsException":               true,
+	"ProvisionedThroughputExceededException": true,
+	"SlowDown":                               true,
+	"PriorRequestNotComplete":                true,
+	"EC2ThrottledException":                  true,
+}
+
+// invalidSpecErrorCodes are AWS API error codes caused by invalid request parameters
+var invalidSpecErrorCodes = map[string]bool{
+	"InvalidParameter":            true,
+	"InvalidParameterValue":       true,
+	"InvalidParameterCombination": true,
+	"MissingParameter":            true,
+	"UnknownParameter":            true,
+	"Unsupported":                 true,
+	"UnsupportedOperation":        true,
+	"ValidationError":             true,
+	"ValidationException":         true,
+}
+
+// classifyAPIErrorCode returns the category of an AWS API error code
+func classifyAPIErrorCode(code string) Category {
+	switch {
+	case authErrorCodes[code]:
+		return CategoryAuth
+	case capacityErrorCodes[code]:
+		return CategoryCapacity
+	case throttlingErrorCodes[code]:
+		return CategoryThrottling
+	// e.g. InvalidInstanceID.NotFound, ResourceNotFoundException, or NoSuchKey
+	case strings.HasSuffix(code, ".NotFound") || strings.HasSuffix(code, "NotFoundException") ||
+		strings.HasPrefix(code, "NoSuch"):
+		return CategoryNotFound
+	// e.g. InvalidAMIID.Malformed
+	case invalidSpecErrorCodes[code] || strings.HasSuffix(code, ".Malformed"):
+		return CategoryInvalidSpec
+	default:
+		return CategoryUnknown
+	}
+}
+
+// classifyGRPCCode returns the category of a (VKVMAgent) gRPC status code
+func classifyGRPCCode(code codes.Code) Category {
+	switch code {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return CategoryAgentUnreachable
+	case codes.NotFound:
+		return CategoryNotFound
+	case codes.InvalidArgument, codes.OutOfRange:
+		return CategoryInvalidSpec
+	case codes.ResourceExhausted:
+		return CategoryThrottling
+	case codes.Unauthenticated, codes.PermissionDenied:
+		return CategoryAuth
+	default:
+		return CategoryUnknown
+	}
+}
@@ -0,0 +1,101 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package poderrors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/smithy-go"
+	"github.com/virtual-kubelet/virtual-kubelet/errdefs"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func apiError(code string) error {
+	return &smithy.GenericAPIError{Code: code, Message: "test"}
+}
+
+func TestClassify(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		expected Category
+	}{
+		{"nil", nil, CategoryUnknown},
+		{"plain error", errors.New("test"), CategoryUnknown},
+		{"capacity", apiError("InsufficientInstanceCapacity"), CategoryCapacity},
+		{"host limit", apiError("HostLimitExceeded"), CategoryCapacity},
+		{"throttling", apiError("RequestLimitExceeded"), CategoryThrottling},
+		{"auth", apiError("UnauthorizedOperation"), CategoryAuth},
+		{"instance not found", apiError("InvalidInstanceID.NotFound"), CategoryNotFound},
+		{"resource not found", apiError("ResourceNotFoundException"), CategoryNotFound},
+		{"invalid parameter", apiError("InvalidParameterValue"), CategoryInvalidSpec},
+		{"malformed", apiError("InvalidAMIID.Malformed"), CategoryInvalidSpec},
+		{"unknown API error", apiError("InternalError"), CategoryUnknown},
+		{"wrapped API error", fmt.Errorf("launch failed: %w", apiError("VcpuLimitExceeded")), CategoryCapacity},
+		{"agent unavailable", status.Error(codes.Unavailable, "test"), CategoryAgentUnreachable},
+		{"agent deadline", status.Error(codes.DeadlineExceeded, "test"), CategoryAgentUnreachable},
+		{"container not found", status.Error(codes.NotFound, "test"), CategoryNotFound},
+		{"invalid argument", status.Error(codes.InvalidArgument, "test"), CategoryInvalidSpec},
+		{"wrapped gRPC error", fmt.Errorf("exec: %w", status.Error(codes.Unavailable, "test")),
+			CategoryAgentUnreachable},
+		{"pod error", Newf(CategoryThrottling, "test"), CategoryThrottling},
+		{"pod error overrides cause", WrapAs(CategoryAgentUnreachable, apiError("InvalidParameterValue")),
+			CategoryAgentUnreachable},
+	}
+
+	for _, tt := range cases {
+		actual := Classify(tt.err)
+		if actual != tt.expected {
+			t.Errorf("Classify(%s): expected %v, actual %v", tt.name, tt.expected, actual)
+		}
+	}
+}
+
+func TestErrdefs(t *testing.T) {
+	cases := []struct {
+		err          error
+		notFound     bool
+		invalidInput bool
+	}{
+		{Wrap(apiError("InvalidInstanceID.NotFound")), true, false},
+		{Wrap(status.Error(codes.InvalidArgument, "test")), false, true},
+		{Wrap(apiError("InsufficientInstanceCapacity")), false, false},
+	}
+
+	for _, tt := range cases {
+		if errdefs.IsNotFound(tt.err) != tt.notFound {
+			t.Errorf("errdefs.IsNotFound(%v): expected %v", tt.err, tt.notFound)
+		}
+		if errdefs.IsInvalidInput(tt.err) != tt.invalidInput {
+			t.Errorf("errdefs.IsInvalidInput(%v): expected %v", tt.err, tt.invalidInput)
+		}
+	}
+}
+
+func TestReason(t *testing.T) {
+	cases := []struct {
+		err      error
+		expected string
+	}{
+		{apiError("InsufficientHostCapacity"), "InsufficientCapacity"},
+		{status.Error(codes.Unavailable, "test"), "AgentUnreachable"},
+		{errors.New("test"), "EC2LaunchFailed"},
+	}
+
+	for _, tt := range cases {
+		actual := Reason(tt.err, "EC2LaunchFailed")
+		if actual != tt.expected {
+			t.Errorf("Reason(%v): expected %v, actual %v", tt.err, tt.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+// Package tracing configures OpenTelemetry tracing and provides helpers for instrumenting the provider with spans
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/aws/aws-virtual-kubelet/internal/config"
+)
+
+// Trace exporters
+const (
+	ExporterNone = "none"
+	ExporterOTLP = "otlp"
+)
+
+// instrumentationName identifies the provider's tracer
+const instrumentationName = "github.com/aws/aws-virtual-kubelet"
+
+// Init configures the global trace propagator and (if an exporter is configured) tracer provider.  The returned
+//
+//	function flushes and stops exporting, and must be called before exit.  Without an exporter, spans are no-ops.
+func Init(ctx context.Context, cfg config.TracingConfig) (func(context.Context) error, error) {
+	// propagate trace context even if spans aren't exported, so an upstream trace can be continued by VKVMAgents
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{},
+		propagation.Baggage{}))
+
+	if cfg.Exporter != ExporterOTLP {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+	if cfg.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create OTLP trace exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(),
+		resource.NewSchemaless(semconv.ServiceNameKey.String(cfg.ServiceName)))
+	if err != nil {
+		return nil, err
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(tracerProvider)
+
+	klog.InfoS("Exporting traces", "exporter", cfg.Exporter, "endpoint", cfg.Endpoint,
+		"sampleRatio", cfg.SampleRatio)
+
+	return tracerProvider.Shutdown, nil
+}
+
+// Start starts a span (as a child of the span in ctx, if any), returning a context containing the new span
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End ends a span, recording err (if not nil) as the reason the span's operation failed
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// WithParent returns ctx with the span of parent, so spans started from it are children of that span even though ctx
+//
+//	isn't derived from parent (e.g. background work that outlives the request that started it)
+func WithParent(ctx context.Context, parent context.Context) context.Context {
+	return trace.ContextWithSpanContext(ctx, trace.SpanContextFromContext(parent))
+}
+
+// PodAttributes returns the span attributes identifying a pod (and its instance, if it has one)
+func PodAttributes(pod *corev1.Pod) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		semconv.K8SNamespaceNameKey.String(pod.Namespace),
+		semconv.K8SPodNameKey.String(pod.Name),
+		semconv.K8SPodUIDKey.String(string(pod.UID)),
+	}
+
+	if instanceID := pod.Annotations["compute.amazonaws.com/instance-id"]; instanceID != "" {
+		attrs = append(attrs, InstanceAttribute(instanceID))
+	}
+
+	return attrs
+}
+
+// InstanceAttribute returns the span attribute identifying an EC2 instance
+func InstanceAttribute(instanceID string) attribute.KeyValue {
+	return semconv.HostIDKey.String(instanceID)
+}
@@ -0,0 +1,90 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/aws-virtual-kubelet/internal/config"
+)
+
+func TestInitNoExporter(t *testing.T) {
+	for _, exporter := range []string{"", ExporterNone} {
+		shutdown, err := Init(context.Background(), config.TracingConfig{Exporter: exporter})
+		if err != nil {
+			t.Fatalf("Init(%q): unexpected error %v", exporter, err)
+		}
+		if err = shutdown(context.Background()); err != nil {
+			t.Errorf("Init(%q): unexpected shutdown error %v", exporter, err)
+		}
+	}
+}
+
+func TestSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Namespace:   "default",
+		Name:        "test",
+		Annotations: map[string]string{"compute.amazonaws.com/instance-id": "i-0123456789abcdef0"},
+	}}
+
+	ctx, parent := Start(context.Background(), "parent", PodAttributes(pod)...)
+	End(parent, nil)
+
+	// a child started from an unrelated context with the parent's span
+	_, child := Start(WithParent(context.Background(), ctx), "child")
+	End(child, errors.New("test"))
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 ended spans, actual %v", len(spans))
+	}
+
+	cases := []struct {
+		span       sdktrace.ReadOnlySpan
+		name       string
+		status     codes.Code
+		attributes int
+	}{
+		{spans[0], "parent", codes.Unset, 4},
+		{spans[1], "child", codes.Error, 0},
+	}
+
+	for _, tt := range cases {
+		if tt.span.Name() != tt.name {
+			t.Errorf("expected span %v, actual %v", tt.name, tt.span.Name())
+		}
+		if tt.span.Status().Code != tt.status {
+			t.Errorf("span %v: expected status %v, actual %v", tt.name, tt.status, tt.span.Status().Code)
+		}
+		if len(tt.span.Attributes()) != tt.attributes {
+			t.Errorf("span %v: expected %v attributes, actual %v", tt.name, tt.attributes,
+				len(tt.span.Attributes()))
+		}
+	}
+
+	if spans[1].Parent().SpanID() != spans[0].SpanContext().SpanID() {
+		t.Errorf("expected child's parent to be %v, actual %v", spans[0].SpanContext().SpanID(),
+			spans[1].Parent().SpanID())
+	}
+}
@@ -12,8 +12,6 @@ package utils
 import (
 	"context"
 
-	"k8s.io/klog/v2"
-
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -59,10 +57,3 @@ func PodIsWarmPool(ctx context.Context, pod *corev1.Pod, configLength int) bool
 	}
 	return false
 }
-
-// ReplaceCompute removes a pod's compute and obtains new compute without changing other pod properties or state
-func ReplaceCompute(ctx context.Context, pod *corev1.Pod) error {
-	klog.InfoS("Attempting to replace compute...", "pod", klog.KObj(pod))
-	klog.InfoS("🚧 TODO implement ec2provider compute replacement", "pod", klog.KObj(pod))
-	return nil
-}
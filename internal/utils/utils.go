@@ -10,7 +10,11 @@ Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL o
 package utils
 
 import (
+	"fmt"
+	"math"
+	"regexp"
 	"strings"
+	"time"
 )
 
 // TrimmedStringSplit returns a list of strings with their whitespaces removed from the head or tail of the string.
@@ -21,3 +25,64 @@ func TrimmedStringSplit(input string, sep string) []string {
 	}
 	return interimString
 }
+
+// ExponentialBackoff returns the delay before the given retry attempt (starting at 1), capped at max
+//
+//	i.e. initial * multiplier^(attempt-1)
+func ExponentialBackoff(attempt int, initial time.Duration, max time.Duration, multiplier float64) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	delay := float64(initial) * math.Pow(multiplier, float64(attempt-1))
+	if delay > float64(max) {
+		return max
+	}
+
+	return time.Duration(delay)
+}
+
+// LaunchTemplateRef identifies an EC2 launch template (by ID or name) and version
+type LaunchTemplateRef struct {
+	ID      string
+	Name    string
+	Version string
+}
+
+// Launch template versions other than version numbers
+const (
+	LaunchTemplateVersionLatest  = "$Latest"
+	LaunchTemplateVersionDefault = "$Default"
+)
+
+var (
+	launchTemplateIDPattern      = regexp.MustCompile(`^lt-[0-9a-f]{17}$`)
+	launchTemplateVersionPattern = regexp.MustCompile(`^[1-9][0-9]*$`)
+)
+
+// ParseLaunchTemplate parses a launch template reference of the form `<id or name>[:<version>]` (e.g.
+//
+//	`lt-0123456789abcdef0:3` or `mac-builder:$Latest`).  The version is a version number, $Latest, or $Default (the
+//	default if omitted).
+func ParseLaunchTemplate(value string) (LaunchTemplateRef, error) {
+	idOrName, version := value, LaunchTemplateVersionDefault
+	if i := strings.LastIndex(value, ":"); i >= 0 {
+		idOrName, version = value[:i], value[i+1:]
+	}
+	idOrName, version = strings.TrimSpace(idOrName), strings.TrimSpace(version)
+
+	if idOrName == "" || strings.Contains(idOrName, ":") {
+		return LaunchTemplateRef{}, fmt.Errorf("launch template %q must be <id or name>[:<version>]", value)
+	}
+	if version != LaunchTemplateVersionLatest && version != LaunchTemplateVersionDefault &&
+		!launchTemplateVersionPattern.MatchString(version) {
+		return LaunchTemplateRef{}, fmt.Errorf("launch template %q version must be a number, %v, or %v", value,
+			LaunchTemplateVersionLatest, LaunchTemplateVersionDefault)
+	}
+
+	if launchTemplateIDPattern.MatchString(idOrName) {
+		return LaunchTemplateRef{ID: idOrName, Version: version}, nil
+	}
+
+	return LaunchTemplateRef{Name: idOrName, Version: version}, nil
+}
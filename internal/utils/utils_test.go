@@ -10,6 +10,7 @@ package utils
 
 import (
 	"testing"
+	"time"
 )
 
 type TrimmedStringSplitTest struct {
@@ -35,3 +36,54 @@ func TestTrimmedStringSplit(t *testing.T) {
 	}
 
 }
+
+func TestExponentialBackoff(t *testing.T) {
+	cases := []struct {
+		attempt  int
+		expected time.Duration
+	}{
+		{0, 10 * time.Second},
+		{1, 10 * time.Second},
+		{2, 20 * time.Second},
+		{3, 40 * time.Second},
+		{5, 60 * time.Second},
+		{100, 60 * time.Second},
+	}
+
+	for _, tt := range cases {
+		actual := ExponentialBackoff(tt.attempt, 10*time.Second, 60*time.Second, 2)
+		if actual != tt.expected {
+			t.Errorf("ExponentialBackoff(%d): expected %v, actual %v", tt.attempt, tt.expected, actual)
+		}
+	}
+}
+
+func TestParseLaunchTemplate(t *testing.T) {
+	cases := []struct {
+		value    string
+		expected LaunchTemplateRef
+		wantErr  bool
+	}{
+		{"lt-0123456789abcdef0", LaunchTemplateRef{ID: "lt-0123456789abcdef0", Version: "$Default"}, false},
+		{"lt-0123456789abcdef0:3", LaunchTemplateRef{ID: "lt-0123456789abcdef0", Version: "3"}, false},
+		{"mac-builder:$Latest", LaunchTemplateRef{Name: "mac-builder", Version: "$Latest"}, false},
+		{" mac-builder : 12 ", LaunchTemplateRef{Name: "mac-builder", Version: "12"}, false},
+		{"", LaunchTemplateRef{}, true},
+		{":3", LaunchTemplateRef{}, true},
+		{"mac-builder:", LaunchTemplateRef{}, true},
+		{"mac-builder:0", LaunchTemplateRef{}, true},
+		{"mac-builder:latest", LaunchTemplateRef{}, true},
+		{"mac:builder:3", LaunchTemplateRef{}, true},
+	}
+
+	for _, tt := range cases {
+		actual, err := ParseLaunchTemplate(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseLaunchTemplate(%q): expected error %v, actual %v", tt.value, tt.wantErr, err)
+			continue
+		}
+		if actual != tt.expected {
+			t.Errorf("ParseLaunchTemplate(%q): expected %+v, actual %+v", tt.value, tt.expected, actual)
+		}
+	}
+}
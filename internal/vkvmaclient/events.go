@@ -0,0 +1,41 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package vkvmaclient
+
+import (
+	"context"
+
+	vkvmagent "github.com/aws/aws-virtual-kubelet/proto/vkvmagent/v0"
+)
+
+// WatchInstanceEvents streams instance events (e.g. spot interruption notices) from the VKVMAgent, calling handler for
+//
+//	each one.  It blocks until the stream ends (or ctx is done), then closes the connection.
+func (v *VkvmaClient) WatchInstanceEvents(ctx context.Context, handler func(*vkvmagent.InstanceEvent)) error {
+	alc, err := v.GetApplicationLifecycleClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer v.closeConnection()
+
+	stream, err := alc.WatchInstanceEvents(ctx, &vkvmagent.WatchInstanceEventsRequest{})
+	if err != nil {
+		return err
+	}
+
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		handler(event)
+	}
+}
@@ -0,0 +1,178 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package vkvmaclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/virtual-kubelet/virtual-kubelet/node/api"
+	"k8s.io/klog/v2"
+	utilexec "k8s.io/utils/exec"
+
+	vkvmagent "github.com/aws/aws-virtual-kubelet/proto/vkvmagent/v0"
+)
+
+// execStdinChunkSize is the maximum amount of stdin sent in a single request
+const execStdinChunkSize = 32 * 1024
+
+// execSender serializes sends on an Exec stream (gRPC does not allow concurrent sends on a single stream)
+type execSender struct {
+	mu     sync.Mutex
+	stream vkvmagent.ApplicationLifecycle_ExecClient
+}
+
+func (s *execSender) send(req *vkvmagent.ExecRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.stream.Send(req)
+}
+
+// ExecInContainer runs a command in a container via the VKVMAgent, attaching the passed-in streams to it.  A non-zero
+//
+//	exit from the command is returned as a utilexec.CodeExitError so the exit code is reported to the caller.
+func (v *VkvmaClient) ExecInContainer(
+	ctx context.Context, containerName string, cmd []string, attach api.AttachIO) error {
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	alc, err := v.GetApplicationLifecycleClient(streamCtx)
+	if err != nil {
+		return err
+	}
+	defer v.closeConnection()
+
+	stream, err := alc.Exec(streamCtx)
+	if err != nil {
+		return err
+	}
+
+	sender := &execSender{stream: stream}
+
+	err = sender.send(&vkvmagent.ExecRequest{
+		Request: &vkvmagent.ExecRequest_Start{
+			Start: &vkvmagent.ExecStart{
+				ContainerName: containerName,
+				Command:       cmd,
+				Tty:           attach.TTY(),
+				Stdin:         attach.Stdin() != nil,
+				Stdout:        attach.Stdout() != nil,
+				Stderr:        attach.Stderr() != nil,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if attach.Stdin() != nil {
+		go sendExecStdin(sender, attach.Stdin())
+	}
+
+	if attach.TTY() {
+		go sendExecResize(streamCtx, sender, attach.Resize())
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return fmt.Errorf("exec stream for container %s ended without an exit status", containerName)
+			}
+			return err
+		}
+
+		switch r := resp.GetResponse().(type) {
+		case *vkvmagent.ExecResponse_Stdout:
+			writeExecOutput(attach.Stdout(), r.Stdout)
+		case *vkvmagent.ExecResponse_Stderr:
+			writeExecOutput(attach.Stderr(), r.Stderr)
+		case *vkvmagent.ExecResponse_Exit:
+			exitCode := int(r.Exit.GetExitCode())
+			if exitCode == 0 {
+				return nil
+			}
+
+			message := r.Exit.GetMessage()
+			if message == "" {
+				message = fmt.Sprintf("command terminated with exit code %d", exitCode)
+			}
+			return utilexec.CodeExitError{Err: errors.New(message), Code: exitCode}
+		}
+	}
+}
+
+// sendExecStdin copies stdin to the stream until EOF (or an error), then tells the agent stdin is closed
+func sendExecStdin(sender *execSender, stdin io.Reader) {
+	buf := make([]byte, execStdinChunkSize)
+
+	for {
+		n, err := stdin.Read(buf)
+		if n > 0 {
+			// copy the chunk since buf is reused for the next read
+			chunk := append([]byte(nil), buf[:n]...)
+			if sendErr := sender.send(&vkvmagent.ExecRequest{
+				Request: &vkvmagent.ExecRequest_Stdin{Stdin: chunk},
+			}); sendErr != nil {
+				klog.V(1).InfoS("Unable to send exec stdin", "error", sendErr)
+				return
+			}
+		}
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				klog.V(1).InfoS("Error reading exec stdin", "error", err)
+			}
+			break
+		}
+	}
+
+	if err := sender.send(&vkvmagent.ExecRequest{
+		Request: &vkvmagent.ExecRequest_StdinClosed{StdinClosed: true},
+	}); err != nil {
+		klog.V(1).InfoS("Unable to send exec stdin close", "error", err)
+	}
+}
+
+// sendExecResize forwards terminal resize events to the stream until the context is done
+func sendExecResize(ctx context.Context, sender *execSender, resize <-chan api.TermSize) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case size, ok := <-resize:
+			if !ok {
+				return
+			}
+			if err := sender.send(&vkvmagent.ExecRequest{
+				Request: &vkvmagent.ExecRequest_Resize{
+					Resize: &vkvmagent.TerminalSize{Width: uint32(size.Width), Height: uint32(size.Height)},
+				},
+			}); err != nil {
+				klog.V(1).InfoS("Unable to send exec terminal resize", "error", err)
+				return
+			}
+		}
+	}
+}
+
+// writeExecOutput writes command output to a stream (if the stream was attached)
+func writeExecOutput(w io.Writer, content []byte) {
+	if w == nil {
+		return
+	}
+
+	if _, err := w.Write(content); err != nil {
+		klog.V(1).InfoS("Error writing exec output", "error", err)
+	}
+}
@@ -0,0 +1,115 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package vkvmaclient
+
+import (
+	"context"
+	"io"
+
+	"github.com/virtual-kubelet/virtual-kubelet/node/api"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"k8s.io/klog/v2"
+
+	vkvmagent "github.com/aws/aws-virtual-kubelet/proto/vkvmagent/v0"
+)
+
+// logStreamReader adapts a StreamLogs client stream to an io.ReadCloser
+type logStreamReader struct {
+	stream vkvmagent.ApplicationLifecycle_StreamLogsClient
+	// buf holds content received from the stream that has not been read yet
+	buf []byte
+	// cancel ends the stream (and releases the connection) when the reader is closed
+	cancel context.CancelFunc
+}
+
+// Read implements io.Reader, receiving the next chunk from the stream once buffered content is exhausted
+func (r *logStreamReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		resp, err := r.stream.Recv()
+		if err != nil {
+			// io.EOF signals the agent closed the stream normally (i.e. the end of the log was reached)
+			return 0, err
+		}
+		r.buf = resp.GetContent()
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+
+	return n, nil
+}
+
+// Close implements io.Closer, ending the stream
+func (r *logStreamReader) Close() error {
+	r.cancel()
+	return nil
+}
+
+// GetContainerLogs opens a log stream for a container and returns it as an io.ReadCloser.  The stream (and the
+//
+//	underlying connection) stays open until the reader is closed or the passed-in context is cancelled.
+func (v *VkvmaClient) GetContainerLogs(
+	ctx context.Context, containerName string, opts api.ContainerLogOpts) (io.ReadCloser, error) {
+	streamCtx, cancel := context.WithCancel(ctx)
+
+	alc, err := v.GetApplicationLifecycleClient(streamCtx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	stream, err := alc.StreamLogs(streamCtx, &vkvmagent.StreamLogsRequest{
+		ContainerName: containerName,
+		Options:       NewContainerLogOptions(opts),
+	})
+	if err != nil {
+		cancel()
+		v.closeConnection()
+		return nil, err
+	}
+
+	return &logStreamReader{
+		stream: stream,
+		cancel: func() {
+			cancel()
+			v.closeConnection()
+		},
+	}, nil
+}
+
+// NewContainerLogOptions converts Virtual Kubelet log options into their VKVMAgent API equivalent
+func NewContainerLogOptions(opts api.ContainerLogOpts) *vkvmagent.ContainerLogOptions {
+	logOpts := &vkvmagent.ContainerLogOptions{
+		Tail:         int32(opts.Tail),
+		LimitBytes:   int64(opts.LimitBytes),
+		Timestamps:   opts.Timestamps,
+		Follow:       opts.Follow,
+		Previous:     opts.Previous,
+		SinceSeconds: int64(opts.SinceSeconds),
+	}
+
+	if !opts.SinceTime.IsZero() {
+		logOpts.SinceTime = timestamppb.New(opts.SinceTime)
+	}
+
+	return logOpts
+}
+
+// closeConnection closes the client's connection (if one is open)
+func (v *VkvmaClient) closeConnection() {
+	if v.VkvmaConnection.connection == nil {
+		return
+	}
+
+	if err := v.VkvmaConnection.connection.Close(); err != nil {
+		klog.V(1).InfoS("Error closing VKVMAgent connection", "address", v.address, "error", err)
+	}
+	v.VkvmaConnection.connection = nil
+}
@@ -0,0 +1,31 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package vkvmaclient
+
+import (
+	"context"
+
+	vkvmagent "github.com/aws/aws-virtual-kubelet/proto/vkvmagent/v0"
+)
+
+// ResetInstance asks the VKVMAgent to scrub the instance so it can be reused for another pod.  The connection is closed
+//
+//	once the request completes since the instance's next pod uses a new client.
+func (v *VkvmaClient) ResetInstance(ctx context.Context) error {
+	alc, err := v.GetApplicationLifecycleClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer v.closeConnection()
+
+	_, err = alc.ResetInstance(ctx, &vkvmagent.ResetInstanceRequest{})
+
+	return err
+}
@@ -0,0 +1,29 @@
+/*
+This sample, non-production-ready code contains a Virtual Kubelet EC2-based provider and example VM Agent implementation.
+© 2021 Amazon Web Services, Inc. or its affiliates. All Rights Reserved.
+
+This AWS Content is provided subject to the terms of the AWS Customer Agreement
+available at http://aws.amazon.com/agreement or other written agreement between
+Customer and either Amazon Web Services, Inc. or Amazon Web Services EMEA SARL or both.
+*/
+
+package vkvmaclient
+
+import (
+	"context"
+
+	vkvmagent "github.com/aws/aws-virtual-kubelet/proto/vkvmagent/v0"
+)
+
+// GetStats retrieves current resource usage for the instance and its containers.  Stats are requested frequently (by
+//
+//	metrics-server, HPA, etc.) so the connection is closed once the request completes.
+func (v *VkvmaClient) GetStats(ctx context.Context) (*vkvmagent.GetStatsResponse, error) {
+	alc, err := v.GetApplicationLifecycleClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer v.closeConnection()
+
+	return alc.GetStats(ctx, &vkvmagent.GetStatsRequest{})
+}
@@ -12,8 +12,11 @@ package vkvmaclient
 import (
 	"context"
 	"fmt"
+	"io"
 	"time"
 
+	"github.com/virtual-kubelet/virtual-kubelet/node/api"
+
 	"google.golang.org/grpc/keepalive"
 
 	"k8s.io/klog/v2"
@@ -28,6 +31,7 @@ import (
 
 	health "github.com/aws/aws-virtual-kubelet/proto/grpc/health/v1"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/backoff"
 	"google.golang.org/grpc/credentials/insecure"
@@ -38,6 +42,11 @@ type GrpcClient interface {
 	GetHealthClient(ctx context.Context) (health.HealthClient, error)
 	GetApplicationLifecycleClient(ctx context.Context) (vkvmagent.ApplicationLifecycleClient, error)
 	IsConnected(ctx context.Context) bool
+	GetContainerLogs(ctx context.Context, containerName string, opts api.ContainerLogOpts) (io.ReadCloser, error)
+	ExecInContainer(ctx context.Context, containerName string, cmd []string, attach api.AttachIO) error
+	GetStats(ctx context.Context) (*vkvmagent.GetStatsResponse, error)
+	ResetInstance(ctx context.Context) error
+	WatchInstanceEvents(ctx context.Context, handler func(*vkvmagent.InstanceEvent)) error
 }
 
 type VkvmaClient struct {
@@ -151,6 +160,9 @@ func (v *VkvmaClient) Connect(ctx context.Context) (*grpc.ClientConn, error) {
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 		grpc.WithBlock(),
 		grpc.WithConnectParams(connectParams),
+		// trace each RPC, propagating the trace context to the VKVMAgent in gRPC metadata
+		grpc.WithUnaryInterceptor(otelgrpc.UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(otelgrpc.StreamClientInterceptor()),
 	}
 
 	if v.config.KeepaliveEnabled {
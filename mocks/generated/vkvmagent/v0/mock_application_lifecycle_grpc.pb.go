@@ -57,6 +57,46 @@ func (mr *MockApplicationLifecycleClientMockRecorder) CheckApplicationHealth(ctx
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckApplicationHealth", reflect.TypeOf((*MockApplicationLifecycleClient)(nil).CheckApplicationHealth), varargs...)
 }
 
+// Exec mocks base method.
+func (m *MockApplicationLifecycleClient) Exec(ctx context.Context, opts ...grpc.CallOption) (vkvmagent_v0.ApplicationLifecycle_ExecClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Exec", varargs...)
+	ret0, _ := ret[0].(vkvmagent_v0.ApplicationLifecycle_ExecClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exec indicates an expected call of Exec.
+func (mr *MockApplicationLifecycleClientMockRecorder) Exec(ctx interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exec", reflect.TypeOf((*MockApplicationLifecycleClient)(nil).Exec), varargs...)
+}
+
+// GetStats mocks base method.
+func (m *MockApplicationLifecycleClient) GetStats(ctx context.Context, in *vkvmagent_v0.GetStatsRequest, opts ...grpc.CallOption) (*vkvmagent_v0.GetStatsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetStats", varargs...)
+	ret0, _ := ret[0].(*vkvmagent_v0.GetStatsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStats indicates an expected call of GetStats.
+func (mr *MockApplicationLifecycleClientMockRecorder) GetStats(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockApplicationLifecycleClient)(nil).GetStats), varargs...)
+}
+
 // LaunchApplication mocks base method.
 func (m *MockApplicationLifecycleClient) LaunchApplication(ctx context.Context, in *vkvmagent_v0.LaunchApplicationRequest, opts ...grpc.CallOption) (*vkvmagent_v0.LaunchApplicationResponse, error) {
 	m.ctrl.T.Helper()
@@ -77,6 +117,46 @@ func (mr *MockApplicationLifecycleClientMockRecorder) LaunchApplication(ctx, in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LaunchApplication", reflect.TypeOf((*MockApplicationLifecycleClient)(nil).LaunchApplication), varargs...)
 }
 
+// ResetInstance mocks base method.
+func (m *MockApplicationLifecycleClient) ResetInstance(ctx context.Context, in *vkvmagent_v0.ResetInstanceRequest, opts ...grpc.CallOption) (*vkvmagent_v0.ResetInstanceResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ResetInstance", varargs...)
+	ret0, _ := ret[0].(*vkvmagent_v0.ResetInstanceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetInstance indicates an expected call of ResetInstance.
+func (mr *MockApplicationLifecycleClientMockRecorder) ResetInstance(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetInstance", reflect.TypeOf((*MockApplicationLifecycleClient)(nil).ResetInstance), varargs...)
+}
+
+// StreamLogs mocks base method.
+func (m *MockApplicationLifecycleClient) StreamLogs(ctx context.Context, in *vkvmagent_v0.StreamLogsRequest, opts ...grpc.CallOption) (vkvmagent_v0.ApplicationLifecycle_StreamLogsClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StreamLogs", varargs...)
+	ret0, _ := ret[0].(vkvmagent_v0.ApplicationLifecycle_StreamLogsClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StreamLogs indicates an expected call of StreamLogs.
+func (mr *MockApplicationLifecycleClientMockRecorder) StreamLogs(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamLogs", reflect.TypeOf((*MockApplicationLifecycleClient)(nil).StreamLogs), varargs...)
+}
+
 // TerminateApplication mocks base method.
 func (m *MockApplicationLifecycleClient) TerminateApplication(ctx context.Context, in *vkvmagent_v0.TerminateApplicationRequest, opts ...grpc.CallOption) (*vkvmagent_v0.TerminateApplicationResponse, error) {
 	m.ctrl.T.Helper()
@@ -117,6 +197,26 @@ func (mr *MockApplicationLifecycleClientMockRecorder) WatchApplicationHealth(ctx
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchApplicationHealth", reflect.TypeOf((*MockApplicationLifecycleClient)(nil).WatchApplicationHealth), varargs...)
 }
 
+// WatchInstanceEvents mocks base method.
+func (m *MockApplicationLifecycleClient) WatchInstanceEvents(ctx context.Context, in *vkvmagent_v0.WatchInstanceEventsRequest, opts ...grpc.CallOption) (vkvmagent_v0.ApplicationLifecycle_WatchInstanceEventsClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WatchInstanceEvents", varargs...)
+	ret0, _ := ret[0].(vkvmagent_v0.ApplicationLifecycle_WatchInstanceEventsClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchInstanceEvents indicates an expected call of WatchInstanceEvents.
+func (mr *MockApplicationLifecycleClientMockRecorder) WatchInstanceEvents(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchInstanceEvents", reflect.TypeOf((*MockApplicationLifecycleClient)(nil).WatchInstanceEvents), varargs...)
+}
+
 // MockApplicationLifecycle_WatchApplicationHealthClient is a mock of ApplicationLifecycle_WatchApplicationHealthClient interface.
 type MockApplicationLifecycle_WatchApplicationHealthClient struct {
 	ctrl     *gomock.Controller
@@ -240,174 +340,212 @@ func (mr *MockApplicationLifecycle_WatchApplicationHealthClientMockRecorder) Tra
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockApplicationLifecycle_WatchApplicationHealthClient)(nil).Trailer))
 }
 
-// MockApplicationLifecycleServer is a mock of ApplicationLifecycleServer interface.
-type MockApplicationLifecycleServer struct {
+// MockApplicationLifecycle_StreamLogsClient is a mock of ApplicationLifecycle_StreamLogsClient interface.
+type MockApplicationLifecycle_StreamLogsClient struct {
 	ctrl     *gomock.Controller
-	recorder *MockApplicationLifecycleServerMockRecorder
+	recorder *MockApplicationLifecycle_StreamLogsClientMockRecorder
 }
 
-// MockApplicationLifecycleServerMockRecorder is the mock recorder for MockApplicationLifecycleServer.
-type MockApplicationLifecycleServerMockRecorder struct {
-	mock *MockApplicationLifecycleServer
+// MockApplicationLifecycle_StreamLogsClientMockRecorder is the mock recorder for MockApplicationLifecycle_StreamLogsClient.
+type MockApplicationLifecycle_StreamLogsClientMockRecorder struct {
+	mock *MockApplicationLifecycle_StreamLogsClient
 }
 
-// NewMockApplicationLifecycleServer creates a new mock instance.
-func NewMockApplicationLifecycleServer(ctrl *gomock.Controller) *MockApplicationLifecycleServer {
-	mock := &MockApplicationLifecycleServer{ctrl: ctrl}
-	mock.recorder = &MockApplicationLifecycleServerMockRecorder{mock}
+// NewMockApplicationLifecycle_StreamLogsClient creates a new mock instance.
+func NewMockApplicationLifecycle_StreamLogsClient(ctrl *gomock.Controller) *MockApplicationLifecycle_StreamLogsClient {
+	mock := &MockApplicationLifecycle_StreamLogsClient{ctrl: ctrl}
+	mock.recorder = &MockApplicationLifecycle_StreamLogsClientMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockApplicationLifecycleServer) EXPECT() *MockApplicationLifecycleServerMockRecorder {
+func (m *MockApplicationLifecycle_StreamLogsClient) EXPECT() *MockApplicationLifecycle_StreamLogsClientMockRecorder {
 	return m.recorder
 }
 
-// CheckApplicationHealth mocks base method.
-func (m *MockApplicationLifecycleServer) CheckApplicationHealth(arg0 context.Context, arg1 *vkvmagent_v0.ApplicationHealthRequest) (*vkvmagent_v0.ApplicationHealthResponse, error) {
+// CloseSend mocks base method.
+func (m *MockApplicationLifecycle_StreamLogsClient) CloseSend() error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CheckApplicationHealth", arg0, arg1)
-	ret0, _ := ret[0].(*vkvmagent_v0.ApplicationHealthResponse)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// CheckApplicationHealth indicates an expected call of CheckApplicationHealth.
-func (mr *MockApplicationLifecycleServerMockRecorder) CheckApplicationHealth(arg0, arg1 interface{}) *gomock.Call {
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockApplicationLifecycle_StreamLogsClientMockRecorder) CloseSend() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckApplicationHealth", reflect.TypeOf((*MockApplicationLifecycleServer)(nil).CheckApplicationHealth), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockApplicationLifecycle_StreamLogsClient)(nil).CloseSend))
 }
 
-// LaunchApplication mocks base method.
-func (m *MockApplicationLifecycleServer) LaunchApplication(arg0 context.Context, arg1 *vkvmagent_v0.LaunchApplicationRequest) (*vkvmagent_v0.LaunchApplicationResponse, error) {
+// Context mocks base method.
+func (m *MockApplicationLifecycle_StreamLogsClient) Context() context.Context {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "LaunchApplication", arg0, arg1)
-	ret0, _ := ret[0].(*vkvmagent_v0.LaunchApplicationResponse)
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockApplicationLifecycle_StreamLogsClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockApplicationLifecycle_StreamLogsClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockApplicationLifecycle_StreamLogsClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// LaunchApplication indicates an expected call of LaunchApplication.
-func (mr *MockApplicationLifecycleServerMockRecorder) LaunchApplication(arg0, arg1 interface{}) *gomock.Call {
+// Header indicates an expected call of Header.
+func (mr *MockApplicationLifecycle_StreamLogsClientMockRecorder) Header() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LaunchApplication", reflect.TypeOf((*MockApplicationLifecycleServer)(nil).LaunchApplication), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockApplicationLifecycle_StreamLogsClient)(nil).Header))
 }
 
-// TerminateApplication mocks base method.
-func (m *MockApplicationLifecycleServer) TerminateApplication(arg0 context.Context, arg1 *vkvmagent_v0.TerminateApplicationRequest) (*vkvmagent_v0.TerminateApplicationResponse, error) {
+// Recv mocks base method.
+func (m *MockApplicationLifecycle_StreamLogsClient) Recv() (*vkvmagent_v0.StreamLogsResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "TerminateApplication", arg0, arg1)
-	ret0, _ := ret[0].(*vkvmagent_v0.TerminateApplicationResponse)
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*vkvmagent_v0.StreamLogsResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// TerminateApplication indicates an expected call of TerminateApplication.
-func (mr *MockApplicationLifecycleServerMockRecorder) TerminateApplication(arg0, arg1 interface{}) *gomock.Call {
+// Recv indicates an expected call of Recv.
+func (mr *MockApplicationLifecycle_StreamLogsClientMockRecorder) Recv() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TerminateApplication", reflect.TypeOf((*MockApplicationLifecycleServer)(nil).TerminateApplication), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockApplicationLifecycle_StreamLogsClient)(nil).Recv))
 }
 
-// WatchApplicationHealth mocks base method.
-func (m *MockApplicationLifecycleServer) WatchApplicationHealth(arg0 *vkvmagent_v0.ApplicationHealthRequest, arg1 vkvmagent_v0.ApplicationLifecycle_WatchApplicationHealthServer) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WatchApplicationHealth", arg0, arg1)
+// RecvMsg mocks base method.
+func (m_2 *MockApplicationLifecycle_StreamLogsClient) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// WatchApplicationHealth indicates an expected call of WatchApplicationHealth.
-func (mr *MockApplicationLifecycleServerMockRecorder) WatchApplicationHealth(arg0, arg1 interface{}) *gomock.Call {
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockApplicationLifecycle_StreamLogsClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchApplicationHealth", reflect.TypeOf((*MockApplicationLifecycleServer)(nil).WatchApplicationHealth), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockApplicationLifecycle_StreamLogsClient)(nil).RecvMsg), m)
 }
 
-// mustEmbedUnimplementedApplicationLifecycleServer mocks base method.
-func (m *MockApplicationLifecycleServer) mustEmbedUnimplementedApplicationLifecycleServer() {
+// SendMsg mocks base method.
+func (m_2 *MockApplicationLifecycle_StreamLogsClient) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockApplicationLifecycle_StreamLogsClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockApplicationLifecycle_StreamLogsClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockApplicationLifecycle_StreamLogsClient) Trailer() metadata.MD {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "mustEmbedUnimplementedApplicationLifecycleServer")
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
 }
 
-// mustEmbedUnimplementedApplicationLifecycleServer indicates an expected call of mustEmbedUnimplementedApplicationLifecycleServer.
-func (mr *MockApplicationLifecycleServerMockRecorder) mustEmbedUnimplementedApplicationLifecycleServer() *gomock.Call {
+// Trailer indicates an expected call of Trailer.
+func (mr *MockApplicationLifecycle_StreamLogsClientMockRecorder) Trailer() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedApplicationLifecycleServer", reflect.TypeOf((*MockApplicationLifecycleServer)(nil).mustEmbedUnimplementedApplicationLifecycleServer))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockApplicationLifecycle_StreamLogsClient)(nil).Trailer))
 }
 
-// MockUnsafeApplicationLifecycleServer is a mock of UnsafeApplicationLifecycleServer interface.
-type MockUnsafeApplicationLifecycleServer struct {
+// MockApplicationLifecycle_ExecClient is a mock of ApplicationLifecycle_ExecClient interface.
+type MockApplicationLifecycle_ExecClient struct {
 	ctrl     *gomock.Controller
-	recorder *MockUnsafeApplicationLifecycleServerMockRecorder
+	recorder *MockApplicationLifecycle_ExecClientMockRecorder
 }
 
-// MockUnsafeApplicationLifecycleServerMockRecorder is the mock recorder for MockUnsafeApplicationLifecycleServer.
-type MockUnsafeApplicationLifecycleServerMockRecorder struct {
-	mock *MockUnsafeApplicationLifecycleServer
+// MockApplicationLifecycle_ExecClientMockRecorder is the mock recorder for MockApplicationLifecycle_ExecClient.
+type MockApplicationLifecycle_ExecClientMockRecorder struct {
+	mock *MockApplicationLifecycle_ExecClient
 }
 
-// NewMockUnsafeApplicationLifecycleServer creates a new mock instance.
-func NewMockUnsafeApplicationLifecycleServer(ctrl *gomock.Controller) *MockUnsafeApplicationLifecycleServer {
-	mock := &MockUnsafeApplicationLifecycleServer{ctrl: ctrl}
-	mock.recorder = &MockUnsafeApplicationLifecycleServerMockRecorder{mock}
+// NewMockApplicationLifecycle_ExecClient creates a new mock instance.
+func NewMockApplicationLifecycle_ExecClient(ctrl *gomock.Controller) *MockApplicationLifecycle_ExecClient {
+	mock := &MockApplicationLifecycle_ExecClient{ctrl: ctrl}
+	mock.recorder = &MockApplicationLifecycle_ExecClientMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockUnsafeApplicationLifecycleServer) EXPECT() *MockUnsafeApplicationLifecycleServerMockRecorder {
+func (m *MockApplicationLifecycle_ExecClient) EXPECT() *MockApplicationLifecycle_ExecClientMockRecorder {
 	return m.recorder
 }
 
-// mustEmbedUnimplementedApplicationLifecycleServer mocks base method.
-func (m *MockUnsafeApplicationLifecycleServer) mustEmbedUnimplementedApplicationLifecycleServer() {
+// CloseSend mocks base method.
+func (m *MockApplicationLifecycle_ExecClient) CloseSend() error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "mustEmbedUnimplementedApplicationLifecycleServer")
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// mustEmbedUnimplementedApplicationLifecycleServer indicates an expected call of mustEmbedUnimplementedApplicationLifecycleServer.
-func (mr *MockUnsafeApplicationLifecycleServerMockRecorder) mustEmbedUnimplementedApplicationLifecycleServer() *gomock.Call {
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockApplicationLifecycle_ExecClientMockRecorder) CloseSend() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedApplicationLifecycleServer", reflect.TypeOf((*MockUnsafeApplicationLifecycleServer)(nil).mustEmbedUnimplementedApplicationLifecycleServer))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockApplicationLifecycle_ExecClient)(nil).CloseSend))
 }
 
-// MockApplicationLifecycle_WatchApplicationHealthServer is a mock of ApplicationLifecycle_WatchApplicationHealthServer interface.
-type MockApplicationLifecycle_WatchApplicationHealthServer struct {
-	ctrl     *gomock.Controller
-	recorder *MockApplicationLifecycle_WatchApplicationHealthServerMockRecorder
+// Context mocks base method.
+func (m *MockApplicationLifecycle_ExecClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
 }
 
-// MockApplicationLifecycle_WatchApplicationHealthServerMockRecorder is the mock recorder for MockApplicationLifecycle_WatchApplicationHealthServer.
-type MockApplicationLifecycle_WatchApplicationHealthServerMockRecorder struct {
-	mock *MockApplicationLifecycle_WatchApplicationHealthServer
+// Context indicates an expected call of Context.
+func (mr *MockApplicationLifecycle_ExecClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockApplicationLifecycle_ExecClient)(nil).Context))
 }
 
-// NewMockApplicationLifecycle_WatchApplicationHealthServer creates a new mock instance.
-func NewMockApplicationLifecycle_WatchApplicationHealthServer(ctrl *gomock.Controller) *MockApplicationLifecycle_WatchApplicationHealthServer {
-	mock := &MockApplicationLifecycle_WatchApplicationHealthServer{ctrl: ctrl}
-	mock.recorder = &MockApplicationLifecycle_WatchApplicationHealthServerMockRecorder{mock}
-	return mock
+// Header mocks base method.
+func (m *MockApplicationLifecycle_ExecClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockApplicationLifecycle_WatchApplicationHealthServer) EXPECT() *MockApplicationLifecycle_WatchApplicationHealthServerMockRecorder {
-	return m.recorder
+// Header indicates an expected call of Header.
+func (mr *MockApplicationLifecycle_ExecClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockApplicationLifecycle_ExecClient)(nil).Header))
 }
 
-// Context mocks base method.
-func (m *MockApplicationLifecycle_WatchApplicationHealthServer) Context() context.Context {
+// Recv mocks base method.
+func (m *MockApplicationLifecycle_ExecClient) Recv() (*vkvmagent_v0.ExecResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Context")
-	ret0, _ := ret[0].(context.Context)
-	return ret0
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*vkvmagent_v0.ExecResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// Context indicates an expected call of Context.
-func (mr *MockApplicationLifecycle_WatchApplicationHealthServerMockRecorder) Context() *gomock.Call {
+// Recv indicates an expected call of Recv.
+func (mr *MockApplicationLifecycle_ExecClientMockRecorder) Recv() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockApplicationLifecycle_WatchApplicationHealthServer)(nil).Context))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockApplicationLifecycle_ExecClient)(nil).Recv))
 }
 
 // RecvMsg mocks base method.
-func (m_2 *MockApplicationLifecycle_WatchApplicationHealthServer) RecvMsg(m interface{}) error {
+func (m_2 *MockApplicationLifecycle_ExecClient) RecvMsg(m interface{}) error {
 	m_2.ctrl.T.Helper()
 	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
 	ret0, _ := ret[0].(error)
@@ -415,13 +553,13 @@ func (m_2 *MockApplicationLifecycle_WatchApplicationHealthServer) RecvMsg(m inte
 }
 
 // RecvMsg indicates an expected call of RecvMsg.
-func (mr *MockApplicationLifecycle_WatchApplicationHealthServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+func (mr *MockApplicationLifecycle_ExecClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockApplicationLifecycle_WatchApplicationHealthServer)(nil).RecvMsg), m)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockApplicationLifecycle_ExecClient)(nil).RecvMsg), m)
 }
 
 // Send mocks base method.
-func (m *MockApplicationLifecycle_WatchApplicationHealthServer) Send(arg0 *vkvmagent_v0.ApplicationHealthResponse) error {
+func (m *MockApplicationLifecycle_ExecClient) Send(arg0 *vkvmagent_v0.ExecRequest) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Send", arg0)
 	ret0, _ := ret[0].(error)
@@ -429,27 +567,13 @@ func (m *MockApplicationLifecycle_WatchApplicationHealthServer) Send(arg0 *vkvma
 }
 
 // Send indicates an expected call of Send.
-func (mr *MockApplicationLifecycle_WatchApplicationHealthServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockApplicationLifecycle_WatchApplicationHealthServer)(nil).Send), arg0)
-}
-
-// SendHeader mocks base method.
-func (m *MockApplicationLifecycle_WatchApplicationHealthServer) SendHeader(arg0 metadata.MD) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SendHeader", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// SendHeader indicates an expected call of SendHeader.
-func (mr *MockApplicationLifecycle_WatchApplicationHealthServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+func (mr *MockApplicationLifecycle_ExecClientMockRecorder) Send(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockApplicationLifecycle_WatchApplicationHealthServer)(nil).SendHeader), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockApplicationLifecycle_ExecClient)(nil).Send), arg0)
 }
 
 // SendMsg mocks base method.
-func (m_2 *MockApplicationLifecycle_WatchApplicationHealthServer) SendMsg(m interface{}) error {
+func (m_2 *MockApplicationLifecycle_ExecClient) SendMsg(m interface{}) error {
 	m_2.ctrl.T.Helper()
 	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
 	ret0, _ := ret[0].(error)
@@ -457,33 +581,836 @@ func (m_2 *MockApplicationLifecycle_WatchApplicationHealthServer) SendMsg(m inte
 }
 
 // SendMsg indicates an expected call of SendMsg.
-func (mr *MockApplicationLifecycle_WatchApplicationHealthServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+func (mr *MockApplicationLifecycle_ExecClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockApplicationLifecycle_WatchApplicationHealthServer)(nil).SendMsg), m)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockApplicationLifecycle_ExecClient)(nil).SendMsg), m)
 }
 
-// SetHeader mocks base method.
-func (m *MockApplicationLifecycle_WatchApplicationHealthServer) SetHeader(arg0 metadata.MD) error {
+// Trailer mocks base method.
+func (m *MockApplicationLifecycle_ExecClient) Trailer() metadata.MD {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetHeader", arg0)
-	ret0, _ := ret[0].(error)
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
 	return ret0
 }
 
-// SetHeader indicates an expected call of SetHeader.
-func (mr *MockApplicationLifecycle_WatchApplicationHealthServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+// Trailer indicates an expected call of Trailer.
+func (mr *MockApplicationLifecycle_ExecClientMockRecorder) Trailer() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockApplicationLifecycle_WatchApplicationHealthServer)(nil).SetHeader), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockApplicationLifecycle_ExecClient)(nil).Trailer))
 }
 
-// SetTrailer mocks base method.
-func (m *MockApplicationLifecycle_WatchApplicationHealthServer) SetTrailer(arg0 metadata.MD) {
-	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetTrailer", arg0)
+// MockApplicationLifecycle_WatchInstanceEventsClient is a mock of ApplicationLifecycle_WatchInstanceEventsClient interface.
+type MockApplicationLifecycle_WatchInstanceEventsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockApplicationLifecycle_WatchInstanceEventsClientMockRecorder
+}
+
+// MockApplicationLifecycle_WatchInstanceEventsClientMockRecorder is the mock recorder for MockApplicationLifecycle_WatchInstanceEventsClient.
+type MockApplicationLifecycle_WatchInstanceEventsClientMockRecorder struct {
+	mock *MockApplicationLifecycle_WatchInstanceEventsClient
+}
+
+// NewMockApplicationLifecycle_WatchInstanceEventsClient creates a new mock instance.
+func NewMockApplicationLifecycle_WatchInstanceEventsClient(ctrl *gomock.Controller) *MockApplicationLifecycle_WatchInstanceEventsClient {
+	mock := &MockApplicationLifecycle_WatchInstanceEventsClient{ctrl: ctrl}
+	mock.recorder = &MockApplicationLifecycle_WatchInstanceEventsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApplicationLifecycle_WatchInstanceEventsClient) EXPECT() *MockApplicationLifecycle_WatchInstanceEventsClientMockRecorder {
+	return m.recorder
+}
+
+// CloseSend mocks base method.
+func (m *MockApplicationLifecycle_WatchInstanceEventsClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockApplicationLifecycle_WatchInstanceEventsClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockApplicationLifecycle_WatchInstanceEventsClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockApplicationLifecycle_WatchInstanceEventsClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockApplicationLifecycle_WatchInstanceEventsClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockApplicationLifecycle_WatchInstanceEventsClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockApplicationLifecycle_WatchInstanceEventsClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockApplicationLifecycle_WatchInstanceEventsClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockApplicationLifecycle_WatchInstanceEventsClient)(nil).Header))
+}
+
+// Recv mocks base method.
+func (m *MockApplicationLifecycle_WatchInstanceEventsClient) Recv() (*vkvmagent_v0.InstanceEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*vkvmagent_v0.InstanceEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockApplicationLifecycle_WatchInstanceEventsClientMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockApplicationLifecycle_WatchInstanceEventsClient)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockApplicationLifecycle_WatchInstanceEventsClient) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockApplicationLifecycle_WatchInstanceEventsClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockApplicationLifecycle_WatchInstanceEventsClient)(nil).RecvMsg), m)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockApplicationLifecycle_WatchInstanceEventsClient) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockApplicationLifecycle_WatchInstanceEventsClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockApplicationLifecycle_WatchInstanceEventsClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockApplicationLifecycle_WatchInstanceEventsClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockApplicationLifecycle_WatchInstanceEventsClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockApplicationLifecycle_WatchInstanceEventsClient)(nil).Trailer))
+}
+
+// MockApplicationLifecycleServer is a mock of ApplicationLifecycleServer interface.
+type MockApplicationLifecycleServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockApplicationLifecycleServerMockRecorder
+}
+
+// MockApplicationLifecycleServerMockRecorder is the mock recorder for MockApplicationLifecycleServer.
+type MockApplicationLifecycleServerMockRecorder struct {
+	mock *MockApplicationLifecycleServer
+}
+
+// NewMockApplicationLifecycleServer creates a new mock instance.
+func NewMockApplicationLifecycleServer(ctrl *gomock.Controller) *MockApplicationLifecycleServer {
+	mock := &MockApplicationLifecycleServer{ctrl: ctrl}
+	mock.recorder = &MockApplicationLifecycleServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApplicationLifecycleServer) EXPECT() *MockApplicationLifecycleServerMockRecorder {
+	return m.recorder
+}
+
+// CheckApplicationHealth mocks base method.
+func (m *MockApplicationLifecycleServer) CheckApplicationHealth(arg0 context.Context, arg1 *vkvmagent_v0.ApplicationHealthRequest) (*vkvmagent_v0.ApplicationHealthResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckApplicationHealth", arg0, arg1)
+	ret0, _ := ret[0].(*vkvmagent_v0.ApplicationHealthResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckApplicationHealth indicates an expected call of CheckApplicationHealth.
+func (mr *MockApplicationLifecycleServerMockRecorder) CheckApplicationHealth(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckApplicationHealth", reflect.TypeOf((*MockApplicationLifecycleServer)(nil).CheckApplicationHealth), arg0, arg1)
+}
+
+// Exec mocks base method.
+func (m *MockApplicationLifecycleServer) Exec(arg0 vkvmagent_v0.ApplicationLifecycle_ExecServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exec", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Exec indicates an expected call of Exec.
+func (mr *MockApplicationLifecycleServerMockRecorder) Exec(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exec", reflect.TypeOf((*MockApplicationLifecycleServer)(nil).Exec), arg0)
+}
+
+// GetStats mocks base method.
+func (m *MockApplicationLifecycleServer) GetStats(arg0 context.Context, arg1 *vkvmagent_v0.GetStatsRequest) (*vkvmagent_v0.GetStatsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStats", arg0, arg1)
+	ret0, _ := ret[0].(*vkvmagent_v0.GetStatsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStats indicates an expected call of GetStats.
+func (mr *MockApplicationLifecycleServerMockRecorder) GetStats(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockApplicationLifecycleServer)(nil).GetStats), arg0, arg1)
+}
+
+// LaunchApplication mocks base method.
+func (m *MockApplicationLifecycleServer) LaunchApplication(arg0 context.Context, arg1 *vkvmagent_v0.LaunchApplicationRequest) (*vkvmagent_v0.LaunchApplicationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LaunchApplication", arg0, arg1)
+	ret0, _ := ret[0].(*vkvmagent_v0.LaunchApplicationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LaunchApplication indicates an expected call of LaunchApplication.
+func (mr *MockApplicationLifecycleServerMockRecorder) LaunchApplication(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LaunchApplication", reflect.TypeOf((*MockApplicationLifecycleServer)(nil).LaunchApplication), arg0, arg1)
+}
+
+// ResetInstance mocks base method.
+func (m *MockApplicationLifecycleServer) ResetInstance(arg0 context.Context, arg1 *vkvmagent_v0.ResetInstanceRequest) (*vkvmagent_v0.ResetInstanceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetInstance", arg0, arg1)
+	ret0, _ := ret[0].(*vkvmagent_v0.ResetInstanceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetInstance indicates an expected call of ResetInstance.
+func (mr *MockApplicationLifecycleServerMockRecorder) ResetInstance(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetInstance", reflect.TypeOf((*MockApplicationLifecycleServer)(nil).ResetInstance), arg0, arg1)
+}
+
+// StreamLogs mocks base method.
+func (m *MockApplicationLifecycleServer) StreamLogs(arg0 *vkvmagent_v0.StreamLogsRequest, arg1 vkvmagent_v0.ApplicationLifecycle_StreamLogsServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamLogs", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamLogs indicates an expected call of StreamLogs.
+func (mr *MockApplicationLifecycleServerMockRecorder) StreamLogs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamLogs", reflect.TypeOf((*MockApplicationLifecycleServer)(nil).StreamLogs), arg0, arg1)
+}
+
+// TerminateApplication mocks base method.
+func (m *MockApplicationLifecycleServer) TerminateApplication(arg0 context.Context, arg1 *vkvmagent_v0.TerminateApplicationRequest) (*vkvmagent_v0.TerminateApplicationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TerminateApplication", arg0, arg1)
+	ret0, _ := ret[0].(*vkvmagent_v0.TerminateApplicationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TerminateApplication indicates an expected call of TerminateApplication.
+func (mr *MockApplicationLifecycleServerMockRecorder) TerminateApplication(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TerminateApplication", reflect.TypeOf((*MockApplicationLifecycleServer)(nil).TerminateApplication), arg0, arg1)
+}
+
+// WatchApplicationHealth mocks base method.
+func (m *MockApplicationLifecycleServer) WatchApplicationHealth(arg0 *vkvmagent_v0.ApplicationHealthRequest, arg1 vkvmagent_v0.ApplicationLifecycle_WatchApplicationHealthServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchApplicationHealth", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WatchApplicationHealth indicates an expected call of WatchApplicationHealth.
+func (mr *MockApplicationLifecycleServerMockRecorder) WatchApplicationHealth(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchApplicationHealth", reflect.TypeOf((*MockApplicationLifecycleServer)(nil).WatchApplicationHealth), arg0, arg1)
+}
+
+// WatchInstanceEvents mocks base method.
+func (m *MockApplicationLifecycleServer) WatchInstanceEvents(arg0 *vkvmagent_v0.WatchInstanceEventsRequest, arg1 vkvmagent_v0.ApplicationLifecycle_WatchInstanceEventsServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchInstanceEvents", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WatchInstanceEvents indicates an expected call of WatchInstanceEvents.
+func (mr *MockApplicationLifecycleServerMockRecorder) WatchInstanceEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchInstanceEvents", reflect.TypeOf((*MockApplicationLifecycleServer)(nil).WatchInstanceEvents), arg0, arg1)
+}
+
+// mustEmbedUnimplementedApplicationLifecycleServer mocks base method.
+func (m *MockApplicationLifecycleServer) mustEmbedUnimplementedApplicationLifecycleServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedApplicationLifecycleServer")
+}
+
+// mustEmbedUnimplementedApplicationLifecycleServer indicates an expected call of mustEmbedUnimplementedApplicationLifecycleServer.
+func (mr *MockApplicationLifecycleServerMockRecorder) mustEmbedUnimplementedApplicationLifecycleServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedApplicationLifecycleServer", reflect.TypeOf((*MockApplicationLifecycleServer)(nil).mustEmbedUnimplementedApplicationLifecycleServer))
+}
+
+// MockUnsafeApplicationLifecycleServer is a mock of UnsafeApplicationLifecycleServer interface.
+type MockUnsafeApplicationLifecycleServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockUnsafeApplicationLifecycleServerMockRecorder
+}
+
+// MockUnsafeApplicationLifecycleServerMockRecorder is the mock recorder for MockUnsafeApplicationLifecycleServer.
+type MockUnsafeApplicationLifecycleServerMockRecorder struct {
+	mock *MockUnsafeApplicationLifecycleServer
+}
+
+// NewMockUnsafeApplicationLifecycleServer creates a new mock instance.
+func NewMockUnsafeApplicationLifecycleServer(ctrl *gomock.Controller) *MockUnsafeApplicationLifecycleServer {
+	mock := &MockUnsafeApplicationLifecycleServer{ctrl: ctrl}
+	mock.recorder = &MockUnsafeApplicationLifecycleServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUnsafeApplicationLifecycleServer) EXPECT() *MockUnsafeApplicationLifecycleServerMockRecorder {
+	return m.recorder
+}
+
+// mustEmbedUnimplementedApplicationLifecycleServer mocks base method.
+func (m *MockUnsafeApplicationLifecycleServer) mustEmbedUnimplementedApplicationLifecycleServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedApplicationLifecycleServer")
+}
+
+// mustEmbedUnimplementedApplicationLifecycleServer indicates an expected call of mustEmbedUnimplementedApplicationLifecycleServer.
+func (mr *MockUnsafeApplicationLifecycleServerMockRecorder) mustEmbedUnimplementedApplicationLifecycleServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedApplicationLifecycleServer", reflect.TypeOf((*MockUnsafeApplicationLifecycleServer)(nil).mustEmbedUnimplementedApplicationLifecycleServer))
+}
+
+// MockApplicationLifecycle_WatchApplicationHealthServer is a mock of ApplicationLifecycle_WatchApplicationHealthServer interface.
+type MockApplicationLifecycle_WatchApplicationHealthServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockApplicationLifecycle_WatchApplicationHealthServerMockRecorder
+}
+
+// MockApplicationLifecycle_WatchApplicationHealthServerMockRecorder is the mock recorder for MockApplicationLifecycle_WatchApplicationHealthServer.
+type MockApplicationLifecycle_WatchApplicationHealthServerMockRecorder struct {
+	mock *MockApplicationLifecycle_WatchApplicationHealthServer
+}
+
+// NewMockApplicationLifecycle_WatchApplicationHealthServer creates a new mock instance.
+func NewMockApplicationLifecycle_WatchApplicationHealthServer(ctrl *gomock.Controller) *MockApplicationLifecycle_WatchApplicationHealthServer {
+	mock := &MockApplicationLifecycle_WatchApplicationHealthServer{ctrl: ctrl}
+	mock.recorder = &MockApplicationLifecycle_WatchApplicationHealthServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApplicationLifecycle_WatchApplicationHealthServer) EXPECT() *MockApplicationLifecycle_WatchApplicationHealthServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockApplicationLifecycle_WatchApplicationHealthServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockApplicationLifecycle_WatchApplicationHealthServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockApplicationLifecycle_WatchApplicationHealthServer)(nil).Context))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockApplicationLifecycle_WatchApplicationHealthServer) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockApplicationLifecycle_WatchApplicationHealthServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockApplicationLifecycle_WatchApplicationHealthServer)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockApplicationLifecycle_WatchApplicationHealthServer) Send(arg0 *vkvmagent_v0.ApplicationHealthResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockApplicationLifecycle_WatchApplicationHealthServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockApplicationLifecycle_WatchApplicationHealthServer)(nil).Send), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockApplicationLifecycle_WatchApplicationHealthServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockApplicationLifecycle_WatchApplicationHealthServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockApplicationLifecycle_WatchApplicationHealthServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockApplicationLifecycle_WatchApplicationHealthServer) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockApplicationLifecycle_WatchApplicationHealthServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockApplicationLifecycle_WatchApplicationHealthServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockApplicationLifecycle_WatchApplicationHealthServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockApplicationLifecycle_WatchApplicationHealthServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockApplicationLifecycle_WatchApplicationHealthServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockApplicationLifecycle_WatchApplicationHealthServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockApplicationLifecycle_WatchApplicationHealthServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockApplicationLifecycle_WatchApplicationHealthServer)(nil).SetTrailer), arg0)
+}
+
+// MockApplicationLifecycle_StreamLogsServer is a mock of ApplicationLifecycle_StreamLogsServer interface.
+type MockApplicationLifecycle_StreamLogsServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockApplicationLifecycle_StreamLogsServerMockRecorder
+}
+
+// MockApplicationLifecycle_StreamLogsServerMockRecorder is the mock recorder for MockApplicationLifecycle_StreamLogsServer.
+type MockApplicationLifecycle_StreamLogsServerMockRecorder struct {
+	mock *MockApplicationLifecycle_StreamLogsServer
+}
+
+// NewMockApplicationLifecycle_StreamLogsServer creates a new mock instance.
+func NewMockApplicationLifecycle_StreamLogsServer(ctrl *gomock.Controller) *MockApplicationLifecycle_StreamLogsServer {
+	mock := &MockApplicationLifecycle_StreamLogsServer{ctrl: ctrl}
+	mock.recorder = &MockApplicationLifecycle_StreamLogsServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApplicationLifecycle_StreamLogsServer) EXPECT() *MockApplicationLifecycle_StreamLogsServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockApplicationLifecycle_StreamLogsServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockApplicationLifecycle_StreamLogsServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockApplicationLifecycle_StreamLogsServer)(nil).Context))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockApplicationLifecycle_StreamLogsServer) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockApplicationLifecycle_StreamLogsServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockApplicationLifecycle_StreamLogsServer)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockApplicationLifecycle_StreamLogsServer) Send(arg0 *vkvmagent_v0.StreamLogsResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockApplicationLifecycle_StreamLogsServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockApplicationLifecycle_StreamLogsServer)(nil).Send), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockApplicationLifecycle_StreamLogsServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockApplicationLifecycle_StreamLogsServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockApplicationLifecycle_StreamLogsServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockApplicationLifecycle_StreamLogsServer) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockApplicationLifecycle_StreamLogsServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockApplicationLifecycle_StreamLogsServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockApplicationLifecycle_StreamLogsServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockApplicationLifecycle_StreamLogsServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockApplicationLifecycle_StreamLogsServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockApplicationLifecycle_StreamLogsServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
 }
 
 // SetTrailer indicates an expected call of SetTrailer.
-func (mr *MockApplicationLifecycle_WatchApplicationHealthServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+func (mr *MockApplicationLifecycle_StreamLogsServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockApplicationLifecycle_WatchApplicationHealthServer)(nil).SetTrailer), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockApplicationLifecycle_StreamLogsServer)(nil).SetTrailer), arg0)
+}
+
+// MockApplicationLifecycle_ExecServer is a mock of ApplicationLifecycle_ExecServer interface.
+type MockApplicationLifecycle_ExecServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockApplicationLifecycle_ExecServerMockRecorder
+}
+
+// MockApplicationLifecycle_ExecServerMockRecorder is the mock recorder for MockApplicationLifecycle_ExecServer.
+type MockApplicationLifecycle_ExecServerMockRecorder struct {
+	mock *MockApplicationLifecycle_ExecServer
+}
+
+// NewMockApplicationLifecycle_ExecServer creates a new mock instance.
+func NewMockApplicationLifecycle_ExecServer(ctrl *gomock.Controller) *MockApplicationLifecycle_ExecServer {
+	mock := &MockApplicationLifecycle_ExecServer{ctrl: ctrl}
+	mock.recorder = &MockApplicationLifecycle_ExecServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApplicationLifecycle_ExecServer) EXPECT() *MockApplicationLifecycle_ExecServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockApplicationLifecycle_ExecServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockApplicationLifecycle_ExecServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockApplicationLifecycle_ExecServer)(nil).Context))
+}
+
+// Recv mocks base method.
+func (m *MockApplicationLifecycle_ExecServer) Recv() (*vkvmagent_v0.ExecRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*vkvmagent_v0.ExecRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockApplicationLifecycle_ExecServerMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockApplicationLifecycle_ExecServer)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockApplicationLifecycle_ExecServer) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockApplicationLifecycle_ExecServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockApplicationLifecycle_ExecServer)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockApplicationLifecycle_ExecServer) Send(arg0 *vkvmagent_v0.ExecResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockApplicationLifecycle_ExecServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockApplicationLifecycle_ExecServer)(nil).Send), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockApplicationLifecycle_ExecServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockApplicationLifecycle_ExecServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockApplicationLifecycle_ExecServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockApplicationLifecycle_ExecServer) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockApplicationLifecycle_ExecServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockApplicationLifecycle_ExecServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockApplicationLifecycle_ExecServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockApplicationLifecycle_ExecServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockApplicationLifecycle_ExecServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockApplicationLifecycle_ExecServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockApplicationLifecycle_ExecServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockApplicationLifecycle_ExecServer)(nil).SetTrailer), arg0)
+}
+
+// MockApplicationLifecycle_WatchInstanceEventsServer is a mock of ApplicationLifecycle_WatchInstanceEventsServer interface.
+type MockApplicationLifecycle_WatchInstanceEventsServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockApplicationLifecycle_WatchInstanceEventsServerMockRecorder
+}
+
+// MockApplicationLifecycle_WatchInstanceEventsServerMockRecorder is the mock recorder for MockApplicationLifecycle_WatchInstanceEventsServer.
+type MockApplicationLifecycle_WatchInstanceEventsServerMockRecorder struct {
+	mock *MockApplicationLifecycle_WatchInstanceEventsServer
+}
+
+// NewMockApplicationLifecycle_WatchInstanceEventsServer creates a new mock instance.
+func NewMockApplicationLifecycle_WatchInstanceEventsServer(ctrl *gomock.Controller) *MockApplicationLifecycle_WatchInstanceEventsServer {
+	mock := &MockApplicationLifecycle_WatchInstanceEventsServer{ctrl: ctrl}
+	mock.recorder = &MockApplicationLifecycle_WatchInstanceEventsServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApplicationLifecycle_WatchInstanceEventsServer) EXPECT() *MockApplicationLifecycle_WatchInstanceEventsServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockApplicationLifecycle_WatchInstanceEventsServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockApplicationLifecycle_WatchInstanceEventsServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockApplicationLifecycle_WatchInstanceEventsServer)(nil).Context))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockApplicationLifecycle_WatchInstanceEventsServer) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockApplicationLifecycle_WatchInstanceEventsServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockApplicationLifecycle_WatchInstanceEventsServer)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockApplicationLifecycle_WatchInstanceEventsServer) Send(arg0 *vkvmagent_v0.InstanceEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockApplicationLifecycle_WatchInstanceEventsServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockApplicationLifecycle_WatchInstanceEventsServer)(nil).Send), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockApplicationLifecycle_WatchInstanceEventsServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockApplicationLifecycle_WatchInstanceEventsServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockApplicationLifecycle_WatchInstanceEventsServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockApplicationLifecycle_WatchInstanceEventsServer) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockApplicationLifecycle_WatchInstanceEventsServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockApplicationLifecycle_WatchInstanceEventsServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockApplicationLifecycle_WatchInstanceEventsServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockApplicationLifecycle_WatchInstanceEventsServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockApplicationLifecycle_WatchInstanceEventsServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockApplicationLifecycle_WatchInstanceEventsServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockApplicationLifecycle_WatchInstanceEventsServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockApplicationLifecycle_WatchInstanceEventsServer)(nil).SetTrailer), arg0)
 }
@@ -5,11 +5,13 @@ package mock_vkvmaclient
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 
 	grpc_health_v1 "github.com/aws/aws-virtual-kubelet/proto/grpc/health/v1"
 	vkvmagent_v0 "github.com/aws/aws-virtual-kubelet/proto/vkvmagent/v0"
 	gomock "github.com/golang/mock/gomock"
+	api "github.com/virtual-kubelet/virtual-kubelet/node/api"
 	grpc "google.golang.org/grpc"
 )
 
@@ -51,6 +53,20 @@ func (mr *MockGrpcClientMockRecorder) Connect(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Connect", reflect.TypeOf((*MockGrpcClient)(nil).Connect), ctx)
 }
 
+// ExecInContainer mocks base method.
+func (m *MockGrpcClient) ExecInContainer(ctx context.Context, containerName string, cmd []string, attach api.AttachIO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecInContainer", ctx, containerName, cmd, attach)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExecInContainer indicates an expected call of ExecInContainer.
+func (mr *MockGrpcClientMockRecorder) ExecInContainer(ctx, containerName, cmd, attach interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecInContainer", reflect.TypeOf((*MockGrpcClient)(nil).ExecInContainer), ctx, containerName, cmd, attach)
+}
+
 // GetApplicationLifecycleClient mocks base method.
 func (m *MockGrpcClient) GetApplicationLifecycleClient(ctx context.Context) (vkvmagent_v0.ApplicationLifecycleClient, error) {
 	m.ctrl.T.Helper()
@@ -66,6 +82,21 @@ func (mr *MockGrpcClientMockRecorder) GetApplicationLifecycleClient(ctx interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApplicationLifecycleClient", reflect.TypeOf((*MockGrpcClient)(nil).GetApplicationLifecycleClient), ctx)
 }
 
+// GetContainerLogs mocks base method.
+func (m *MockGrpcClient) GetContainerLogs(ctx context.Context, containerName string, opts api.ContainerLogOpts) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContainerLogs", ctx, containerName, opts)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContainerLogs indicates an expected call of GetContainerLogs.
+func (mr *MockGrpcClientMockRecorder) GetContainerLogs(ctx, containerName, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContainerLogs", reflect.TypeOf((*MockGrpcClient)(nil).GetContainerLogs), ctx, containerName, opts)
+}
+
 // GetHealthClient mocks base method.
 func (m *MockGrpcClient) GetHealthClient(ctx context.Context) (grpc_health_v1.HealthClient, error) {
 	m.ctrl.T.Helper()
@@ -81,6 +112,49 @@ func (mr *MockGrpcClientMockRecorder) GetHealthClient(ctx interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHealthClient", reflect.TypeOf((*MockGrpcClient)(nil).GetHealthClient), ctx)
 }
 
+// GetStats mocks base method.
+func (m *MockGrpcClient) GetStats(ctx context.Context) (*vkvmagent_v0.GetStatsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStats", ctx)
+	ret0, _ := ret[0].(*vkvmagent_v0.GetStatsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStats indicates an expected call of GetStats.
+func (mr *MockGrpcClientMockRecorder) GetStats(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockGrpcClient)(nil).GetStats), ctx)
+}
+
+// ResetInstance mocks base method.
+func (m *MockGrpcClient) ResetInstance(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetInstance", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetInstance indicates an expected call of ResetInstance.
+func (mr *MockGrpcClientMockRecorder) ResetInstance(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetInstance", reflect.TypeOf((*MockGrpcClient)(nil).ResetInstance), ctx)
+}
+
+// WatchInstanceEvents mocks base method.
+func (m *MockGrpcClient) WatchInstanceEvents(ctx context.Context, handler func(*vkvmagent_v0.InstanceEvent)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchInstanceEvents", ctx, handler)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WatchInstanceEvents indicates an expected call of WatchInstanceEvents.
+func (mr *MockGrpcClientMockRecorder) WatchInstanceEvents(ctx, handler interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchInstanceEvents", reflect.TypeOf((*MockGrpcClient)(nil).WatchInstanceEvents), ctx, handler)
+}
+
 // IsConnected mocks base method.
 func (m *MockGrpcClient) IsConnected(ctx context.Context) bool {
 	m.ctrl.T.Helper()
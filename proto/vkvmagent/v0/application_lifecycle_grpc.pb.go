@@ -26,6 +26,23 @@ type ApplicationLifecycleClient interface {
 	TerminateApplication(ctx context.Context, in *TerminateApplicationRequest, opts ...grpc.CallOption) (*TerminateApplicationResponse, error)
 	CheckApplicationHealth(ctx context.Context, in *ApplicationHealthRequest, opts ...grpc.CallOption) (*ApplicationHealthResponse, error)
 	WatchApplicationHealth(ctx context.Context, in *ApplicationHealthRequest, opts ...grpc.CallOption) (ApplicationLifecycle_WatchApplicationHealthClient, error)
+	// StreamLogs sends the log output of a container in chunks.  When `follow` is set the stream stays open and new
+	// output is sent as it is produced, otherwise the stream ends once the existing output has been sent.
+	StreamLogs(ctx context.Context, in *StreamLogsRequest, opts ...grpc.CallOption) (ApplicationLifecycle_StreamLogsClient, error)
+	// Exec runs a command in a container.  The first request on the stream must be an `ExecStart`, subsequent requests
+	// carry stdin and terminal resize events.  The agent streams stdout/stderr back and ends with an `ExecExit`.
+	Exec(ctx context.Context, opts ...grpc.CallOption) (ApplicationLifecycle_ExecClient, error)
+	// GetStats reports current resource usage for the instance and each of its containers.  Stats that the agent can't
+	// collect are left unset.
+	GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error)
+	// ResetInstance scrubs the instance after its pod is deleted so it can be reused for another pod (e.g. stopping any
+	// remaining processes and removing the previous application's files and users).  The response is sent once the
+	// instance is ready for reuse; an error means the instance must not be reused.
+	ResetInstance(ctx context.Context, in *ResetInstanceRequest, opts ...grpc.CallOption) (*ResetInstanceResponse, error)
+	// WatchInstanceEvents streams notices about the instance that affect its pod (e.g. a spot interruption notice read
+	// from the instance metadata service).  Each notice is sent once per stream, and the stream stays open until the
+	// provider cancels it.
+	WatchInstanceEvents(ctx context.Context, in *WatchInstanceEventsRequest, opts ...grpc.CallOption) (ApplicationLifecycle_WatchInstanceEventsClient, error)
 }
 
 type applicationLifecycleClient struct {
@@ -95,6 +112,119 @@ func (x *applicationLifecycleWatchApplicationHealthClient) Recv() (*ApplicationH
 	return m, nil
 }
 
+func (c *applicationLifecycleClient) StreamLogs(ctx context.Context, in *StreamLogsRequest, opts ...grpc.CallOption) (ApplicationLifecycle_StreamLogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ApplicationLifecycle_ServiceDesc.Streams[1], "/vkvmagent.v0.ApplicationLifecycle/StreamLogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &applicationLifecycleStreamLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ApplicationLifecycle_StreamLogsClient interface {
+	Recv() (*StreamLogsResponse, error)
+	grpc.ClientStream
+}
+
+type applicationLifecycleStreamLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *applicationLifecycleStreamLogsClient) Recv() (*StreamLogsResponse, error) {
+	m := new(StreamLogsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *applicationLifecycleClient) Exec(ctx context.Context, opts ...grpc.CallOption) (ApplicationLifecycle_ExecClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ApplicationLifecycle_ServiceDesc.Streams[2], "/vkvmagent.v0.ApplicationLifecycle/Exec", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &applicationLifecycleExecClient{stream}
+	return x, nil
+}
+
+type ApplicationLifecycle_ExecClient interface {
+	Send(*ExecRequest) error
+	Recv() (*ExecResponse, error)
+	grpc.ClientStream
+}
+
+type applicationLifecycleExecClient struct {
+	grpc.ClientStream
+}
+
+func (x *applicationLifecycleExecClient) Send(m *ExecRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *applicationLifecycleExecClient) Recv() (*ExecResponse, error) {
+	m := new(ExecResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *applicationLifecycleClient) GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error) {
+	out := new(GetStatsResponse)
+	err := c.cc.Invoke(ctx, "/vkvmagent.v0.ApplicationLifecycle/GetStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationLifecycleClient) ResetInstance(ctx context.Context, in *ResetInstanceRequest, opts ...grpc.CallOption) (*ResetInstanceResponse, error) {
+	out := new(ResetInstanceResponse)
+	err := c.cc.Invoke(ctx, "/vkvmagent.v0.ApplicationLifecycle/ResetInstance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationLifecycleClient) WatchInstanceEvents(ctx context.Context, in *WatchInstanceEventsRequest, opts ...grpc.CallOption) (ApplicationLifecycle_WatchInstanceEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ApplicationLifecycle_ServiceDesc.Streams[3], "/vkvmagent.v0.ApplicationLifecycle/WatchInstanceEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &applicationLifecycleWatchInstanceEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ApplicationLifecycle_WatchInstanceEventsClient interface {
+	Recv() (*InstanceEvent, error)
+	grpc.ClientStream
+}
+
+type applicationLifecycleWatchInstanceEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *applicationLifecycleWatchInstanceEventsClient) Recv() (*InstanceEvent, error) {
+	m := new(InstanceEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ApplicationLifecycleServer is the server API for ApplicationLifecycle service.
 // All implementations must embed UnimplementedApplicationLifecycleServer
 // for forward compatibility
@@ -103,6 +233,23 @@ type ApplicationLifecycleServer interface {
 	TerminateApplication(context.Context, *TerminateApplicationRequest) (*TerminateApplicationResponse, error)
 	CheckApplicationHealth(context.Context, *ApplicationHealthRequest) (*ApplicationHealthResponse, error)
 	WatchApplicationHealth(*ApplicationHealthRequest, ApplicationLifecycle_WatchApplicationHealthServer) error
+	// StreamLogs sends the log output of a container in chunks.  When `follow` is set the stream stays open and new
+	// output is sent as it is produced, otherwise the stream ends once the existing output has been sent.
+	StreamLogs(*StreamLogsRequest, ApplicationLifecycle_StreamLogsServer) error
+	// Exec runs a command in a container.  The first request on the stream must be an `ExecStart`, subsequent requests
+	// carry stdin and terminal resize events.  The agent streams stdout/stderr back and ends with an `ExecExit`.
+	Exec(ApplicationLifecycle_ExecServer) error
+	// GetStats reports current resource usage for the instance and each of its containers.  Stats that the agent can't
+	// collect are left unset.
+	GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error)
+	// ResetInstance scrubs the instance after its pod is deleted so it can be reused for another pod (e.g. stopping any
+	// remaining processes and removing the previous application's files and users).  The response is sent once the
+	// instance is ready for reuse; an error means the instance must not be reused.
+	ResetInstance(context.Context, *ResetInstanceRequest) (*ResetInstanceResponse, error)
+	// WatchInstanceEvents streams notices about the instance that affect its pod (e.g. a spot interruption notice read
+	// from the instance metadata service).  Each notice is sent once per stream, and the stream stays open until the
+	// provider cancels it.
+	WatchInstanceEvents(*WatchInstanceEventsRequest, ApplicationLifecycle_WatchInstanceEventsServer) error
 	mustEmbedUnimplementedApplicationLifecycleServer()
 }
 
@@ -122,6 +269,21 @@ func (UnimplementedApplicationLifecycleServer) CheckApplicationHealth(context.Co
 func (UnimplementedApplicationLifecycleServer) WatchApplicationHealth(*ApplicationHealthRequest, ApplicationLifecycle_WatchApplicationHealthServer) error {
 	return status.Errorf(codes.Unimplemented, "method WatchApplicationHealth not implemented")
 }
+func (UnimplementedApplicationLifecycleServer) StreamLogs(*StreamLogsRequest, ApplicationLifecycle_StreamLogsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamLogs not implemented")
+}
+func (UnimplementedApplicationLifecycleServer) Exec(ApplicationLifecycle_ExecServer) error {
+	return status.Errorf(codes.Unimplemented, "method Exec not implemented")
+}
+func (UnimplementedApplicationLifecycleServer) GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
+}
+func (UnimplementedApplicationLifecycleServer) ResetInstance(context.Context, *ResetInstanceRequest) (*ResetInstanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetInstance not implemented")
+}
+func (UnimplementedApplicationLifecycleServer) WatchInstanceEvents(*WatchInstanceEventsRequest, ApplicationLifecycle_WatchInstanceEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchInstanceEvents not implemented")
+}
 func (UnimplementedApplicationLifecycleServer) mustEmbedUnimplementedApplicationLifecycleServer() {}
 
 // UnsafeApplicationLifecycleServer may be embedded to opt out of forward compatibility for this service.
@@ -210,6 +372,110 @@ func (x *applicationLifecycleWatchApplicationHealthServer) Send(m *ApplicationHe
 	return x.ServerStream.SendMsg(m)
 }
 
+func _ApplicationLifecycle_StreamLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ApplicationLifecycleServer).StreamLogs(m, &applicationLifecycleStreamLogsServer{stream})
+}
+
+type ApplicationLifecycle_StreamLogsServer interface {
+	Send(*StreamLogsResponse) error
+	grpc.ServerStream
+}
+
+type applicationLifecycleStreamLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *applicationLifecycleStreamLogsServer) Send(m *StreamLogsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ApplicationLifecycle_Exec_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ApplicationLifecycleServer).Exec(&applicationLifecycleExecServer{stream})
+}
+
+type ApplicationLifecycle_ExecServer interface {
+	Send(*ExecResponse) error
+	Recv() (*ExecRequest, error)
+	grpc.ServerStream
+}
+
+type applicationLifecycleExecServer struct {
+	grpc.ServerStream
+}
+
+func (x *applicationLifecycleExecServer) Send(m *ExecResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *applicationLifecycleExecServer) Recv() (*ExecRequest, error) {
+	m := new(ExecRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _ApplicationLifecycle_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationLifecycleServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vkvmagent.v0.ApplicationLifecycle/GetStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationLifecycleServer).GetStats(ctx, req.(*GetStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationLifecycle_ResetInstance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetInstanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationLifecycleServer).ResetInstance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vkvmagent.v0.ApplicationLifecycle/ResetInstance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationLifecycleServer).ResetInstance(ctx, req.(*ResetInstanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationLifecycle_WatchInstanceEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchInstanceEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ApplicationLifecycleServer).WatchInstanceEvents(m, &applicationLifecycleWatchInstanceEventsServer{stream})
+}
+
+type ApplicationLifecycle_WatchInstanceEventsServer interface {
+	Send(*InstanceEvent) error
+	grpc.ServerStream
+}
+
+type applicationLifecycleWatchInstanceEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *applicationLifecycleWatchInstanceEventsServer) Send(m *InstanceEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // ApplicationLifecycle_ServiceDesc is the grpc.ServiceDesc for ApplicationLifecycle service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -229,6 +495,14 @@ var ApplicationLifecycle_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CheckApplicationHealth",
 			Handler:    _ApplicationLifecycle_CheckApplicationHealth_Handler,
 		},
+		{
+			MethodName: "GetStats",
+			Handler:    _ApplicationLifecycle_GetStats_Handler,
+		},
+		{
+			MethodName: "ResetInstance",
+			Handler:    _ApplicationLifecycle_ResetInstance_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -236,6 +510,22 @@ var ApplicationLifecycle_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _ApplicationLifecycle_WatchApplicationHealth_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "StreamLogs",
+			Handler:       _ApplicationLifecycle_StreamLogs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Exec",
+			Handler:       _ApplicationLifecycle_Exec_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "WatchInstanceEvents",
+			Handler:       _ApplicationLifecycle_WatchInstanceEvents_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "vkvmagent/v0/application_lifecycle.proto",
 }